@@ -116,6 +116,10 @@ func (chainNode *ChainNode) GetPersistenceInitTimeout() time.Duration {
 			return d
 		}
 	}
+	// Downloading and extracting a tarball takes far longer than a regular init.
+	if chainNode.ShouldRestoreFromTarball() {
+		return 24 * time.Hour
+	}
 	return 5 * time.Minute
 }
 
@@ -130,10 +134,19 @@ func (chainNode *ChainNode) SnapshotsEnabled() bool {
 	return chainNode.Spec.Persistence != nil && chainNode.Spec.Persistence.Snapshots != nil
 }
 
+// ShouldSnapshotBeforeUpgrade returns true if a volume snapshot must be taken before upgrading the node.
+func (chainNode *ChainNode) ShouldSnapshotBeforeUpgrade() bool {
+	return chainNode.Spec.Persistence != nil && ptr.Deref(chainNode.Spec.Persistence.SnapshotBeforeUpgrade, false)
+}
+
 func (chainNode *ChainNode) ShouldRestoreFromSnapshot() bool {
 	return chainNode.Spec.Persistence != nil && chainNode.Spec.Persistence.RestoreFromSnapshot != nil
 }
 
+func (chainNode *ChainNode) ShouldRestoreFromTarball() bool {
+	return chainNode.Spec.Persistence != nil && chainNode.Spec.Persistence.RestoreFromTarball != nil
+}
+
 func (chainNode *ChainNode) IsValidator() bool {
 	return chainNode.Spec.Validator != nil
 }
@@ -201,6 +214,63 @@ func (chainNode *ChainNode) ShouldCreateValidator() bool {
 	return chainNode.Spec.Validator != nil && chainNode.Spec.Validator.CreateValidator != nil && chainNode.Status.ValidatorStatus == ""
 }
 
+// ShouldEditValidator returns true if cosmopilot manages the validator account and should reconcile
+// the on-chain description and commission rate of an existing validator.
+func (chainNode *ChainNode) ShouldEditValidator() bool {
+	return chainNode.Spec.Validator != nil && chainNode.Spec.Validator.CreateValidator != nil && chainNode.Status.ValidatorAddress != ""
+}
+
+// ShouldCheckBalances returns true if the balances of the validator account should be checked against
+// the configured minimums.
+func (chainNode *ChainNode) ShouldCheckBalances() bool {
+	return chainNode.Spec.Validator != nil && chainNode.Spec.Validator.LowBalance != nil
+}
+
+// ShouldUseSigningLease returns true if the validator pod must hold the signing lease before starting the
+// application. Nodes signing through cosmosigner do not hold any key, so they never use it.
+func (chainNode *ChainNode) ShouldUseSigningLease() bool {
+	if !chainNode.IsValidator() || chainNode.IsSignerTarget() {
+		return false
+	}
+	if chainNode.Spec.Validator.SigningLease != nil {
+		return *chainNode.Spec.Validator.SigningLease
+	}
+	return true
+}
+
+// ShouldTrackSigningState returns true if the signing state of the validator is kept in its data volume, and
+// should therefore be tracked and restored when it goes back. Remote signers keep track of it themselves.
+func (chainNode *ChainNode) ShouldTrackSigningState() bool {
+	return chainNode.IsValidator() && !chainNode.UsesRemoteSigner()
+}
+
+// ShouldCheckDoubleSign returns true if recent blocks should be checked for signatures of the validator before
+// it starts. Only validators keeping their key in the pod are checked.
+func (chainNode *ChainNode) ShouldCheckDoubleSign() bool {
+	return chainNode.ShouldTrackSigningState() && chainNode.Spec.Validator.DoubleSignCheck != nil
+}
+
+// GetSigningLeaseName returns the name of the Lease held by the validator pod while it is allowed to sign.
+func (chainNode *ChainNode) GetSigningLeaseName() string {
+	return fmt.Sprintf("%s-signing", chainNode.GetName())
+}
+
+// ShouldAutoRestake returns true if rewards and commission of an existing validator should be
+// periodically withdrawn and restaked.
+func (chainNode *ChainNode) ShouldAutoRestake() bool {
+	return chainNode.Spec.Validator != nil && chainNode.Spec.Validator.AutoRestake != nil && chainNode.Status.ValidatorAddress != ""
+}
+
+// ShouldTrackProposals returns true if governance proposals should be tracked and voted on with the
+// validator account.
+func (chainNode *ChainNode) ShouldTrackProposals() bool {
+	return chainNode.Spec.Validator != nil && chainNode.Spec.Validator.Governance != nil
+}
+
+func (chainNode *ChainNode) ShouldAutoUnjail() bool {
+	return chainNode.Spec.Validator != nil && chainNode.Spec.Validator.AutoUnjail != nil
+}
+
 func (chainNode *ChainNode) RequiresPrivKey() bool {
 	if !chainNode.IsValidator() {
 		return false
@@ -416,6 +486,12 @@ func (val *ValidatorConfig) GetValPrefix() string {
 	return DefaultValPrefix
 }
 
+// GetConsPrefix returns the bech32 prefix for consensus addresses, derived from the validator
+// operator prefix (e.g. `cosmosvaloper` becomes `cosmosvalcons`).
+func (val *ValidatorConfig) GetConsPrefix() string {
+	return strings.TrimSuffix(val.GetValPrefix(), "valoper") + "valcons"
+}
+
 func (val *ValidatorConfig) GetInitUnbondingTime() string {
 	if val.Init != nil && val.Init.UnbondingTime != nil {
 		return *val.Init.UnbondingTime
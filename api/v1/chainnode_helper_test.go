@@ -57,6 +57,15 @@ func TestValidatorConfigGetAccountSecretName(t *testing.T) {
 	}
 }
 
+func TestValidatorConfigGetConsPrefix(t *testing.T) {
+	var nilConfig *ValidatorConfig
+	assert.Equal(t, "cosmosvalcons", nilConfig.GetConsPrefix())
+	assert.Equal(t, "osmovalcons", (&ValidatorConfig{ValPrefix: ptr.To("osmovaloper")}).GetConsPrefix())
+	assert.Equal(t, "nibivalcons", (&ValidatorConfig{
+		CreateValidator: &CreateValidatorConfig{ValPrefix: ptr.To("nibivaloper")},
+	}).GetConsPrefix())
+}
+
 func TestChainNodeIsReady(t *testing.T) {
 	stopsForSnapshots := &Persistence{Snapshots: &VolumeSnapshotsConfig{StopNode: ptr.To(true)}}
 
@@ -97,3 +106,22 @@ func TestChainNodeIsReady(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotHeightScheduleNextHeight(t *testing.T) {
+	interval := &SnapshotHeightSchedule{Interval: ptr.To[int64](100)}
+	assert.Equal(t, int64(1100), interval.NextHeight(1000))
+	assert.Equal(t, int64(1100), interval.NextHeight(1099))
+	assert.Equal(t, int64(1200), interval.NextHeight(1100))
+
+	heights := &SnapshotHeightSchedule{Heights: []int64{2000, 500}}
+	assert.Equal(t, int64(500), heights.NextHeight(499))
+	assert.Equal(t, int64(2000), heights.NextHeight(500))
+	assert.Zero(t, heights.NextHeight(2000))
+
+	both := &SnapshotHeightSchedule{Interval: ptr.To[int64](1000), Heights: []int64{1500}}
+	assert.Equal(t, int64(1500), both.NextHeight(1200))
+	assert.Equal(t, int64(2000), both.NextHeight(1500))
+
+	var nilSchedule *SnapshotHeightSchedule
+	assert.Zero(t, nilSchedule.NextHeight(0))
+}
@@ -53,9 +53,45 @@ const (
 	ConditionUpgrade = "Upgrade"
 	// ConditionSnapshotExportCleanup indicates that exported snapshot data requires operator cleanup.
 	ConditionSnapshotExportCleanup = "SnapshotExportCleanup"
+	// ConditionValidatorMissedBlocks indicates whether the validator missed more blocks than the configured threshold.
+	ConditionValidatorMissedBlocks = "ValidatorMissedBlocks"
+	// ConditionLowBalance indicates whether a balance of the validator account is below its configured minimum.
+	ConditionLowBalance = "LowBalance"
+	// ConditionValidatorKeyInUse indicates whether the validator key was found signing recent blocks while this
+	// node was not running.
+	ConditionValidatorKeyInUse = "ValidatorKeyInUse"
+	// ConditionUpgradeImagePullFailed indicates whether the image of the next scheduled upgrade could not be
+	// pre-pulled.
+	ConditionUpgradeImagePullFailed = "UpgradeImagePullFailed"
+	// ConditionCommissionAboveMaxRate indicates whether the commission rate in spec is above the max rate of the
+	// on-chain validator, in which case only the validator description is reconciled.
+	ConditionCommissionAboveMaxRate = "CommissionAboveMaxRate"
+	// ConditionValidatorEditFailed indicates whether the last edit-validator transaction submitted by cosmopilot
+	// failed.
+	ConditionValidatorEditFailed = "ValidatorEditFailed"
 
 	// ReasonUpgradeSuccess indicates that the upgrade completed successfully.
 	ReasonUpgradeSuccess = "UpgradeSuccessful"
+	// ReasonMissedBlocksAboveThreshold indicates that missed blocks reached the configured threshold.
+	ReasonMissedBlocksAboveThreshold = "MissedBlocksAboveThreshold"
+	// ReasonMissedBlocksBelowThreshold indicates that missed blocks are below the configured threshold.
+	ReasonMissedBlocksBelowThreshold = "MissedBlocksBelowThreshold"
+	// ReasonBalanceBelowMinimum indicates that a balance of the validator account is below its minimum.
+	ReasonBalanceBelowMinimum = "BalanceBelowMinimum"
+	// ReasonBalanceAboveMinimum indicates that all balances of the validator account are at or above their minimum.
+	ReasonBalanceAboveMinimum = "BalanceAboveMinimum"
+	// ReasonInvalidMinBalances indicates that the minimum balances are invalid, so balances are not checked.
+	ReasonInvalidMinBalances = "InvalidMinBalances"
+	// ReasonKeySigningElsewhere indicates that the validator key signed recent blocks while this node was not running.
+	ReasonKeySigningElsewhere = "KeySigningElsewhere"
+	// ReasonKeyNotSigningElsewhere indicates that the validator key did not sign any of the recent blocks checked.
+	ReasonKeyNotSigningElsewhere = "KeyNotSigningElsewhere"
+	// ReasonDoubleSignCheckFailed indicates that recent blocks could not be checked for signatures of the validator.
+	ReasonDoubleSignCheckFailed = "DoubleSignCheckFailed"
+	// ReasonRateAboveMaxRate indicates that the commission rate in spec is above the max rate of the validator.
+	ReasonRateAboveMaxRate = "RateAboveMaxRate"
+	// ReasonRateWithinMaxRate indicates that the commission rate in spec is within the max rate of the validator.
+	ReasonRateWithinMaxRate = "RateWithinMaxRate"
 )
 
 //+kubebuilder:object:root=true
@@ -188,6 +224,11 @@ type ChainNodeSpec struct {
 	// Mutually exclusive with ingress.
 	// +optional
 	Gateway *GatewayConfig `json:"gateway,omitempty"`
+
+	// Creates Prometheus Operator monitors for the node and a PrometheusRule with default alerts.
+	// Requires the Prometheus Operator CRDs to be installed.
+	// +optional
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
 }
 
 // ChainNodeStatus defines the observed state of ChainNode
@@ -239,6 +280,39 @@ type ChainNodeStatus struct {
 	// +optional
 	Jailed bool `json:"jailed,omitempty"`
 
+	// Slashing signing info of this validator, including missed blocks and tombstone state.
+	// Omitted when not a validator.
+	// +optional
+	SigningInfo *ValidatorSigningInfo `json:"signingInfo,omitempty"`
+
+	// Values applied by the last edit-validator transaction submitted by cosmopilot to reconcile the
+	// validator description and commission rate with the ones in spec.
+	// +optional
+	LastValidatorEdit *ValidatorEditStatus `json:"lastValidatorEdit,omitempty"`
+
+	// Governance proposals in voting period and the vote of the validator account on each of them. Only
+	// reported when `.spec.validator.governance` is set.
+	// +optional
+	Proposals []GovernanceProposal `json:"proposals,omitempty"`
+
+	// Auto-restake run that has not finished yet.
+	// +optional
+	RestakeInProgress *RestakeRun `json:"restakeInProgress,omitempty"`
+
+	// Most recent auto-restake runs, oldest first. Only the last 10 runs are kept.
+	// +optional
+	Restakes []RestakeRun `json:"restakes,omitempty"`
+
+	// Balances of the validator account in the denoms of `.spec.validator.lowBalance.minBalances`.
+	// +optional
+	Balances []string `json:"balances,omitempty"`
+
+	// Highest signing state of the validator observed in `priv_validator_state.json`. It is restored before the
+	// node starts whenever the state on disk is behind it (e.g. after restoring data from a snapshot), so that the
+	// validator never signs again at a height it might already have signed.
+	// +optional
+	SigningState *SigningState `json:"signingState,omitempty"`
+
 	// GenesisSigningDigest is a controller-internal fingerprint of the genesis-initializing validator's
 	// signing material and init config, recorded when this node initializes genesis. It lets the
 	// no-webhook reconcile path reject post-genesis changes to .spec.validator.init without a previous
@@ -403,4 +477,42 @@ type ValidatorConfig struct {
 	// +optional
 	// +default="cosmosvaloper"
 	ValPrefix *string `json:"valPrefix,omitempty"`
+
+	// Enables alerting when this validator misses blocks. Signing info is always reported in status,
+	// but events and conditions are only emitted when this is set.
+	// +optional
+	MissedBlocks *MissedBlocksConfig `json:"missedBlocks,omitempty"`
+
+	// Enables automatic unjailing of this validator when it gets jailed for downtime. The unjail
+	// transaction is signed with the validator account. Tombstoned validators are never unjailed.
+	// +optional
+	AutoUnjail *AutoUnjailConfig `json:"autoUnjail,omitempty"`
+
+	// Enables tracking of governance proposals in voting period and voting on them according to the
+	// configured policy. Votes are signed with the validator account.
+	// +optional
+	Governance *GovernanceConfig `json:"governance,omitempty"`
+
+	// Enables periodic withdrawal of the rewards and commission of this validator and their delegation back
+	// to it, keeping a reserve for fees. Transactions are signed with the validator account.
+	// +optional
+	AutoRestake *AutoRestakeConfig `json:"autoRestake,omitempty"`
+
+	// Enables alerting when the balance of the validator account drops below a minimum, so that transactions
+	// signed with it (create-validator, unjail, votes and restakes) do not fail for lack of fees.
+	// +optional
+	LowBalance *LowBalanceConfig `json:"lowBalance,omitempty"`
+
+	// Whether the validator pod must hold a Kubernetes Lease before it is allowed to sign, so that two pods never
+	// sign with the same key at once (e.g. when a pod is stuck terminating on a partitioned node). Does not apply
+	// to nodes signing through cosmosigner. Defaults to `true`.
+	// +optional
+	// +default=true
+	SigningLease *bool `json:"signingLease,omitempty"`
+
+	// Enables checking recent blocks for signatures of this validator before it starts. Start-up is blocked
+	// while the key is signing somewhere else (e.g. in another cluster using the same key Secret). Does not
+	// apply to validators using TmKMS or cosmosigner.
+	// +optional
+	DoubleSignCheck *DoubleSignCheckConfig `json:"doubleSignCheck,omitempty"`
 }
@@ -3,8 +3,10 @@ package v1
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"strings"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"k8s.io/apimachinery/pkg/api/resource"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -104,6 +106,11 @@ func (chainNode *ChainNode) Validate(old *ChainNode) (admission.Warnings, error)
 		return nil, fmt.Errorf(".spec.genesis and .spec.validator.init are mutually exclusive")
 	}
 
+	// Validate upgrade image resolver
+	if err := chainNode.Spec.App.UpgradeImageResolver.Validate(".spec.app.upgradeImageResolver"); err != nil {
+		return nil, err
+	}
+
 	// Validate snapshots config
 	if chainNode.Spec.Persistence != nil && chainNode.Spec.Persistence.Snapshots != nil {
 		if err := validateSnapshotsConfig(chainNode.Spec.Persistence.Snapshots, ".spec.persistence.snapshots"); err != nil {
@@ -111,6 +118,11 @@ func (chainNode *ChainNode) Validate(old *ChainNode) (admission.Warnings, error)
 		}
 	}
 
+	// Validate restore source
+	if err := validatePersistenceRestore(chainNode.Spec.Persistence, ".spec.persistence"); err != nil {
+		return nil, err
+	}
+
 	// The CosmoGuard dashboard port must not collide with a port the guard Service already exposes.
 	if err := chainNode.Spec.Config.ValidateCosmoGuardDashboard(chainNode.GetNamespace()); err != nil {
 		return nil, fmt.Errorf(".spec.config.%w", err)
@@ -134,6 +146,20 @@ func (chainNode *ChainNode) Validate(old *ChainNode) (admission.Warnings, error)
 		return nil, err
 	}
 
+	if v := chainNode.Spec.Validator; v != nil {
+		if err := validateValidatorCommission(v.Init, v.CreateValidator, ".spec.validator"); err != nil {
+			return nil, err
+		}
+		if err := validateLowBalance(v.LowBalance, ".spec.validator.lowBalance"); err != nil {
+			return nil, err
+		}
+		// Only the ChainNodeSet controller adds the RPC endpoints of its other groups. A standalone validator
+		// without any would never pass the check and never start signing.
+		if v.DoubleSignCheck != nil && len(v.DoubleSignCheck.RPCs) == 0 && !isControlledByChainNodeSet(chainNode) {
+			return nil, fmt.Errorf(".spec.validator.doubleSignCheck.rpcs must list at least one trusted RPC endpoint")
+		}
+	}
+
 	// remoteSignerTarget is a controller-managed marker set by the ChainNodeSet controller on nodes
 	// of targeted groups. Setting it by hand on a ChainNode that has no cosmosigner of its own and no
 	// owning ChainNodeSet would make a validator stop mounting its key and silently fail to sign.
@@ -484,7 +510,80 @@ func (chainNode *ChainNode) IsControlledByChainNodeSet() bool {
 	return false
 }
 
+func validatePersistenceRestore(persistence *Persistence, path string) error {
+	if persistence == nil {
+		return nil
+	}
+	if persistence.RestoreFromSnapshot != nil && persistence.RestoreFromTarball != nil {
+		return fmt.Errorf("%s.restoreFromSnapshot and %s.restoreFromTarball are mutually exclusive", path, path)
+	}
+	return persistence.RestoreFromTarball.Validate(path + ".restoreFromTarball")
+}
+
+// validateValidatorCommission rejects a commission rate above the commission max rate. The validator could
+// not be created with it, and an existing validator can never change to a rate above its max rate.
+func validateValidatorCommission(init *GenesisInitConfig, createValidator *CreateValidatorConfig, path string) error {
+	v := &ValidatorConfig{Init: init, CreateValidator: createValidator}
+	switch {
+	case init != nil:
+		path += ".init"
+	case createValidator != nil:
+		path += ".createValidator"
+	default:
+		return nil
+	}
+
+	rate, ok := new(big.Rat).SetString(v.GetCommissionRate())
+	if !ok {
+		return fmt.Errorf("%s.commissionRate %q is not a valid decimal", path, v.GetCommissionRate())
+	}
+	maxRate, ok := new(big.Rat).SetString(v.GetCommissionMaxRate())
+	if !ok {
+		return fmt.Errorf("%s.commissionMaxRate %q is not a valid decimal", path, v.GetCommissionMaxRate())
+	}
+	if rate.Cmp(maxRate) > 0 {
+		return fmt.Errorf("%s.commissionRate %s cannot be above %s.commissionMaxRate %s", path, v.GetCommissionRate(), path, v.GetCommissionMaxRate())
+	}
+	return nil
+}
+
+// validateLowBalance rejects minimum balances that cannot be parsed as coins, which would otherwise disable the
+// balance checks.
+func validateLowBalance(config *LowBalanceConfig, path string) error {
+	if config == nil {
+		return nil
+	}
+	for i, balance := range config.MinBalances {
+		if _, err := sdk.ParseCoinNormalized(balance); err != nil {
+			return fmt.Errorf("%s.minBalances[%d] %q is not a valid coin (e.g. `1000000uatom`): %v", path, i, balance, err)
+		}
+	}
+	if _, err := sdk.ParseCoinsNormalized(strings.Join(config.MinBalances, ",")); err != nil {
+		return fmt.Errorf("%s.minBalances is not valid: %v", path, err)
+	}
+	return nil
+}
+
 func validateSnapshotsConfig(config *VolumeSnapshotsConfig, path string) error {
+	triggers := 0
+	if config.Frequency != "" {
+		triggers++
+	}
+	if config.Schedule != nil {
+		triggers++
+		if _, err := config.GetCronSchedule(); err != nil {
+			return fmt.Errorf("%s.schedule is not a valid cron expression: %w", path, err)
+		}
+	}
+	if config.HeightSchedule != nil {
+		triggers++
+		if config.HeightSchedule.Interval == nil && len(config.HeightSchedule.Heights) == 0 {
+			return fmt.Errorf("%s.heightSchedule requires interval or heights", path)
+		}
+	}
+	if triggers != 1 {
+		return fmt.Errorf("exactly one of %s.frequency, %s.schedule or %s.heightSchedule must be set", path, path, path)
+	}
 	if config.Retention != nil && config.Retain != nil {
 		return fmt.Errorf("%s.retention and %s.retain are mutually exclusive", path, path)
 	}
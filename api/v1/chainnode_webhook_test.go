@@ -173,6 +173,261 @@ func TestChainNodeValidateGcsExportCredentials(t *testing.T) {
 	})
 }
 
+func TestChainNodeValidateSnapshotSchedules(t *testing.T) {
+	chainNode := func(snapshots *VolumeSnapshotsConfig) *ChainNode {
+		return &ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "cn"},
+			Spec: ChainNodeSpec{
+				Genesis:     &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+				Persistence: &Persistence{Snapshots: snapshots},
+			},
+		}
+	}
+
+	t.Run("cron schedule is allowed", func(t *testing.T) {
+		_, err := chainNode(&VolumeSnapshotsConfig{Schedule: ptr.To("0 3 * * *")}).Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("height schedule is allowed", func(t *testing.T) {
+		_, err := chainNode(&VolumeSnapshotsConfig{
+			HeightSchedule: &SnapshotHeightSchedule{Heights: []int64{1000}},
+		}).Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("multiple triggers are rejected", func(t *testing.T) {
+		_, err := chainNode(&VolumeSnapshotsConfig{Frequency: "24h", Schedule: ptr.To("0 3 * * *")}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of")
+	})
+
+	t.Run("no trigger is rejected", func(t *testing.T) {
+		_, err := chainNode(&VolumeSnapshotsConfig{}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of")
+	})
+
+	t.Run("invalid cron is rejected", func(t *testing.T) {
+		_, err := chainNode(&VolumeSnapshotsConfig{Schedule: ptr.To("every day")}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a valid cron expression")
+	})
+
+	t.Run("empty height schedule is rejected", func(t *testing.T) {
+		_, err := chainNode(&VolumeSnapshotsConfig{HeightSchedule: &SnapshotHeightSchedule{}}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires interval or heights")
+	})
+}
+
+func TestChainNodeValidateCommission(t *testing.T) {
+	chainNode := func(createValidator *CreateValidatorConfig) *ChainNode {
+		return &ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "cn"},
+			Spec: ChainNodeSpec{
+				Genesis:   &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+				Validator: &ValidatorConfig{CreateValidator: createValidator},
+			},
+		}
+	}
+
+	t.Run("rate within max rate is allowed", func(t *testing.T) {
+		_, err := chainNode(&CreateValidatorConfig{
+			CommissionRate:    ptr.To("0.05"),
+			CommissionMaxRate: ptr.To("0.2"),
+		}).Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rate above max rate is rejected", func(t *testing.T) {
+		_, err := chainNode(&CreateValidatorConfig{
+			CommissionRate:    ptr.To("0.3"),
+			CommissionMaxRate: ptr.To("0.2"),
+		}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ".spec.validator.createValidator.commissionRate 0.3 cannot be above")
+	})
+
+	t.Run("rate above default max rate is rejected", func(t *testing.T) {
+		_, err := chainNode(&CreateValidatorConfig{CommissionRate: ptr.To("0.15")}).Validate(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid rate is rejected", func(t *testing.T) {
+		_, err := chainNode(&CreateValidatorConfig{CommissionRate: ptr.To("ten percent")}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a valid decimal")
+	})
+}
+
+func TestChainNodeValidateLowBalance(t *testing.T) {
+	chainNode := func(minBalances ...string) *ChainNode {
+		return &ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "cn"},
+			Spec: ChainNodeSpec{
+				Genesis: &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+				Validator: &ValidatorConfig{
+					LowBalance: &LowBalanceConfig{MinBalances: minBalances},
+				},
+			},
+		}
+	}
+
+	t.Run("valid balances are allowed", func(t *testing.T) {
+		_, err := chainNode("1000000uatom", "500ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2").Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid balance is rejected", func(t *testing.T) {
+		_, err := chainNode("1000000uatom", "uatom").Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ".spec.validator.lowBalance.minBalances[1]")
+	})
+
+	t.Run("duplicate denoms are rejected", func(t *testing.T) {
+		_, err := chainNode("1000000uatom", "5uatom").Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ".spec.validator.lowBalance.minBalances is not valid")
+	})
+}
+
+func TestChainNodeValidateDoubleSignCheck(t *testing.T) {
+	chainNode := func(rpcs ...string) *ChainNode {
+		return &ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "cn"},
+			Spec: ChainNodeSpec{
+				Genesis: &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+				Validator: &ValidatorConfig{
+					DoubleSignCheck: &DoubleSignCheckConfig{RPCs: rpcs},
+				},
+			},
+		}
+	}
+
+	t.Run("standalone node with rpcs is allowed", func(t *testing.T) {
+		_, err := chainNode("https://rpc.example.com:443").Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("standalone node without rpcs is rejected", func(t *testing.T) {
+		_, err := chainNode().Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ".spec.validator.doubleSignCheck.rpcs")
+	})
+
+	t.Run("chainnodeset child without rpcs is allowed", func(t *testing.T) {
+		child := chainNode()
+		child.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: GroupVersion.String(), Kind: "ChainNodeSet", Name: "cns",
+			UID: "11111111-1111-1111-1111-111111111111", Controller: ptr.To(true),
+		}}
+		_, err := child.Validate(nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestChainNodeValidateUpgradeImageResolver(t *testing.T) {
+	chainNode := func(resolver *UpgradeImageResolver) *ChainNode {
+		return &ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "cn"},
+			Spec: ChainNodeSpec{
+				Genesis: &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+				App:     AppSpec{UpgradeImageResolver: resolver},
+			},
+		}
+	}
+
+	t.Run("chain-registry url and template are allowed", func(t *testing.T) {
+		_, err := chainNode(&UpgradeImageResolver{
+			ChainRegistry: &ChainRegistryVersions{Url: ptr.To("https://example.com/chain.json")},
+			Template:      ptr.To("ghcr.io/org/app:{{.Name}}"),
+		}).Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("chain-registry without source is rejected", func(t *testing.T) {
+		_, err := chainNode(&UpgradeImageResolver{ChainRegistry: &ChainRegistryVersions{}}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "one of url or configMap must be set")
+	})
+
+	t.Run("chain-registry with both sources is rejected", func(t *testing.T) {
+		_, err := chainNode(&UpgradeImageResolver{ChainRegistry: &ChainRegistryVersions{
+			Url:       ptr.To("https://example.com/chain.json"),
+			ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "registry"}, Key: "chain.json"},
+		}}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutually exclusive")
+	})
+
+	t.Run("invalid template is rejected", func(t *testing.T) {
+		_, err := chainNode(&UpgradeImageResolver{Template: ptr.To("ghcr.io/org/app:{{.Name")}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a valid template")
+	})
+}
+
+func TestChainNodeValidateRestoreFromTarball(t *testing.T) {
+	s3 := &S3ExportConfig{Bucket: "snapshots", Region: "us-east-1"}
+	chainNode := func(persistence *Persistence) *ChainNode {
+		return &ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "cn"},
+			Spec: ChainNodeSpec{
+				Genesis:     &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+				Persistence: persistence,
+			},
+		}
+	}
+
+	t.Run("latest archive from s3 is allowed", func(t *testing.T) {
+		_, err := chainNode(&Persistence{RestoreFromTarball: &TarballRestoreConfig{S3: s3}}).Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("named archive with height is allowed", func(t *testing.T) {
+		_, err := chainNode(&Persistence{RestoreFromTarball: &TarballRestoreConfig{
+			Name:   ptr.To("cosmoshub-4-20240101120000"),
+			Height: ptr.To[int64](1000),
+			S3:     s3,
+		}}).Validate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("restoreFromSnapshot together is rejected", func(t *testing.T) {
+		_, err := chainNode(&Persistence{
+			RestoreFromSnapshot: &PvcSnapshot{Name: "snapshot"},
+			RestoreFromTarball:  &TarballRestoreConfig{S3: s3},
+		}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutually exclusive")
+	})
+
+	t.Run("name and prefix together are rejected", func(t *testing.T) {
+		_, err := chainNode(&Persistence{RestoreFromTarball: &TarballRestoreConfig{
+			Name:   ptr.To("cosmoshub-4-20240101120000"),
+			Prefix: ptr.To("cosmoshub-4-"),
+			S3:     s3,
+		}}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "name and prefix are mutually exclusive")
+	})
+
+	t.Run("missing bucket is rejected", func(t *testing.T) {
+		_, err := chainNode(&Persistence{RestoreFromTarball: &TarballRestoreConfig{}}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "one of gcs or s3 must be set")
+	})
+
+	t.Run("gcs credentials are validated", func(t *testing.T) {
+		_, err := chainNode(&Persistence{RestoreFromTarball: &TarballRestoreConfig{
+			GCS: &GcsExportConfig{Bucket: "snapshots"},
+		}}).Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ".spec.persistence.restoreFromTarball.gcs")
+	})
+}
+
 // TestChainNodeValidateRejectsInitChangeAfterCreation verifies that a standalone ChainNode rejects
 // changes to .spec.validator.init after genesis (old.status.chainID set): the entire init block is
 // baked into the immutable genesis, so altering it would rebuild a different genesis if the ChainNode
@@ -2,13 +2,16 @@ package v1
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/voluzi/cosmoseed/pkg/cosmoseed"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -32,6 +35,16 @@ const (
 	// ReservedValidatorGroupName is the group name reserved for the legacy
 	// singleton .spec.validator. It cannot be used as a node group name.
 	ReservedValidatorGroupName = "validator"
+
+	// DefaultUpgradeRolloutSoakTime is the default time a rollout stage must remain healthy.
+	DefaultUpgradeRolloutSoakTime = 10 * time.Minute
+
+	// DefaultUpgradeRolloutTimeout is the default time a rollout stage has to pass the health gates.
+	DefaultUpgradeRolloutTimeout = 30 * time.Minute
+
+	// DefaultUpdateStrategyMaxUnavailable is the default number of ChainNodes of a group that can be
+	// unavailable during a rolling update.
+	DefaultUpdateStrategyMaxUnavailable = 1
 )
 
 func (nodeSet *ChainNodeSet) GetNamespacedName() string {
@@ -117,6 +130,16 @@ func (nodeSet *ChainNodeSet) GeneratedValidatorNodeName(group string, index int)
 	return fmt.Sprintf("%s-%s-%d", nodeSet.GetName(), group, index)
 }
 
+// HasMaxBlockLag returns true if any group of this ChainNodeSet removes lagging nodes from Services.
+func (nodeSet *ChainNodeSet) HasMaxBlockLag() bool {
+	for _, group := range nodeSet.Spec.Nodes {
+		if group.HasMaxBlockLag() {
+			return true
+		}
+	}
+	return false
+}
+
 func (nodeSet *ChainNodeSet) HasValidator() bool {
 	if nodeSet.Spec.Validator != nil {
 		return true
@@ -265,6 +288,32 @@ func (group *NodeGroupSpec) ShouldIgnoreGroupLabelOnDisruptions() bool {
 	return false
 }
 
+// HasMaxBlockLag returns true if nodes of this group are removed from Services while lagging behind.
+func (group *NodeGroupSpec) HasMaxBlockLag() bool {
+	return group != nil && group.Validator == nil && group.MaxBlockLag != nil
+}
+
+func (s *NodeGroupUpdateStrategy) GetMaxUnavailable() int {
+	if s != nil && s.MaxUnavailable != nil {
+		return *s.MaxUnavailable
+	}
+	return DefaultUpdateStrategyMaxUnavailable
+}
+
+func (s *NodeGroupUpdateStrategy) GetPartition() int {
+	if s != nil && s.Partition != nil {
+		return *s.Partition
+	}
+	return 0
+}
+
+func (s *NodeGroupUpdateStrategy) ShouldWaitForSynced() bool {
+	if s != nil && s.WaitForSynced != nil {
+		return *s.WaitForSynced
+	}
+	return true
+}
+
 // MisplacedValidatorScopedFields returns the JSON names of group-level fields that are set on a
 // validator group but never consulted for it: every instance of a validator group is reconciled
 // from .validator.<field> instead (see getValidatorSpecWithBlockedSignerTargets and the validator
@@ -314,6 +363,8 @@ func (group *NodeGroupSpec) MisplacedValidatorScopedFields() []string {
 //   - ignoreGroupOnDisruptionChecks: validator pods already coordinate disruptions chain-wide
 //     ({chain-id, validator}), ignoring nodeset and group labels entirely.
 //   - inheritValidatorGasPrice: a validator group is itself the gas-price source.
+//   - updateStrategy: validator pods are already recreated one at a time.
+//   - maxBlockLag: validator pods are never removed from Services for lagging behind.
 func (group *NodeGroupSpec) IneffectiveValidatorGroupFlags() []string {
 	if group == nil || group.Validator == nil {
 		return nil
@@ -326,6 +377,12 @@ func (group *NodeGroupSpec) IneffectiveValidatorGroupFlags() []string {
 	if group.InheritValidatorGasPrice != nil {
 		flags = append(flags, "inheritValidatorGasPrice")
 	}
+	if group.UpdateStrategy != nil {
+		flags = append(flags, "updateStrategy")
+	}
+	if group.MaxBlockLag != nil {
+		flags = append(flags, "maxBlockLag")
+	}
 	return flags
 }
 
@@ -448,6 +505,25 @@ func (gi *GlobalIngressConfig) GetServiceName(owner client.Object) string {
 	return fmt.Sprintf("%s-global-%s", owner.GetName(), gi.Name)
 }
 
+// GetHeightProxyName returns the name of the height routing proxy Deployment of this ingress.
+func (gi *GlobalIngressConfig) GetHeightProxyName(owner client.Object) string {
+	return fmt.Sprintf("%s-height", gi.GetName(owner))
+}
+
+// GetRecentGroups returns the groups of this ingress that serve recent heights when height routing is enabled.
+func (gi *GlobalIngressConfig) GetRecentGroups() []string {
+	if gi.HeightRouting == nil {
+		return gi.Groups
+	}
+	groups := make([]string, 0, len(gi.Groups))
+	for _, group := range gi.Groups {
+		if group != gi.HeightRouting.ArchiveGroup {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
 func (gi *GlobalIngressConfig) GetGrpcName(owner client.Object) string {
 	return fmt.Sprintf("%s-global-%s-grpc", owner.GetName(), gi.Name)
 }
@@ -700,3 +776,96 @@ func (g GatewayRef) GetParentRef() gwapiv1.ParentReference {
 	}
 	return ref
 }
+
+// UpgradeRolloutStrategy helper methods
+
+// ShouldStageUpgrade returns true if the given upgrade should be rolled out in stages.
+func (nodeSet *ChainNodeSet) ShouldStageUpgrade(upgrade UpgradeSpec) bool {
+	return nodeSet.Spec.UpgradeRollout != nil && !upgrade.ForceGovUpgrade()
+}
+
+func (s *UpgradeRolloutStrategy) GetSoakTime() time.Duration {
+	if s != nil && s.SoakTime != nil {
+		if d, err := strfmt.ParseDuration(*s.SoakTime); err == nil {
+			return d
+		}
+	}
+	return DefaultUpgradeRolloutSoakTime
+}
+
+func (s *UpgradeRolloutStrategy) GetTimeout() time.Duration {
+	if s != nil && s.Timeout != nil {
+		if d, err := strfmt.ParseDuration(*s.Timeout); err == nil {
+			return d
+		}
+	}
+	return DefaultUpgradeRolloutTimeout
+}
+
+// ShouldRequireSynced returns true if nodes of a stage must be running and synced.
+func (s *UpgradeRolloutStrategy) ShouldRequireSynced() bool {
+	if s != nil && s.HealthGates != nil && s.HealthGates.Synced != nil {
+		return *s.HealthGates.Synced
+	}
+	return true
+}
+
+// ShouldRequireHeightAdvancing returns true if nodes of a stage must process blocks after the stage started.
+func (s *UpgradeRolloutStrategy) ShouldRequireHeightAdvancing() bool {
+	if s != nil && s.HealthGates != nil && s.HealthGates.HeightAdvancing != nil {
+		return *s.HealthGates.HeightAdvancing
+	}
+	return true
+}
+
+// Validate returns an error if the rollout strategy is invalid for the given groups.
+func (s *UpgradeRolloutStrategy) Validate(path string, groups []NodeGroupSpec) error {
+	if s == nil {
+		return nil
+	}
+	switch {
+	case len(s.Groups) > 0 && s.Canary != nil:
+		return fmt.Errorf("%s: groups and canary are mutually exclusive", path)
+	case len(s.Groups) == 0 && s.Canary == nil:
+		return fmt.Errorf("%s: one of groups or canary must be set", path)
+	case s.Canary != nil && *s.Canary < 1:
+		return fmt.Errorf("%s.canary must be at least 1", path)
+	}
+	seen := map[string]bool{}
+	for _, group := range s.Groups {
+		if seen[group] {
+			return fmt.Errorf("%s.groups: group %q is listed more than once", path, group)
+		}
+		seen[group] = true
+		if group != ReservedValidatorGroupName && !slices.ContainsFunc(groups, func(g NodeGroupSpec) bool { return g.Name == group }) {
+			return fmt.Errorf("%s.groups: group %q does not exist", path, group)
+		}
+	}
+	if s.SoakTime != nil {
+		if _, err := strfmt.ParseDuration(*s.SoakTime); err != nil {
+			return fmt.Errorf("%s.soakTime: %w", path, err)
+		}
+	}
+	if s.Timeout != nil {
+		if _, err := strfmt.ParseDuration(*s.Timeout); err != nil {
+			return fmt.Errorf("%s.timeout: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Height Routing helper methods
+
+func (hr *HeightRoutingConfig) GetRecentBlocks() int64 {
+	if hr.RecentBlocks != nil {
+		return *hr.RecentBlocks
+	}
+	return DefaultHeightRoutingRecentBlocks
+}
+
+func (hr *HeightRoutingConfig) GetReplicas() int32 {
+	if hr.Replicas != nil {
+		return *hr.Replicas
+	}
+	return DefaultHeightRoutingReplicas
+}
@@ -56,6 +56,12 @@ type ChainNodeSetSpec struct {
 	// or setting/updating the image for an on-chain upgrade.
 	App AppSpec `json:"app"`
 
+	// Strategy for rolling out manual upgrades from `.spec.app.upgrades` across the nodes of this set in stages.
+	// When not set, all nodes switch image at the upgrade height. Upgrades processed as on-chain upgrades
+	// are never staged.
+	// +optional
+	UpgradeRollout *UpgradeRolloutStrategy `json:"upgradeRollout,omitempty"`
+
 	// Indicates where this node will get the genesis from. Can be omitted when a single validator
 	// initializes a new genesis via .spec.validator.init or .spec.nodes[].validator.init, and no
 	// other validator relies on an external genesis.
@@ -93,6 +99,12 @@ type ChainNodeSetSpec struct {
 	// mounting a local key or running TmKMS.
 	// +optional
 	Cosmosigner *Cosmosigner `json:"cosmosigner,omitempty"`
+
+	// Creates Prometheus Operator monitors and a PrometheusRule with default alerts for every node of
+	// the set, and monitors for the CosmoGuard instances of its groups. Requires the Prometheus Operator
+	// CRDs to be installed.
+	// +optional
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
 }
 
 // ChainNodeSetStatus defines the observed state of ChainNodeSet.
@@ -157,6 +169,10 @@ type ChainNodeSetStatus struct {
 	// +optional
 	Upgrades []Upgrade `json:"upgrades,omitempty"`
 
+	// Progress of staged upgrade rollouts, when `.spec.upgradeRollout` is set.
+	// +optional
+	UpgradeRollouts []UpgradeRolloutStatus `json:"upgradeRollouts,omitempty"`
+
 	// Last height read on the nodes by cosmopilot.
 	// +optional
 	LatestHeight int64 `json:"latestHeight,omitempty"`
@@ -393,6 +409,45 @@ type NodeSetValidatorConfig struct {
 	// +optional
 	// +default="cosmosvaloper"
 	ValPrefix *string `json:"valPrefix,omitempty"`
+
+	// Enables alerting when this validator misses blocks. Signing info is always reported in status,
+	// but events and conditions are only emitted when this is set.
+	// +optional
+	MissedBlocks *MissedBlocksConfig `json:"missedBlocks,omitempty"`
+
+	// Enables automatic unjailing of this validator when it gets jailed for downtime. The unjail
+	// transaction is signed with the validator account. Tombstoned validators are never unjailed.
+	// +optional
+	AutoUnjail *AutoUnjailConfig `json:"autoUnjail,omitempty"`
+
+	// Enables tracking of governance proposals in voting period and voting on them according to the
+	// configured policy. Votes are signed with the validator account.
+	// +optional
+	Governance *GovernanceConfig `json:"governance,omitempty"`
+
+	// Enables periodic withdrawal of the rewards and commission of this validator and their delegation back
+	// to it, keeping a reserve for fees. Transactions are signed with the validator account.
+	// +optional
+	AutoRestake *AutoRestakeConfig `json:"autoRestake,omitempty"`
+
+	// Enables alerting when the balance of the validator account drops below a minimum, so that transactions
+	// signed with it (create-validator, unjail, votes and restakes) do not fail for lack of fees.
+	// +optional
+	LowBalance *LowBalanceConfig `json:"lowBalance,omitempty"`
+
+	// Whether the validator pod must hold a Kubernetes Lease before it is allowed to sign, so that two pods never
+	// sign with the same key at once (e.g. when a pod is stuck terminating on a partitioned node). Does not apply
+	// to nodes signing through cosmosigner. Defaults to `true`.
+	// +optional
+	// +default=true
+	SigningLease *bool `json:"signingLease,omitempty"`
+
+	// Enables checking recent blocks for signatures of this validator before it starts. Start-up is blocked
+	// while the key is signing somewhere else (e.g. in another cluster using the same key Secret). Does not
+	// apply to validators using TmKMS or cosmosigner. The internal services of the other groups of this
+	// ChainNodeSet are always added to the trusted RPC endpoints.
+	// +optional
+	DoubleSignCheck *DoubleSignCheckConfig `json:"doubleSignCheck,omitempty"`
 }
 
 // NodeGroupSpec sets chainnode configurations for a group.
@@ -530,6 +585,45 @@ type NodeGroupSpec struct {
 	// Ignored when this group has a `validator` block; use `.validator.overrideVersion` instead.
 	// +optional
 	OverrideVersion *string `json:"overrideVersion,omitempty"`
+
+	// UpdateStrategy configures how changes to this group are rolled out to its ChainNodes. By default,
+	// all ChainNodes of the group are updated at once.
+	// Has no effect when this group has a `validator` block: validator pods are already recreated one at a time.
+	// +optional
+	UpdateStrategy *NodeGroupUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// MaxBlockLag is the number of blocks a node of this group can be behind the highest node of the
+	// ChainNodeSet before it is removed from the group and global Services. It is added back once it catches
+	// up. Disabled by default.
+	// Has no effect when this group has a `validator` block.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxBlockLag *int64 `json:"maxBlockLag,omitempty"`
+}
+
+// NodeGroupUpdateStrategy configures a rolling update of the ChainNodes of a group, similar to the
+// RollingUpdate strategy of a StatefulSet. ChainNodes are updated from the highest index to the lowest.
+type NodeGroupUpdateStrategy struct {
+	// Maximum number of ChainNodes of the group that can be unavailable during the update.
+	// Defaults to `1`.
+	// +optional
+	// +default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxUnavailable *int `json:"maxUnavailable,omitempty"`
+
+	// ChainNodes with an index lower than the partition are not updated. This allows staging a change on the
+	// ChainNodes with the highest indexes only. New ChainNodes are always created with the latest spec.
+	// Defaults to `0`.
+	// +optional
+	// +default=0
+	// +kubebuilder:validation:Minimum=0
+	Partition *int `json:"partition,omitempty"`
+
+	// Whether an updated ChainNode must be running and synced before it counts as available. When disabled,
+	// a ChainNode that is still syncing counts as available.
+	// Defaults to `true`.
+	// +optional
+	WaitForSynced *bool `json:"waitForSynced,omitempty"`
 }
 
 // IngressConfig specifies configurations for ingress to expose API endpoints.
@@ -669,6 +763,37 @@ type GlobalIngressConfig struct {
 	// Useful for usage with custom controllers that have their own CRDs.
 	// +optional
 	ServicesOnly *bool `json:"servicesOnly,omitempty"`
+
+	// HeightRouting deploys a proxy behind this ingress that sends requests for old heights to an archive
+	// group, and all other requests to the remaining groups in Groups.
+	// +optional
+	HeightRouting *HeightRoutingConfig `json:"heightRouting,omitempty"`
+}
+
+// HeightRoutingConfig configures a proxy that routes historical queries to archive nodes. The height of a
+// request is taken from the `x-cosmos-block-height` header (also used as gRPC metadata), the `height` query
+// parameter or the `height` parameter of JSON-RPC requests.
+type HeightRoutingConfig struct {
+	// ArchiveGroup is the group of nodes that serves requests for heights older than RecentBlocks.
+	// +kubebuilder:validation:MinLength=1
+	ArchiveGroup string `json:"archiveGroup"`
+
+	// RecentBlocks is the number of most recent blocks available on the other groups of the ingress. It
+	// should not exceed the number of blocks kept by their pruning settings.
+	// +optional
+	// +default=100
+	// +kubebuilder:validation:Minimum=1
+	RecentBlocks *int64 `json:"recentBlocks,omitempty"`
+
+	// Replicas is the number of proxy instances.
+	// +optional
+	// +default=1
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources of the proxy container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // PdbConfig configures the Pod Disruption Budget for a pod.
@@ -937,3 +1062,101 @@ type CosmoseedGatewayConfig struct {
 	// Host in which cosmoseed nodes will be exposed.
 	Host string `json:"host"`
 }
+
+// UpgradeRolloutStrategy configures how manual upgrades are rolled out across the nodes of a ChainNodeSet.
+// Nodes are upgraded in stages: each stage must pass the health gates for the soak time before the upgrade
+// is released to the next stage. Either `groups` or `canary` must be set.
+type UpgradeRolloutStrategy struct {
+	// Ordered list of groups to upgrade, one stage per group. Nodes of groups not listed (including
+	// validators) are upgraded in a final stage.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Number of nodes upgraded in a first canary stage. All other nodes are upgraded in a second stage.
+	// Non-validator nodes are preferred as canaries.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Canary *int `json:"canary,omitempty"`
+
+	// Time each stage must remain healthy before the upgrade is released to the next stage.
+	// Defaults to `10m`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	SoakTime *string `json:"soakTime,omitempty"`
+
+	// Time each stage has to pass the health gates after the upgrade is applied. When exceeded, or when an
+	// upgrade of the stage fails, the rollout is halted. Defaults to `30m`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Timeout *string `json:"timeout,omitempty"`
+
+	// Health gates nodes of a stage must pass.
+	// +optional
+	HealthGates *UpgradeRolloutHealthGates `json:"healthGates,omitempty"`
+}
+
+// UpgradeRolloutHealthGates configures the checks nodes of a rollout stage must pass.
+type UpgradeRolloutHealthGates struct {
+	// Whether nodes must be running and synced. Defaults to `true`.
+	// +optional
+	Synced *bool `json:"synced,omitempty"`
+
+	// Whether nodes must have processed blocks since the stage started. Defaults to `true`.
+	// +optional
+	HeightAdvancing *bool `json:"heightAdvancing,omitempty"`
+}
+
+// UpgradeRolloutPhase represents the phase of a staged upgrade rollout.
+type UpgradeRolloutPhase string
+
+const (
+	// UpgradeRolloutPending indicates that the chain has not reached the upgrade height yet.
+	UpgradeRolloutPending UpgradeRolloutPhase = "Pending"
+
+	// UpgradeRolloutProgressing indicates that the upgrade is being rolled out.
+	UpgradeRolloutProgressing UpgradeRolloutPhase = "Progressing"
+
+	// UpgradeRolloutCompleted indicates that all nodes were upgraded.
+	UpgradeRolloutCompleted UpgradeRolloutPhase = "Completed"
+
+	// UpgradeRolloutHalted indicates that a stage failed and the remaining nodes were not upgraded.
+	UpgradeRolloutHalted UpgradeRolloutPhase = "Halted"
+)
+
+// UpgradeRolloutStatus holds the progress of a staged upgrade rollout.
+type UpgradeRolloutStatus struct {
+	// Height of the upgrade being rolled out.
+	Height int64 `json:"height"`
+
+	// Image of the upgrade being rolled out.
+	Image string `json:"image"`
+
+	// Phase of the rollout.
+	Phase UpgradeRolloutPhase `json:"phase"`
+
+	// Index of the current stage.
+	Stage int `json:"stage"`
+
+	// Total number of stages.
+	Stages int `json:"stages"`
+
+	// ChainNodes the upgrade was released to.
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+
+	// Time at which the current stage started.
+	// +optional
+	StageStartedAt *metav1.Time `json:"stageStartedAt,omitempty"`
+
+	// Chain height when the current stage started.
+	// +optional
+	StageStartHeight int64 `json:"stageStartHeight,omitempty"`
+
+	// Time since which all nodes of the rollout have been passing the health gates.
+	// +optional
+	HealthySince *metav1.Time `json:"healthySince,omitempty"`
+
+	// Details about the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
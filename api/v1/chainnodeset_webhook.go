@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -196,6 +197,14 @@ func (nodeSet *ChainNodeSet) Validate(old *ChainNodeSet) (admission.Warnings, er
 	if nodeSet.Spec.Validator != nil && nodeSet.Spec.Validator.Init != nil && nodeSet.Spec.Validator.CreateValidator != nil {
 		return nil, fmt.Errorf(".spec.validator.init and .spec.validator.createValidator are mutually exclusive")
 	}
+	if v := nodeSet.Spec.Validator; v != nil {
+		if err := validateValidatorCommission(v.Init, v.CreateValidator, ".spec.validator"); err != nil {
+			return nil, err
+		}
+		if err := validateLowBalance(v.LowBalance, ".spec.validator.lowBalance"); err != nil {
+			return nil, err
+		}
+	}
 
 	// Mirror the per-group create-validator/TmKMS guard below for the legacy singleton .spec.validator:
 	// the pod signs through the KMS sidecar and never mounts the local priv-key secret, so the
@@ -205,12 +214,27 @@ func (nodeSet *ChainNodeSet) Validate(old *ChainNodeSet) (admission.Warnings, er
 		return nil, fmt.Errorf(".spec.validator.tmKMS with createValidator requires hashicorp.uploadGenerated=true so the locally-generated key is uploaded to the KMS and the registered create-validator pubkey matches the signing key")
 	}
 
+	// Validate upgrade image resolver
+	if err := nodeSet.Spec.App.UpgradeImageResolver.Validate(".spec.app.upgradeImageResolver"); err != nil {
+		return nil, err
+	}
+
+	// Validate upgrade rollout strategy
+	if err := nodeSet.Spec.UpgradeRollout.Validate(".spec.upgradeRollout", nodeSet.Spec.Nodes); err != nil {
+		return nil, err
+	}
+
 	// Validate validator snapshots config
 	if nodeSet.Spec.Validator != nil && nodeSet.Spec.Validator.Persistence != nil && nodeSet.Spec.Validator.Persistence.Snapshots != nil {
 		if err := validateSnapshotsConfig(nodeSet.Spec.Validator.Persistence.Snapshots, ".spec.validator.persistence.snapshots"); err != nil {
 			return nil, err
 		}
 	}
+	if nodeSet.Spec.Validator != nil {
+		if err := validatePersistenceRestore(nodeSet.Spec.Validator.Persistence, ".spec.validator.persistence"); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate validator persistence size with the same logic used for regular group persistence,
 	// so an invalid quantity is rejected here instead of failing later on the generated ChainNode.
@@ -283,6 +307,9 @@ func (nodeSet *ChainNodeSet) Validate(old *ChainNodeSet) (admission.Warnings, er
 				return nil, err
 			}
 		}
+		if err := validatePersistenceRestore(group.Persistence, fmt.Sprintf(".spec.nodes[%d].persistence", i)); err != nil {
+			return nil, err
+		}
 
 		// Validate group validator config
 		if group.Validator != nil {
@@ -298,6 +325,12 @@ func (nodeSet *ChainNodeSet) Validate(old *ChainNodeSet) (admission.Warnings, er
 			if group.Validator.Init != nil && group.Validator.CreateValidator != nil {
 				return nil, fmt.Errorf(".spec.nodes[%d].validator.init and .spec.nodes[%d].validator.createValidator are mutually exclusive", i, i)
 			}
+			if err := validateValidatorCommission(group.Validator.Init, group.Validator.CreateValidator, fmt.Sprintf(".spec.nodes[%d].validator", i)); err != nil {
+				return nil, err
+			}
+			if err := validateLowBalance(group.Validator.LowBalance, fmt.Sprintf(".spec.nodes[%d].validator.lowBalance", i)); err != nil {
+				return nil, err
+			}
 			// A multi-instance validator group WITHOUT a cosmosigner runs one validator per instance,
 			// each of which must sign with its own consensus key. A shared privateKeySecret or a shared
 			// tmKMS key would make every instance sign with the same key (double-signing), so both are
@@ -350,6 +383,9 @@ func (nodeSet *ChainNodeSet) Validate(old *ChainNodeSet) (admission.Warnings, er
 					return nil, err
 				}
 			}
+			if err := validatePersistenceRestore(group.Validator.Persistence, fmt.Sprintf(".spec.nodes[%d].validator.persistence", i)); err != nil {
+				return nil, err
+			}
 		}
 
 		if group.GetSnapshotNodeIndex() < 0 || group.GetSnapshotNodeIndex() >= group.GetInstances() {
@@ -602,6 +638,11 @@ func (nodeSet *ChainNodeSet) Validate(old *ChainNodeSet) (admission.Warnings, er
 			return nil, err
 		}
 	}
+	for i, ing := range nodeSet.Spec.Ingresses {
+		if err := nodeSet.validateHeightRouting(fmt.Sprintf(".spec.ingresses[%d]", i), &ing); err != nil {
+			return nil, err
+		}
+	}
 	for i, gw := range nodeSet.Spec.GatewayRoutes {
 		if err := ValidateSubdomainPrefixes(fmt.Sprintf(".spec.gatewayRoutes[%d]", i), gw.Subdomains,
 			gw.EnableRPC, gw.EnableGRPC, gw.EnableLCD, gw.EnableEvmRPC, gw.EnableEvmRpcWs); err != nil {
@@ -613,6 +654,21 @@ func (nodeSet *ChainNodeSet) Validate(old *ChainNodeSet) (admission.Warnings, er
 	return append(warnings, nodeSet.genesisSignerCollapseWarnings(genesisAlreadyCreated)...), nil
 }
 
+// validateHeightRouting checks that the archive group of an ingress with height routing exists, and that the
+// ingress targets at least one other group to serve recent heights.
+func (nodeSet *ChainNodeSet) validateHeightRouting(path string, ing *GlobalIngressConfig) error {
+	if ing.HeightRouting == nil {
+		return nil
+	}
+	if !slices.ContainsFunc(nodeSet.Spec.Nodes, func(g NodeGroupSpec) bool { return g.Name == ing.HeightRouting.ArchiveGroup }) {
+		return fmt.Errorf("%s.heightRouting.archiveGroup %q is not a group of this ChainNodeSet", path, ing.HeightRouting.ArchiveGroup)
+	}
+	if len(ing.GetRecentGroups()) == 0 {
+		return fmt.Errorf("%s.groups must include at least one group other than the archive group %q when heightRouting is set", path, ing.HeightRouting.ArchiveGroup)
+	}
+	return nil
+}
+
 // validateCosmosigner validates every managed cosmosigner a ChainNodeSet runs: the top-level
 // .spec.cosmosigner (which selects node groups) and each per-group .spec.nodes[].cosmosigner (whose
 // target is fixed to its enclosing group). Each signer signs for a single consensus identity shared
@@ -318,6 +318,48 @@ func TestChainNodeSetValidateDuplicateGroupNames(t *testing.T) {
 	}
 }
 
+func TestChainNodeSetValidateHeightRouting(t *testing.T) {
+	tests := []struct {
+		name    string
+		groups  []string
+		archive string
+		wantErr string
+	}{
+		{name: "archive group in ingress groups", groups: []string{"pruned", "archive"}, archive: "archive"},
+		{name: "archive group outside ingress groups", groups: []string{"pruned"}, archive: "archive"},
+		{name: "unknown archive group", groups: []string{"pruned"}, archive: "missing", wantErr: "is not a group"},
+		{name: "no recent group", groups: []string{"archive"}, archive: "archive", wantErr: "other than the archive group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeSet := &ChainNodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "set"},
+				Spec: ChainNodeSetSpec{
+					Genesis: &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+					Nodes: []NodeGroupSpec{
+						{Name: "pruned", Instances: ptr.To(1)},
+						{Name: "archive", Instances: ptr.To(1)},
+					},
+					Ingresses: []GlobalIngressConfig{{
+						Name:          "rpc",
+						Groups:        tt.groups,
+						Host:          "nodes.example.com",
+						HeightRouting: &HeightRoutingConfig{ArchiveGroup: tt.archive},
+					}},
+				},
+			}
+			_, err := nodeSet.Validate(nil)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestChainNodeSetValidateReservedGroupName(t *testing.T) {
 	nodeSet := &ChainNodeSet{
 		Spec: ChainNodeSetSpec{
@@ -2079,3 +2121,38 @@ func TestChainNodeSetValidateWarnsOnGenesisSignerCollapse(t *testing.T) {
 		assert.Empty(t, warnings)
 	})
 }
+
+func TestChainNodeSetValidateUpgradeRollout(t *testing.T) {
+	nodeSet := func(rollout *UpgradeRolloutStrategy) *ChainNodeSet {
+		return &ChainNodeSet{Spec: ChainNodeSetSpec{
+			Genesis:        &GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+			Nodes:          []NodeGroupSpec{{Name: "rpc", Instances: ptr.To(2)}},
+			UpgradeRollout: rollout,
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		rollout *UpgradeRolloutStrategy
+		err     string
+	}{
+		{name: "groups", rollout: &UpgradeRolloutStrategy{Groups: []string{"rpc", "validator"}}},
+		{name: "canary", rollout: &UpgradeRolloutStrategy{Canary: ptr.To(1), SoakTime: ptr.To("5m")}},
+		{name: "none", rollout: &UpgradeRolloutStrategy{}, err: "one of groups or canary must be set"},
+		{name: "both", rollout: &UpgradeRolloutStrategy{Groups: []string{"rpc"}, Canary: ptr.To(1)}, err: "mutually exclusive"},
+		{name: "unknown group", rollout: &UpgradeRolloutStrategy{Groups: []string{"archive"}}, err: `group "archive" does not exist`},
+		{name: "duplicate group", rollout: &UpgradeRolloutStrategy{Groups: []string{"rpc", "rpc"}}, err: "listed more than once"},
+		{name: "invalid soak time", rollout: &UpgradeRolloutStrategy{Canary: ptr.To(1), SoakTime: ptr.To("soon")}, err: ".spec.upgradeRollout.soakTime"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := nodeSet(tt.rollout).Validate(nil)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
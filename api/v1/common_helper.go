@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -113,6 +115,44 @@ const (
 
 	// DefaultOOMRecoveryWindow is the default time window for counting OOM recoveries.
 	DefaultOOMRecoveryWindow = 1 * time.Hour
+
+	// DefaultMaxAutoUnjails is the default maximum number of automatic unjails within the unjail window.
+	DefaultMaxAutoUnjails = 3
+
+	// DefaultAutoUnjailWindow is the default time window for counting automatic unjails.
+	DefaultAutoUnjailWindow = 24 * time.Hour
+
+	// DefaultAutoUnjailMinSyncedBlocks is the default number of blocks a node must be synced before unjailing.
+	DefaultAutoUnjailMinSyncedBlocks int64 = 100
+
+	// DefaultAutoRestakeFrequency is the default interval between auto-restake runs.
+	DefaultAutoRestakeFrequency = 24 * time.Hour
+
+	// DefaultDoubleSignCheckBlocks is the default number of recent blocks checked for signatures of a validator
+	// before it starts.
+	DefaultDoubleSignCheckBlocks int64 = 10
+
+	// DefaultHeightRoutingRecentBlocks is the default number of recent blocks served by pruned groups when
+	// routing historical queries to archive nodes.
+	DefaultHeightRoutingRecentBlocks int64 = 100
+
+	// DefaultHeightRoutingReplicas is the default number of height routing proxy instances.
+	DefaultHeightRoutingReplicas int32 = 1
+
+	// DefaultUpgradeFailureDeadline is the default time a node has to get past an upgrade height.
+	DefaultUpgradeFailureDeadline = time.Hour
+
+	// DefaultUpgradeRehearsalTimeout is the default time the new image has to produce blocks on a rehearsal clone.
+	DefaultUpgradeRehearsalTimeout = 30 * time.Minute
+
+	// DefaultMonitoringInterval is the default scrape interval of the monitors created for a node.
+	DefaultMonitoringInterval = 30 * time.Second
+
+	// DefaultHeightStalledFor is the default time without new blocks after which a node is considered stalled.
+	DefaultHeightStalledFor = 5 * time.Minute
+
+	// DefaultDiskUsageAlertPercent is the default data volume usage above which an alert is fired.
+	DefaultDiskUsageAlertPercent = 90
 )
 
 // GetImage returns the versioned image to be used
@@ -153,6 +193,70 @@ func (app *AppSpec) ShouldQueryGovUpgrades() bool {
 	return true
 }
 
+// ShouldPrePullUpgradeImages returns true if images of scheduled upgrades should be pulled ahead of time.
+func (app *AppSpec) ShouldPrePullUpgradeImages() bool {
+	if app.PrePullUpgradeImages != nil {
+		return *app.PrePullUpgradeImages
+	}
+	return true
+}
+
+// UpgradeImageResolver helper methods
+
+// ShouldFetchPlanInfo returns true if plan info URLs should be downloaded to look for the upgrade image.
+func (r *UpgradeImageResolver) ShouldFetchPlanInfo() bool {
+	if r != nil && r.FetchPlanInfo != nil {
+		return *r.FetchPlanInfo
+	}
+	return true
+}
+
+// GetTemplate returns the parsed image template, or nil if none is configured.
+func (r *UpgradeImageResolver) GetTemplate() (*template.Template, error) {
+	if r == nil || r.Template == nil {
+		return nil, nil
+	}
+	return template.New("image").Option("missingkey=error").Parse(*r.Template)
+}
+
+// Validate returns an error if the resolver configuration is invalid.
+func (r *UpgradeImageResolver) Validate(path string) error {
+	if r == nil {
+		return nil
+	}
+	if r.ChainRegistry != nil {
+		switch {
+		case r.ChainRegistry.Url != nil && r.ChainRegistry.ConfigMap != nil:
+			return fmt.Errorf("%s.chainRegistry: url and configMap are mutually exclusive", path)
+		case r.ChainRegistry.Url == nil && r.ChainRegistry.ConfigMap == nil:
+			return fmt.Errorf("%s.chainRegistry: one of url or configMap must be set", path)
+		}
+	}
+	if _, err := r.GetTemplate(); err != nil {
+		return fmt.Errorf("%s.template is not a valid template: %w", path, err)
+	}
+	return nil
+}
+
+// ShouldRollbackFailedUpgrades returns true if failed upgrades should be rolled back automatically.
+func (app *AppSpec) ShouldRollbackFailedUpgrades() bool {
+	return app.UpgradeFailurePolicy != nil
+}
+
+func (app *AppSpec) ShouldRehearseUpgrades() bool {
+	return app.UpgradeRehearsal != nil
+}
+
+// GetUpgradeFallbackImage returns the fallback image of the manual upgrade at the given height, if any.
+func (app *AppSpec) GetUpgradeFallbackImage(height int64) string {
+	for _, upgrade := range app.Upgrades {
+		if upgrade.Height == height && upgrade.FallbackImage != nil {
+			return *upgrade.FallbackImage
+		}
+	}
+	return ""
+}
+
 // UseGenesisSubcommand returns whether genesis commands should use the "genesis" subcommand
 // (e.g., "genesis gentx" vs "gentx"). Defaults to true for sdkVersion >= v0.47.
 func (app *AppSpec) UseGenesisSubcommand() bool {
@@ -832,7 +936,12 @@ func (gg *FromNodeRPCConfig) GetGenesisFromRPCUrl() string {
 
 // VolumeSnapshotsConfig helper methods
 
+// ShouldStopNode returns true if the node must be stopped while snapshots are taken. This is always the case
+// with height schedules, since the node is halted at each scheduled height.
 func (s *VolumeSnapshotsConfig) ShouldStopNode() bool {
+	if s != nil && s.HeightSchedule != nil {
+		return true
+	}
 	if s != nil && s.StopNode != nil {
 		return *s.StopNode
 	}
@@ -881,6 +990,34 @@ func (s *VolumeSnapshotsConfig) GetRetainCount() *int32 {
 	return nil
 }
 
+// GetCronSchedule parses the cron schedule of snapshots. Returns nil if no schedule is set.
+func (s *VolumeSnapshotsConfig) GetCronSchedule() (cron.Schedule, error) {
+	if s == nil || s.Schedule == nil {
+		return nil, nil
+	}
+	return cron.ParseStandard(*s.Schedule)
+}
+
+// SnapshotHeightSchedule helper methods
+
+// NextHeight returns the lowest scheduled height above fromHeight (usually the height of the last
+// snapshot), or zero if there is none.
+func (h *SnapshotHeightSchedule) NextHeight(fromHeight int64) int64 {
+	if h == nil {
+		return 0
+	}
+	var next int64
+	if h.Interval != nil && *h.Interval > 0 {
+		next = (fromHeight / *h.Interval + 1) * *h.Interval
+	}
+	for _, height := range h.Heights {
+		if height > fromHeight && (next == 0 || height < next) {
+			next = height
+		}
+	}
+	return next
+}
+
 // ExportTarballConfig helper methods
 
 func (e *ExportTarballConfig) GetSuffix() string {
@@ -924,6 +1061,41 @@ func (e *ExportTarballConfig) Validate(path string) error {
 	return e.S3.Validate(path + ".s3")
 }
 
+// TarballRestoreConfig helper methods
+
+// GetHeight returns the configured data height of the archive, or 0 when unknown.
+func (t *TarballRestoreConfig) GetHeight() int64 {
+	if t != nil && t.Height != nil {
+		return *t.Height
+	}
+	return 0
+}
+
+// Validate ensures one source bucket is configured and that name and prefix are not both set.
+func (t *TarballRestoreConfig) Validate(path string) error {
+	if t == nil {
+		return nil
+	}
+	switch {
+	case t.GCS != nil && t.S3 != nil:
+		return fmt.Errorf("%s: gcs and s3 are mutually exclusive", path)
+	case t.GCS == nil && t.S3 == nil:
+		return fmt.Errorf("%s: one of gcs or s3 must be set", path)
+	case t.Name != nil && t.Prefix != nil:
+		return fmt.Errorf("%s: name and prefix are mutually exclusive", path)
+	case t.Name != nil && *t.Name == "":
+		return fmt.Errorf("%s.name must not be empty", path)
+	case t.Prefix != nil && *t.Prefix == "":
+		return fmt.Errorf("%s.prefix must not be empty", path)
+	case t.Height != nil && *t.Height < 0:
+		return fmt.Errorf("%s.height must not be negative", path)
+	}
+	if t.GCS != nil {
+		return t.GCS.Validate(path + ".gcs")
+	}
+	return t.S3.Validate(path + ".s3")
+}
+
 // GcsExporter helper methods
 
 // Validate ensures exactly one authentication method is configured for uploading to GCS: either a
@@ -1417,3 +1589,153 @@ func ValidateSubdomainPrefixes(path string, sub *SubdomainsConfig, enableRPC, en
 	}
 	return nil
 }
+
+// Auto Unjail
+
+func (cfg *AutoUnjailConfig) GetMaxUnjails() int {
+	if cfg != nil && cfg.MaxUnjails != nil {
+		return *cfg.MaxUnjails
+	}
+	return DefaultMaxAutoUnjails
+}
+
+func (cfg *AutoUnjailConfig) GetWindow() time.Duration {
+	if cfg != nil && cfg.Window != nil {
+		if d, err := strfmt.ParseDuration(*cfg.Window); err == nil {
+			return d
+		}
+	}
+	return DefaultAutoUnjailWindow
+}
+
+func (cfg *AutoUnjailConfig) GetMinSyncedBlocks() int64 {
+	if cfg != nil && cfg.MinSyncedBlocks != nil {
+		return *cfg.MinSyncedBlocks
+	}
+	return DefaultAutoUnjailMinSyncedBlocks
+}
+
+func (cfg *DoubleSignCheckConfig) GetBlocks() int64 {
+	if cfg != nil && cfg.Blocks != nil {
+		return *cfg.Blocks
+	}
+	return DefaultDoubleSignCheckBlocks
+}
+
+func (cfg *AutoRestakeConfig) GetFrequency() time.Duration {
+	if cfg != nil && cfg.Frequency != nil {
+		if d, err := strfmt.ParseDuration(*cfg.Frequency); err == nil {
+			return d
+		}
+	}
+	return DefaultAutoRestakeFrequency
+}
+
+// GetVote returns the option to vote with on a proposal, given the vote currently cast by the validator
+// account. It returns nil when the current vote already matches the policy or when the proposal must not
+// be voted automatically. Default votes never replace a vote already cast.
+func (cfg *GovernanceConfig) GetVote(proposalID uint64, softwareUpgrade bool, current VoteOption) *VoteOption {
+	for _, vote := range cfg.Votes {
+		if vote.ProposalID == proposalID {
+			if vote.Option == current {
+				return nil
+			}
+			return &vote.Option
+		}
+	}
+
+	if current != "" {
+		return nil
+	}
+	if softwareUpgrade && cfg.SoftwareUpgradeVote != nil {
+		return cfg.SoftwareUpgradeVote
+	}
+	return cfg.DefaultVote
+}
+
+// Upgrade Failure Policy
+
+func (p *UpgradeFailurePolicy) GetDeadline() time.Duration {
+	if p != nil && p.Deadline != nil {
+		if d, err := strfmt.ParseDuration(*p.Deadline); err == nil {
+			return d
+		}
+	}
+	return DefaultUpgradeFailureDeadline
+}
+
+func (p *UpgradeFailurePolicy) ShouldRestoreData() bool {
+	if p != nil && p.RestoreData != nil {
+		return *p.RestoreData
+	}
+	return true
+}
+
+// Upgrade Rehearsal
+
+func (r *UpgradeRehearsal) GetTimeout() time.Duration {
+	if r != nil && r.Timeout != nil {
+		if d, err := strfmt.ParseDuration(*r.Timeout); err == nil {
+			return d
+		}
+	}
+	return DefaultUpgradeRehearsalTimeout
+}
+
+// Monitoring
+
+func (m *MonitoringConfig) UsePodMonitor() bool {
+	if m != nil && m.PodMonitor != nil {
+		return *m.PodMonitor
+	}
+	return false
+}
+
+func (m *MonitoringConfig) GetInterval() time.Duration {
+	if m != nil && m.Interval != nil {
+		if d, err := strfmt.ParseDuration(*m.Interval); err == nil {
+			return d
+		}
+	}
+	return DefaultMonitoringInterval
+}
+
+func (m *MonitoringConfig) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *MonitoringConfig) GetAlerts() *MonitoringAlertsConfig {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+func (m *MonitoringConfig) AlertsEnabled() bool {
+	if m == nil {
+		return false
+	}
+	if m.Alerts != nil && m.Alerts.Enabled != nil {
+		return *m.Alerts.Enabled
+	}
+	return true
+}
+
+func (a *MonitoringAlertsConfig) GetHeightStalledFor() time.Duration {
+	if a != nil && a.HeightStalledFor != nil {
+		if d, err := strfmt.ParseDuration(*a.HeightStalledFor); err == nil {
+			return d
+		}
+	}
+	return DefaultHeightStalledFor
+}
+
+func (a *MonitoringAlertsConfig) GetDiskUsagePercent() int {
+	if a != nil && a.DiskUsagePercent != nil {
+		return *a.DiskUsagePercent
+	}
+	return DefaultDiskUsageAlertPercent
+}
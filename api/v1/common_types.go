@@ -35,6 +35,23 @@ const (
 	ReasonNodeRunning                      = "NodeRunning"
 	ReasonValidatorJailed                  = "ValidatorJailed"
 	ReasonValidatorUnjailed                = "ValidatorUnjailed"
+	ReasonValidatorMissingBlocks           = "ValidatorMissingBlocks"
+	ReasonValidatorSigningRecovered        = "ValidatorSigningRecovered"
+	ReasonValidatorTombstoned              = "ValidatorTombstoned"
+	ReasonUnjailSuccess                    = "UnjailSuccess"
+	ReasonEditValidatorSuccess             = "EditValidatorSuccess"
+	ReasonEditValidatorFailure             = "FailedEditValidator"
+	ReasonUnjailFailure                    = "FailedUnjail"
+	ReasonAutoUnjailLimitReached           = "AutoUnjailLimitReached"
+	ReasonProposalVotingPeriod             = "ProposalVotingPeriod"
+	ReasonProposalVoteSuccess              = "ProposalVoteSuccess"
+	ReasonProposalVoteFailure              = "FailedProposalVote"
+	ReasonAutoRestakeSuccess               = "AutoRestakeSuccess"
+	ReasonAutoRestakeFailure               = "FailedAutoRestake"
+	ReasonLowBalance                       = "LowBalance"
+	ReasonBalanceRecovered                 = "BalanceRecovered"
+	ReasonSigningLeaseHeld                 = "SigningLeaseHeld"
+	ReasonValidatorKeyInUse                = "ValidatorKeyInUse"
 	ReasonNodeCreated                      = "NodeCreated"
 	ReasonNodeUpdated                      = "NodeUpdated"
 	ReasonNodeDeleted                      = "NodeDeleted"
@@ -59,6 +76,17 @@ const (
 	ReasonUpgradeCompleted                 = "UpgradeCompleted"
 	ReasonUpgradeFailed                    = "UpgradeFailed"
 	ReasonUpgradeMissingData               = "UpgradeMissingData"
+	ReasonUpgradeSnapshotCreated           = "UpgradeSnapshotCreated"
+	ReasonUpgradeSnapshotFailed            = "UpgradeSnapshotFailed"
+	ReasonUpgradeRolledBack                = "UpgradeRolledBack"
+	ReasonUpgradeImagePulled               = "UpgradeImagePulled"
+	ReasonUpgradeImagePullFailed           = "UpgradeImagePullFailed"
+	ReasonUpgradeRolloutStageCompleted     = "UpgradeRolloutStageCompleted"
+	ReasonUpgradeRolloutCompleted          = "UpgradeRolloutCompleted"
+	ReasonUpgradeRolloutHalted             = "UpgradeRolloutHalted"
+	ReasonUpgradeRehearsalStarted          = "UpgradeRehearsalStarted"
+	ReasonUpgradeRehearsalPassed           = "UpgradeRehearsalPassed"
+	ReasonUpgradeRehearsalFailed           = "UpgradeRehearsalFailed"
 	ReasonCreateValidatorFailure           = "FailedCreateValidator"
 	ReasonCreateValidatorSuccess           = "CreateValidatorSuccess"
 	ReasonInvalid                          = "Invalid"
@@ -150,10 +178,32 @@ type AppSpec struct {
 	// +default=true
 	CheckGovUpgrades *bool `json:"checkGovUpgrades,omitempty"`
 
+	// Configures how cosmopilot resolves the image of governance upgrades whose plan info does not include
+	// one. When not set, only the `binaries.docker` key of the plan info (or of the document it links to) is used.
+	// +optional
+	UpgradeImageResolver *UpgradeImageResolver `json:"upgradeImageResolver,omitempty"`
+
 	// List of upgrades to schedule for this node.
 	// +optional
 	Upgrades []UpgradeSpec `json:"upgrades,omitempty"`
 
+	// Whether cosmopilot should pull the image of scheduled upgrades onto the Kubernetes node running the
+	// node pod ahead of the upgrade height. Each pull runs a short-lived pod on that Kubernetes node. Defaults to
+	// `true`.
+	// +optional
+	// +default=true
+	PrePullUpgradeImages *bool `json:"prePullUpgradeImages,omitempty"`
+
+	// Policy for rolling back upgrades whose new image fails to start. When not set, failed upgrades
+	// require manual intervention.
+	// +optional
+	UpgradeFailurePolicy *UpgradeFailurePolicy `json:"upgradeFailurePolicy,omitempty"`
+
+	// Rehearse scheduled upgrades on a temporary clone of the node, restored from its latest volume snapshot.
+	// Only nodes with `.spec.persistence.snapshots` enabled rehearse upgrades.
+	// +optional
+	UpgradeRehearsal *UpgradeRehearsal `json:"upgradeRehearsal,omitempty"`
+
 	// SdkOptions allows customizing SDK command behavior for chains that diverge from standard SDK CLI.
 	// +optional
 	SdkOptions *SdkOptions `json:"sdkOptions,omitempty"`
@@ -313,6 +363,7 @@ type Config struct {
 
 	// ServiceAccountName is the name of the ServiceAccount to use for the node's pod.
 	// If not specified, the default service account in the namespace is used.
+	// Validators holding the signing lease get a dedicated service account instead.
 	// This is useful when sidecars need specific permissions (e.g., for leader election using leases).
 	// +optional
 	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
@@ -972,6 +1023,7 @@ type FromNodeRPCConfig struct {
 }
 
 // Persistence configuration for a node.
+// +kubebuilder:validation:XValidation:rule="!(has(self.restoreFromSnapshot) && has(self.restoreFromTarball))",message="restoreFromSnapshot and restoreFromTarball are mutually exclusive"
 type Persistence struct {
 	// Size of the persistent volume for storing data. Can't be updated when autoResize is enabled.
 	// Defaults to `50Gi`.
@@ -1019,11 +1071,24 @@ type Persistence struct {
 	// +optional
 	Snapshots *VolumeSnapshotsConfig `json:"snapshots,omitempty"`
 
+	// Whether cosmopilot should take a volume snapshot once the node halts at an upgrade height and
+	// before the new image starts. Uses the snapshot class from `snapshots` when configured. These
+	// snapshots are labelled with the upgrade height and are not subject to snapshot retention, so
+	// they must be deleted manually. Defaults to `false`.
+	// +optional
+	SnapshotBeforeUpgrade *bool `json:"snapshotBeforeUpgrade,omitempty"`
+
 	// Restore from the specified snapshot when creating the PVC for this node.
 	// +optional
 	RestoreFromSnapshot *PvcSnapshot `json:"restoreFromSnapshot,omitempty"`
 
-	// Time to wait for data initialization pod to be successful. Defaults to `5m`.
+	// Restore from a tarball previously exported to GCS or S3 when creating the PVC for this node.
+	// Mutually exclusive with `restoreFromSnapshot`.
+	// +optional
+	RestoreFromTarball *TarballRestoreConfig `json:"restoreFromTarball,omitempty"`
+
+	// Time to wait for data initialization pod to be successful. Defaults to `5m`, or `24h` when
+	// restoring from a tarball.
 	// +optional
 	InitTimeout *string `json:"initTimeout,omitempty"`
 
@@ -1035,10 +1100,24 @@ type Persistence struct {
 }
 
 // VolumeSnapshotsConfig holds the configuration of snapshotting feature.
+// +kubebuilder:validation:XValidation:rule="(has(self.frequency) ? 1 : 0) + (has(self.schedule) ? 1 : 0) + (has(self.heightSchedule) ? 1 : 0) == 1",message="exactly one of frequency, schedule or heightSchedule must be set"
 type VolumeSnapshotsConfig struct {
 	// How often a snapshot should be created.
+	// Exactly one of frequency, schedule or heightSchedule must be set.
+	// +optional
 	// +kubebuilder:validation:Format=duration
-	Frequency string `json:"frequency"`
+	Frequency string `json:"frequency,omitempty"`
+
+	// Cron expression in UTC defining when snapshots should be created (e.g. `0 3 * * *` for every
+	// day at 03:00 UTC). Descriptors such as `@daily` are also supported.
+	// Exactly one of frequency, schedule or heightSchedule must be set.
+	// +optional
+	Schedule *string `json:"schedule,omitempty"`
+
+	// Creates snapshots based on block height.
+	// Exactly one of frequency, schedule or heightSchedule must be set.
+	// +optional
+	HeightSchedule *SnapshotHeightSchedule `json:"heightSchedule,omitempty"`
 
 	// How long a snapshot should be retained. Default is indefinite retention.
 	// Cannot be used together with Retain.
@@ -1062,7 +1141,8 @@ type VolumeSnapshotsConfig struct {
 	// +optional
 	SnapshotClassName *string `json:"snapshotClass,omitempty"`
 
-	// Whether the node should be stopped while the snapshot is taken. Defaults to `false`.
+	// Whether the node should be stopped while the snapshot is taken. Defaults to `false`, and is always
+	// `true` with `heightSchedule`.
 	// +optional
 	// +default=false
 	StopNode *bool `json:"stopNode,omitempty"`
@@ -1100,12 +1180,62 @@ type VolumeSnapshotsConfig struct {
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 }
 
+// SnapshotHeightSchedule defines block heights at which snapshots should be created. The node is halted
+// right after committing each scheduled height and stays stopped while the snapshot is taken, so the
+// snapshot holds the data at exactly that height (recorded in the `cosmopilot.voluzi.com/data-height`
+// annotation of the VolumeSnapshot). Heights passed while the node is not halted, such as while it syncs
+// with `disableWhileSyncing`, are skipped.
+// +kubebuilder:validation:XValidation:rule="has(self.interval) || (has(self.heights) && size(self.heights) > 0)",message="at least one of interval or heights must be set"
+type SnapshotHeightSchedule struct {
+	// Creates a snapshot every time the height reaches a multiple of this value.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Interval *int64 `json:"interval,omitempty"`
+
+	// Creates a snapshot at each of these heights.
+	// +optional
+	Heights []int64 `json:"heights,omitempty"`
+}
+
 // PvcSnapshot represents a snapshot to be used to restore a PVC.
 type PvcSnapshot struct {
 	// Name of the volume snapshot being referenced.
 	Name string `json:"name"`
 }
 
+// TarballRestoreConfig specifies an exported tarball to restore node data from.
+// +kubebuilder:validation:XValidation:rule="has(self.gcs) != has(self.s3)",message="exactly one of gcs or s3 must be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.name) && has(self.prefix))",message="name and prefix are mutually exclusive"
+type TarballRestoreConfig struct {
+	// Name of the archive to restore, without the compression extension or `-part-N` suffix
+	// (e.g. `cosmoshub-4-20240101120000`). When omitted, the most recent archive whose name starts
+	// with `prefix` is restored.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Name *string `json:"name,omitempty"`
+
+	// Prefix used to find the most recent archive when `name` is not set. Defaults to `<chain-id>-`,
+	// which matches tarballs exported by cosmopilot. The chain ID is taken from `.spec.genesis.chainID`
+	// or from the node status, so one of them must be known when the data volume is created.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Prefix *string `json:"prefix,omitempty"`
+
+	// Block height of the data in the archive. Tarballs do not record it, so set it when the chain had
+	// upgrades, so that cosmopilot starts the node with the right version.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Height *int64 `json:"height,omitempty"`
+
+	// GCS bucket to download the archive from.
+	// +optional
+	GCS *GcsExportConfig `json:"gcs,omitempty"`
+
+	// Amazon S3 or S3-compatible bucket to download the archive from.
+	// +optional
+	S3 *S3ExportConfig `json:"s3,omitempty"`
+}
+
 // TarballCompression identifies the compression applied to exported tar archives.
 // +kubebuilder:validation:Enum=none;gzip;zstd;lz4
 type TarballCompression string
@@ -1345,6 +1475,10 @@ const (
 	// UpgradeSkipped indicates that cosmopilot will not perform the upgrade
 	// because it is in the past.
 	UpgradeSkipped UpgradePhase = "skipped"
+
+	// UpgradeFailed indicates that the node did not get past the upgrade height
+	// with the new image and the upgrade was rolled back.
+	UpgradeFailed UpgradePhase = "failed"
 )
 
 // UpgradeSource indicates the source of a scheduled upgrade.
@@ -1371,6 +1505,108 @@ type UpgradeSpec struct {
 	// Defaults to `false`.
 	// +optional
 	ForceOnChain *bool `json:"forceOnChain,omitempty"`
+
+	// Container image to retry this upgrade with when `image` fails and the upgrade is rolled back.
+	// Only used when `.spec.app.upgradeFailurePolicy` is set.
+	// +optional
+	FallbackImage *string `json:"fallbackImage,omitempty"`
+}
+
+// UpgradeImageResolver configures the sources used to resolve the image of governance upgrades. Sources are
+// tried in the following order until an image is found: the plan info, the chain-registry versions and the
+// template.
+type UpgradeImageResolver struct {
+	// Whether to download the plan info when it is a URL and look for the `binaries.docker` key in it.
+	// Defaults to `true`.
+	// +optional
+	// +default=true
+	FetchPlanInfo *bool `json:"fetchPlanInfo,omitempty"`
+
+	// Chain-registry versions mapping upgrade names to tags of `.spec.app.image`.
+	// +optional
+	ChainRegistry *ChainRegistryVersions `json:"chainRegistry,omitempty"`
+
+	// Go template used to build the image. Available fields are `.Name` (the upgrade name), `.Height` and
+	// `.Image` (`.spec.app.image`). Example: `ghcr.io/org/app:{{.Name}}`.
+	// +optional
+	Template *string `json:"template,omitempty"`
+}
+
+// ChainRegistryVersions specifies where to load chain-registry versions from. Either a `chain.json` (with
+// `codebase.versions`) or a `versions.json` (with `versions`) file from the chain-registry can be used.
+// Exactly one of `url` or `configMap` must be set.
+type ChainRegistryVersions struct {
+	// URL to download the chain-registry file from.
+	// +optional
+	Url *string `json:"url,omitempty"`
+
+	// ConfigMap key containing the chain-registry file.
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// UpgradeFailurePolicy configures how cosmopilot handles upgrades whose new image fails to start.
+type UpgradeFailurePolicy struct {
+	// Time the node has to get past the upgrade height after the new image is started. When exceeded,
+	// the upgrade is marked as failed and rolled back. Defaults to `1h`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Deadline *string `json:"deadline,omitempty"`
+
+	// Whether to restore the data from the snapshot taken before the upgrade when rolling back. Requires
+	// `.spec.persistence.snapshotBeforeUpgrade`. When disabled, or when no snapshot is available, only the
+	// image is reverted. Defaults to `true`.
+	// +optional
+	RestoreData *bool `json:"restoreData,omitempty"`
+}
+
+// UpgradeRehearsal configures rehearsing upgrades on a temporary clone of the node.
+type UpgradeRehearsal struct {
+	// Time the new image has, once started on the clone, to produce a block past the upgrade height. If the
+	// new image is still running when it expires, the migration is considered started and the rehearsal
+	// passes. Defaults to `30m`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// UpgradeRehearsalPhase represents the phase of an upgrade rehearsal.
+// +kubebuilder:validation:Enum=running;passed;failed
+type UpgradeRehearsalPhase string
+
+const (
+	// UpgradeRehearsalRunning indicates the clone is running.
+	UpgradeRehearsalRunning UpgradeRehearsalPhase = "running"
+
+	// UpgradeRehearsalPassed indicates the new image produced blocks or started the migration on the clone.
+	UpgradeRehearsalPassed UpgradeRehearsalPhase = "passed"
+
+	// UpgradeRehearsalFailed indicates the new image failed on the clone.
+	UpgradeRehearsalFailed UpgradeRehearsalPhase = "failed"
+)
+
+// UpgradeRehearsalStatus is the outcome of rehearsing an upgrade on a clone of the node.
+type UpgradeRehearsalStatus struct {
+	// Phase of the rehearsal.
+	Phase UpgradeRehearsalPhase `json:"phase"`
+
+	// Image that was rehearsed.
+	Image string `json:"image"`
+
+	// Name of the VolumeSnapshot the clone was restored from.
+	Snapshot string `json:"snapshot"`
+
+	// Time at which the clone was created.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// Time at which the rehearsal finished.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+
+	// Human-readable outcome of the rehearsal.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // Upgrade represents an upgrade processed by cosmopilot and added to status.
@@ -1381,11 +1617,35 @@ type Upgrade struct {
 	// Container image replacement to be used in the upgrade.
 	Image string `json:"image"`
 
+	// Name of the upgrade plan, when known.
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Upgrade status.
 	Status UpgradePhase `json:"status"`
 
 	// Where cosmopilot got this upgrade from.
 	Source UpgradeSource `json:"source"`
+
+	// Name of the VolumeSnapshot taken before this upgrade, if any.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// Time at which the node was started with the upgrade image.
+	// +optional
+	AppliedAt *metav1.Time `json:"appliedAt,omitempty"`
+
+	// Images that failed to start for this upgrade and were rolled back.
+	// +optional
+	FailedImages []string `json:"failedImages,omitempty"`
+
+	// Kubernetes node on which the upgrade image was pre-pulled.
+	// +optional
+	PrePulledOn string `json:"prePulledOn,omitempty"`
+
+	// Outcome of rehearsing this upgrade on a clone of the node.
+	// +optional
+	Rehearsal *UpgradeRehearsalStatus `json:"rehearsal,omitempty"`
 }
 
 // CreateValidatorConfig holds configuration for cosmopilot to submit a create-validator transaction.
@@ -1440,6 +1700,234 @@ type CreateValidatorConfig struct {
 	GasPrices string `json:"gasPrices"`
 }
 
+// MissedBlocksConfig configures alerting on blocks missed by a validator.
+type MissedBlocksConfig struct {
+	// Number of blocks missed within the current slashing signing window at which cosmopilot emits
+	// a Warning event and sets the `ValidatorMissedBlocks` condition.
+	// +kubebuilder:validation:Minimum=1
+	Threshold int64 `json:"threshold"`
+}
+
+// ValidatorEditStatus contains the values applied by the last edit-validator transaction submitted
+// by cosmopilot. Fields that were not changed by the transaction are omitted.
+type ValidatorEditStatus struct {
+	// Moniker applied to the validator.
+	// +optional
+	Moniker *string `json:"moniker,omitempty"`
+
+	// Details applied to the validator.
+	// +optional
+	Details *string `json:"details,omitempty"`
+
+	// Website applied to the validator.
+	// +optional
+	Website *string `json:"website,omitempty"`
+
+	// Identity applied to the validator.
+	// +optional
+	Identity *string `json:"identity,omitempty"`
+
+	// Commission rate applied to the validator.
+	// +optional
+	CommissionRate *string `json:"commissionRate,omitempty"`
+
+	// Time at which the transaction was submitted.
+	Time metav1.Time `json:"time"`
+}
+
+// AutoUnjailConfig configures automatic unjailing of a validator jailed for downtime.
+type AutoUnjailConfig struct {
+	// Gas prices in decimal format to determine the transaction fee.
+	GasPrices string `json:"gasPrices"`
+
+	// Maximum number of automatic unjails within Window. Once reached, the validator must be
+	// unjailed manually. Defaults to `3`.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxUnjails *int `json:"maxUnjails,omitempty"`
+
+	// Time window for counting automatic unjails. Defaults to `24h`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Window *string `json:"window,omitempty"`
+
+	// Number of blocks the node must stay synced after its jail period ends before the unjail
+	// transaction is submitted. Defaults to `100`.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinSyncedBlocks *int64 `json:"minSyncedBlocks,omitempty"`
+}
+
+// LowBalanceConfig configures alerting when the balance of the validator account drops below a minimum.
+type LowBalanceConfig struct {
+	// Minimum balances of the validator account, one per denom (e.g. `1000000uatom`).
+	// +kubebuilder:validation:MinItems=1
+	MinBalances []string `json:"minBalances"`
+}
+
+// DoubleSignCheckConfig configures the check for signatures of the validator in recent blocks before it starts.
+type DoubleSignCheckConfig struct {
+	// RPC endpoints trusted to report recent block commits (e.g. `https://rpc.example.com:443`). Start-up is
+	// blocked until at least one of them can be reached. Required on ChainNodes that are not part of a ChainNodeSet,
+	// which adds the internal services of its other groups.
+	// +optional
+	RPCs []string `json:"rpcs,omitempty"`
+
+	// Number of most recent blocks checked for signatures of the validator. Defaults to `10`.
+	// +optional
+	// +default=10
+	// +kubebuilder:validation:Minimum=1
+	Blocks *int64 `json:"blocks,omitempty"`
+}
+
+// AutoRestakeConfig configures periodic withdrawal of the rewards and commission of a validator and their
+// delegation back to it.
+type AutoRestakeConfig struct {
+	// Gas prices in decimal format to determine the transaction fee.
+	GasPrices string `json:"gasPrices"`
+
+	// How often rewards and commission are withdrawn and restaked. Defaults to `24h`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	Frequency *string `json:"frequency,omitempty"`
+
+	// Amount kept in the validator account to pay for transaction fees (e.g. `1000000uatom`). Its denom must
+	// be the staking denom of the chain. Only the balance above it is delegated.
+	Reserve string `json:"reserve"`
+}
+
+// RestakeRun contains the result of an auto-restake run.
+type RestakeRun struct {
+	// Time at which the run started.
+	Time metav1.Time `json:"time"`
+
+	// Hash of the transaction withdrawing rewards and commission.
+	// +optional
+	WithdrawTxHash string `json:"withdrawTxHash,omitempty"`
+
+	// Hash of the transaction delegating to the validator. Omitted when there was nothing to delegate.
+	// +optional
+	DelegateTxHash string `json:"delegateTxHash,omitempty"`
+
+	// Amount delegated to the validator. While the run is in progress, the amount being delegated.
+	// +optional
+	DelegatedAmount string `json:"delegatedAmount,omitempty"`
+
+	// Reason the run failed. Omitted when successful.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// SigningState is the last height, round and step signed by a validator.
+type SigningState struct {
+	// Height of the last signed vote or proposal.
+	Height int64 `json:"height"`
+
+	// Round of the last signed vote or proposal.
+	Round int32 `json:"round"`
+
+	// Step of the last signed vote or proposal (1 for proposals, 2 for prevotes and 3 for precommits).
+	Step int32 `json:"step"`
+}
+
+// VoteOption is an option to vote on a governance proposal.
+// +kubebuilder:validation:Enum=yes;no;abstain;no_with_veto
+type VoteOption string
+
+const (
+	VoteYes        VoteOption = "yes"
+	VoteNo         VoteOption = "no"
+	VoteAbstain    VoteOption = "abstain"
+	VoteNoWithVeto VoteOption = "no_with_veto"
+)
+
+// GovernanceConfig configures tracking of governance proposals and the policy used to vote on them with
+// the validator account.
+type GovernanceConfig struct {
+	// Gas prices in decimal format to determine the transaction fee of votes.
+	GasPrices string `json:"gasPrices"`
+
+	// Vote cast on software upgrade proposals without a vote declared in `votes`. When not set, software
+	// upgrade proposals are voted with `defaultVote`.
+	// +optional
+	SoftwareUpgradeVote *VoteOption `json:"softwareUpgradeVote,omitempty"`
+
+	// Vote cast on proposals without a vote declared in `votes`. When not set, these proposals are only
+	// tracked and must be voted manually.
+	// +optional
+	DefaultVote *VoteOption `json:"defaultVote,omitempty"`
+
+	// Votes declared per proposal. These take precedence over the default votes and replace any other vote
+	// previously cast by the validator account on the proposal.
+	// +optional
+	// +listType=map
+	// +listMapKey=proposalId
+	Votes []ProposalVote `json:"votes,omitempty"`
+}
+
+// ProposalVote declares the vote on a governance proposal.
+type ProposalVote struct {
+	// ID of the proposal.
+	ProposalID uint64 `json:"proposalId"`
+
+	// Option to vote with.
+	Option VoteOption `json:"option"`
+}
+
+// GovernanceProposal contains a governance proposal in voting period.
+type GovernanceProposal struct {
+	// ID of the proposal.
+	ID uint64 `json:"id"`
+
+	// Title of the proposal.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Type URLs of the messages of the proposal. Legacy proposals report the type URL of their content.
+	// +optional
+	Types []string `json:"types,omitempty"`
+
+	// Time at which the voting period ends.
+	VotingEndTime metav1.Time `json:"votingEndTime"`
+
+	// Option voted by the validator account, or the one with the highest weight for weighted votes.
+	// Omitted when the validator account did not vote yet.
+	// +optional
+	Vote VoteOption `json:"vote,omitempty"`
+
+	// Time at which cosmopilot last attempted to submit a vote on this proposal. Failed attempts are retried
+	// after a cooldown while the voting period is not over.
+	// +optional
+	VoteSubmittedAt *metav1.Time `json:"voteSubmittedAt,omitempty"`
+
+	// Reason why the last vote submitted by cosmopilot on this proposal failed. Omitted when it succeeded.
+	// +optional
+	VoteError string `json:"voteError,omitempty"`
+}
+
+// ValidatorSigningInfo contains the slashing signing info of a validator.
+type ValidatorSigningInfo struct {
+	// Number of blocks missed within the current signing window.
+	// +optional
+	MissedBlocksCounter int64 `json:"missedBlocksCounter,omitempty"`
+
+	// Position of the validator within the signing window.
+	// +optional
+	IndexOffset int64 `json:"indexOffset,omitempty"`
+
+	// Number of blocks in the signing window, as defined in slashing params.
+	// +optional
+	SignedBlocksWindow int64 `json:"signedBlocksWindow,omitempty"`
+
+	// Whether this validator has been tombstoned.
+	// +optional
+	Tombstoned bool `json:"tombstoned,omitempty"`
+
+	// Time until which this validator is jailed. Omitted when it was never jailed.
+	// +optional
+	JailedUntil *metav1.Time `json:"jailedUntil,omitempty"`
+}
+
 // VerticalAutoscalingConfig defines rules and thresholds for vertical autoscaling of a pod.
 type VerticalAutoscalingConfig struct {
 	// Enables vertical autoscaling for the pod.
@@ -1618,3 +2106,47 @@ type SubdomainsConfig struct {
 	// +optional
 	EvmRpcWs *string `json:"evmRpcWS,omitempty"`
 }
+
+// MonitoringConfig configures the Prometheus Operator resources created to scrape and alert on nodes.
+type MonitoringConfig struct {
+	// Whether to create PodMonitors instead of ServiceMonitors. Defaults to `false`.
+	// +optional
+	// +default=false
+	PodMonitor *bool `json:"podMonitor,omitempty"`
+
+	// Interval at which metrics are scraped. Defaults to `30s`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	// +default="30s"
+	Interval *string `json:"interval,omitempty"`
+
+	// Additional labels added to the monitors and rules, usually to match the selectors of a Prometheus
+	// instance.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Configures the PrometheusRule created with default alerts for the node.
+	// +optional
+	Alerts *MonitoringAlertsConfig `json:"alerts,omitempty"`
+}
+
+// MonitoringAlertsConfig configures the default alerts created for a node.
+type MonitoringAlertsConfig struct {
+	// Whether to create a PrometheusRule with the default alerts. Defaults to `true`.
+	// +optional
+	// +default=true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Time without new blocks after which the node is considered stalled. Defaults to `5m`.
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	// +default="5m"
+	HeightStalledFor *string `json:"heightStalledFor,omitempty"`
+
+	// Percentage of the data volume in use above which an alert is fired. Defaults to `90`.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +default=90
+	DiskUsagePercent *int `json:"diskUsagePercent,omitempty"`
+}
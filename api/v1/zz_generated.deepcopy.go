@@ -49,6 +49,11 @@ func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UpgradeImageResolver != nil {
+		in, out := &in.UpgradeImageResolver, &out.UpgradeImageResolver
+		*out = new(UpgradeImageResolver)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Upgrades != nil {
 		in, out := &in.Upgrades, &out.Upgrades
 		*out = make([]UpgradeSpec, len(*in))
@@ -56,6 +61,21 @@ func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PrePullUpgradeImages != nil {
+		in, out := &in.PrePullUpgradeImages, &out.PrePullUpgradeImages
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UpgradeFailurePolicy != nil {
+		in, out := &in.UpgradeFailurePolicy, &out.UpgradeFailurePolicy
+		*out = new(UpgradeFailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeRehearsal != nil {
+		in, out := &in.UpgradeRehearsal, &out.UpgradeRehearsal
+		*out = new(UpgradeRehearsal)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SdkOptions != nil {
 		in, out := &in.SdkOptions, &out.SdkOptions
 		*out = new(SdkOptions)
@@ -73,6 +93,56 @@ func (in *AppSpec) DeepCopy() *AppSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRestakeConfig) DeepCopyInto(out *AutoRestakeConfig) {
+	*out = *in
+	if in.Frequency != nil {
+		in, out := &in.Frequency, &out.Frequency
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRestakeConfig.
+func (in *AutoRestakeConfig) DeepCopy() *AutoRestakeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRestakeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoUnjailConfig) DeepCopyInto(out *AutoUnjailConfig) {
+	*out = *in
+	if in.MaxUnjails != nil {
+		in, out := &in.MaxUnjails, &out.MaxUnjails
+		*out = new(int)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinSyncedBlocks != nil {
+		in, out := &in.MinSyncedBlocks, &out.MinSyncedBlocks
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoUnjailConfig.
+func (in *AutoUnjailConfig) DeepCopy() *AutoUnjailConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoUnjailConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChainNode) DeepCopyInto(out *ChainNode) {
 	*out = *in
@@ -230,6 +300,11 @@ func (in *ChainNodeSetNodeStatus) DeepCopy() *ChainNodeSetNodeStatus {
 func (in *ChainNodeSetSpec) DeepCopyInto(out *ChainNodeSetSpec) {
 	*out = *in
 	in.App.DeepCopyInto(&out.App)
+	if in.UpgradeRollout != nil {
+		in, out := &in.UpgradeRollout, &out.UpgradeRollout
+		*out = new(UpgradeRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Genesis != nil {
 		in, out := &in.Genesis, &out.Genesis
 		*out = new(GenesisConfig)
@@ -276,6 +351,11 @@ func (in *ChainNodeSetSpec) DeepCopyInto(out *ChainNodeSetSpec) {
 		*out = new(Cosmosigner)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainNodeSetSpec.
@@ -309,7 +389,16 @@ func (in *ChainNodeSetStatus) DeepCopyInto(out *ChainNodeSetStatus) {
 	if in.Upgrades != nil {
 		in, out := &in.Upgrades, &out.Upgrades
 		*out = make([]Upgrade, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradeRollouts != nil {
+		in, out := &in.UpgradeRollouts, &out.UpgradeRollouts
+		*out = make([]UpgradeRolloutStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Seeds != nil {
 		in, out := &in.Seeds, &out.Seeds
@@ -465,6 +554,11 @@ func (in *ChainNodeSpec) DeepCopyInto(out *ChainNodeSpec) {
 		*out = new(GatewayConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainNodeSpec.
@@ -487,10 +581,51 @@ func (in *ChainNodeStatus) DeepCopyInto(out *ChainNodeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SigningInfo != nil {
+		in, out := &in.SigningInfo, &out.SigningInfo
+		*out = new(ValidatorSigningInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastValidatorEdit != nil {
+		in, out := &in.LastValidatorEdit, &out.LastValidatorEdit
+		*out = new(ValidatorEditStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proposals != nil {
+		in, out := &in.Proposals, &out.Proposals
+		*out = make([]GovernanceProposal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestakeInProgress != nil {
+		in, out := &in.RestakeInProgress, &out.RestakeInProgress
+		*out = new(RestakeRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restakes != nil {
+		in, out := &in.Restakes, &out.Restakes
+		*out = make([]RestakeRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Balances != nil {
+		in, out := &in.Balances, &out.Balances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SigningState != nil {
+		in, out := &in.SigningState, &out.SigningState
+		*out = new(SigningState)
+		**out = **in
+	}
 	if in.Upgrades != nil {
 		in, out := &in.Upgrades, &out.Upgrades
 		*out = make([]Upgrade, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.CosmosignerMigration != nil {
 		in, out := &in.CosmosignerMigration, &out.CosmosignerMigration
@@ -536,6 +671,31 @@ func (in *ChainNodeStatus) DeepCopy() *ChainNodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChainRegistryVersions) DeepCopyInto(out *ChainRegistryVersions) {
+	*out = *in
+	if in.Url != nil {
+		in, out := &in.Url, &out.Url
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainRegistryVersions.
+func (in *ChainRegistryVersions) DeepCopy() *ChainRegistryVersions {
+	if in == nil {
+		return nil
+	}
+	out := new(ChainRegistryVersions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -1466,6 +1626,31 @@ func (in *DiscoveryResourceRequirements) DeepCopy() *DiscoveryResourceRequiremen
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DoubleSignCheckConfig) DeepCopyInto(out *DoubleSignCheckConfig) {
+	*out = *in
+	if in.RPCs != nil {
+		in, out := &in.RPCs, &out.RPCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Blocks != nil {
+		in, out := &in.Blocks, &out.Blocks
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DoubleSignCheckConfig.
+func (in *DoubleSignCheckConfig) DeepCopy() *DoubleSignCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DoubleSignCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExportTarballConfig) DeepCopyInto(out *ExportTarballConfig) {
 	*out = *in
@@ -1936,6 +2121,11 @@ func (in *GlobalIngressConfig) DeepCopyInto(out *GlobalIngressConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HeightRouting != nil {
+		in, out := &in.HeightRouting, &out.HeightRouting
+		*out = new(HeightRoutingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalIngressConfig.
@@ -1948,6 +2138,87 @@ func (in *GlobalIngressConfig) DeepCopy() *GlobalIngressConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GovernanceConfig) DeepCopyInto(out *GovernanceConfig) {
+	*out = *in
+	if in.SoftwareUpgradeVote != nil {
+		in, out := &in.SoftwareUpgradeVote, &out.SoftwareUpgradeVote
+		*out = new(VoteOption)
+		**out = **in
+	}
+	if in.DefaultVote != nil {
+		in, out := &in.DefaultVote, &out.DefaultVote
+		*out = new(VoteOption)
+		**out = **in
+	}
+	if in.Votes != nil {
+		in, out := &in.Votes, &out.Votes
+		*out = make([]ProposalVote, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GovernanceConfig.
+func (in *GovernanceConfig) DeepCopy() *GovernanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GovernanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GovernanceProposal) DeepCopyInto(out *GovernanceProposal) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.VotingEndTime.DeepCopyInto(&out.VotingEndTime)
+	if in.VoteSubmittedAt != nil {
+		in, out := &in.VoteSubmittedAt, &out.VoteSubmittedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GovernanceProposal.
+func (in *GovernanceProposal) DeepCopy() *GovernanceProposal {
+	if in == nil {
+		return nil
+	}
+	out := new(GovernanceProposal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeightRoutingConfig) DeepCopyInto(out *HeightRoutingConfig) {
+	*out = *in
+	if in.RecentBlocks != nil {
+		in, out := &in.RecentBlocks, &out.RecentBlocks
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeightRoutingConfig.
+func (in *HeightRoutingConfig) DeepCopy() *HeightRoutingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HeightRoutingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IndividualIngressConfig) DeepCopyInto(out *IndividualIngressConfig) {
 	*out = *in
@@ -2050,6 +2321,108 @@ func (in *InitCommand) DeepCopy() *InitCommand {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LowBalanceConfig) DeepCopyInto(out *LowBalanceConfig) {
+	*out = *in
+	if in.MinBalances != nil {
+		in, out := &in.MinBalances, &out.MinBalances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LowBalanceConfig.
+func (in *LowBalanceConfig) DeepCopy() *LowBalanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LowBalanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MissedBlocksConfig) DeepCopyInto(out *MissedBlocksConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MissedBlocksConfig.
+func (in *MissedBlocksConfig) DeepCopy() *MissedBlocksConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MissedBlocksConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAlertsConfig) DeepCopyInto(out *MonitoringAlertsConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HeightStalledFor != nil {
+		in, out := &in.HeightStalledFor, &out.HeightStalledFor
+		*out = new(string)
+		**out = **in
+	}
+	if in.DiskUsagePercent != nil {
+		in, out := &in.DiskUsagePercent, &out.DiskUsagePercent
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAlertsConfig.
+func (in *MonitoringAlertsConfig) DeepCopy() *MonitoringAlertsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAlertsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	if in.PodMonitor != nil {
+		in, out := &in.PodMonitor, &out.PodMonitor
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(MonitoringAlertsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupSpec) DeepCopyInto(out *NodeGroupSpec) {
 	*out = *in
@@ -2149,6 +2522,16 @@ func (in *NodeGroupSpec) DeepCopyInto(out *NodeGroupSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(NodeGroupUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxBlockLag != nil {
+		in, out := &in.MaxBlockLag, &out.MaxBlockLag
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupSpec.
@@ -2162,15 +2545,45 @@ func (in *NodeGroupSpec) DeepCopy() *NodeGroupSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetValidatorConfig) DeepCopyInto(out *NodeSetValidatorConfig) {
+func (in *NodeGroupUpdateStrategy) DeepCopyInto(out *NodeGroupUpdateStrategy) {
 	*out = *in
-	if in.PrivateKeySecret != nil {
-		in, out := &in.PrivateKeySecret, &out.PrivateKeySecret
-		*out = new(string)
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int)
 		**out = **in
 	}
-	if in.Info != nil {
-		in, out := &in.Info, &out.Info
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int)
+		**out = **in
+	}
+	if in.WaitForSynced != nil {
+		in, out := &in.WaitForSynced, &out.WaitForSynced
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupUpdateStrategy.
+func (in *NodeGroupUpdateStrategy) DeepCopy() *NodeGroupUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetValidatorConfig) DeepCopyInto(out *NodeSetValidatorConfig) {
+	*out = *in
+	if in.PrivateKeySecret != nil {
+		in, out := &in.PrivateKeySecret, &out.PrivateKeySecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.Info != nil {
+		in, out := &in.Info, &out.Info
 		*out = new(ValidatorInfo)
 		(*in).DeepCopyInto(*out)
 	}
@@ -2248,6 +2661,41 @@ func (in *NodeSetValidatorConfig) DeepCopyInto(out *NodeSetValidatorConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MissedBlocks != nil {
+		in, out := &in.MissedBlocks, &out.MissedBlocks
+		*out = new(MissedBlocksConfig)
+		**out = **in
+	}
+	if in.AutoUnjail != nil {
+		in, out := &in.AutoUnjail, &out.AutoUnjail
+		*out = new(AutoUnjailConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Governance != nil {
+		in, out := &in.Governance, &out.Governance
+		*out = new(GovernanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRestake != nil {
+		in, out := &in.AutoRestake, &out.AutoRestake
+		*out = new(AutoRestakeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LowBalance != nil {
+		in, out := &in.LowBalance, &out.LowBalance
+		*out = new(LowBalanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SigningLease != nil {
+		in, out := &in.SigningLease, &out.SigningLease
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DoubleSignCheck != nil {
+		in, out := &in.DoubleSignCheck, &out.DoubleSignCheck
+		*out = new(DoubleSignCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetValidatorConfig.
@@ -2381,11 +2829,21 @@ func (in *Persistence) DeepCopyInto(out *Persistence) {
 		*out = new(VolumeSnapshotsConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SnapshotBeforeUpgrade != nil {
+		in, out := &in.SnapshotBeforeUpgrade, &out.SnapshotBeforeUpgrade
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RestoreFromSnapshot != nil {
 		in, out := &in.RestoreFromSnapshot, &out.RestoreFromSnapshot
 		*out = new(PvcSnapshot)
 		**out = **in
 	}
+	if in.RestoreFromTarball != nil {
+		in, out := &in.RestoreFromTarball, &out.RestoreFromTarball
+		*out = new(TarballRestoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InitTimeout != nil {
 		in, out := &in.InitTimeout, &out.InitTimeout
 		*out = new(string)
@@ -2410,6 +2868,21 @@ func (in *Persistence) DeepCopy() *Persistence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProposalVote) DeepCopyInto(out *ProposalVote) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProposalVote.
+func (in *ProposalVote) DeepCopy() *ProposalVote {
+	if in == nil {
+		return nil
+	}
+	out := new(ProposalVote)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PvcSnapshot) DeepCopyInto(out *PvcSnapshot) {
 	*out = *in
@@ -2450,6 +2923,22 @@ func (in *ResolvedSigner) DeepCopy() *ResolvedSigner {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestakeRun) DeepCopyInto(out *RestakeRun) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestakeRun.
+func (in *RestakeRun) DeepCopy() *RestakeRun {
+	if in == nil {
+		return nil
+	}
+	out := new(RestakeRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3ExportConfig) DeepCopyInto(out *S3ExportConfig) {
 	*out = *in
@@ -2613,6 +3102,21 @@ func (in *SidecarSpec) DeepCopy() *SidecarSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigningState) DeepCopyInto(out *SigningState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigningState.
+func (in *SigningState) DeepCopy() *SigningState {
+	if in == nil {
+		return nil
+	}
+	out := new(SigningState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotExportDestination) DeepCopyInto(out *SnapshotExportDestination) {
 	*out = *in
@@ -2668,6 +3172,31 @@ func (in *SnapshotExportStatus) DeepCopy() *SnapshotExportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotHeightSchedule) DeepCopyInto(out *SnapshotHeightSchedule) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Heights != nil {
+		in, out := &in.Heights, &out.Heights
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotHeightSchedule.
+func (in *SnapshotHeightSchedule) DeepCopy() *SnapshotHeightSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotHeightSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StateSyncConfig) DeepCopyInto(out *StateSyncConfig) {
 	*out = *in
@@ -2728,6 +3257,46 @@ func (in *SubdomainsConfig) DeepCopy() *SubdomainsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TarballRestoreConfig) DeepCopyInto(out *TarballRestoreConfig) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Height != nil {
+		in, out := &in.Height, &out.Height
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GcsExportConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3ExportConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TarballRestoreConfig.
+func (in *TarballRestoreConfig) DeepCopy() *TarballRestoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TarballRestoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TmKMS) DeepCopyInto(out *TmKMS) {
 	*out = *in
@@ -2827,6 +3396,20 @@ func (in *TmKmsProvider) DeepCopy() *TmKmsProvider {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Upgrade) DeepCopyInto(out *Upgrade) {
 	*out = *in
+	if in.AppliedAt != nil {
+		in, out := &in.AppliedAt, &out.AppliedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FailedImages != nil {
+		in, out := &in.FailedImages, &out.FailedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rehearsal != nil {
+		in, out := &in.Rehearsal, &out.Rehearsal
+		*out = new(UpgradeRehearsalStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Upgrade.
@@ -2839,6 +3422,197 @@ func (in *Upgrade) DeepCopy() *Upgrade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeFailurePolicy) DeepCopyInto(out *UpgradeFailurePolicy) {
+	*out = *in
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = new(string)
+		**out = **in
+	}
+	if in.RestoreData != nil {
+		in, out := &in.RestoreData, &out.RestoreData
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeFailurePolicy.
+func (in *UpgradeFailurePolicy) DeepCopy() *UpgradeFailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeFailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeImageResolver) DeepCopyInto(out *UpgradeImageResolver) {
+	*out = *in
+	if in.FetchPlanInfo != nil {
+		in, out := &in.FetchPlanInfo, &out.FetchPlanInfo
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ChainRegistry != nil {
+		in, out := &in.ChainRegistry, &out.ChainRegistry
+		*out = new(ChainRegistryVersions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeImageResolver.
+func (in *UpgradeImageResolver) DeepCopy() *UpgradeImageResolver {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeImageResolver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRehearsal) DeepCopyInto(out *UpgradeRehearsal) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRehearsal.
+func (in *UpgradeRehearsal) DeepCopy() *UpgradeRehearsal {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRehearsal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRehearsalStatus) DeepCopyInto(out *UpgradeRehearsalStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRehearsalStatus.
+func (in *UpgradeRehearsalStatus) DeepCopy() *UpgradeRehearsalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRehearsalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutHealthGates) DeepCopyInto(out *UpgradeRolloutHealthGates) {
+	*out = *in
+	if in.Synced != nil {
+		in, out := &in.Synced, &out.Synced
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HeightAdvancing != nil {
+		in, out := &in.HeightAdvancing, &out.HeightAdvancing
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutHealthGates.
+func (in *UpgradeRolloutHealthGates) DeepCopy() *UpgradeRolloutHealthGates {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutHealthGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutStatus) DeepCopyInto(out *UpgradeRolloutStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StageStartedAt != nil {
+		in, out := &in.StageStartedAt, &out.StageStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.HealthySince != nil {
+		in, out := &in.HealthySince, &out.HealthySince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutStatus.
+func (in *UpgradeRolloutStatus) DeepCopy() *UpgradeRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRolloutStrategy) DeepCopyInto(out *UpgradeRolloutStrategy) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(int)
+		**out = **in
+	}
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.HealthGates != nil {
+		in, out := &in.HealthGates, &out.HealthGates
+		*out = new(UpgradeRolloutHealthGates)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRolloutStrategy.
+func (in *UpgradeRolloutStrategy) DeepCopy() *UpgradeRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeSpec) DeepCopyInto(out *UpgradeSpec) {
 	*out = *in
@@ -2847,6 +3621,11 @@ func (in *UpgradeSpec) DeepCopyInto(out *UpgradeSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FallbackImage != nil {
+		in, out := &in.FallbackImage, &out.FallbackImage
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeSpec.
@@ -2902,6 +3681,41 @@ func (in *ValidatorConfig) DeepCopyInto(out *ValidatorConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MissedBlocks != nil {
+		in, out := &in.MissedBlocks, &out.MissedBlocks
+		*out = new(MissedBlocksConfig)
+		**out = **in
+	}
+	if in.AutoUnjail != nil {
+		in, out := &in.AutoUnjail, &out.AutoUnjail
+		*out = new(AutoUnjailConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Governance != nil {
+		in, out := &in.Governance, &out.Governance
+		*out = new(GovernanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRestake != nil {
+		in, out := &in.AutoRestake, &out.AutoRestake
+		*out = new(AutoRestakeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LowBalance != nil {
+		in, out := &in.LowBalance, &out.LowBalance
+		*out = new(LowBalanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SigningLease != nil {
+		in, out := &in.SigningLease, &out.SigningLease
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DoubleSignCheck != nil {
+		in, out := &in.DoubleSignCheck, &out.DoubleSignCheck
+		*out = new(DoubleSignCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorConfig.
@@ -2914,6 +3728,47 @@ func (in *ValidatorConfig) DeepCopy() *ValidatorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatorEditStatus) DeepCopyInto(out *ValidatorEditStatus) {
+	*out = *in
+	if in.Moniker != nil {
+		in, out := &in.Moniker, &out.Moniker
+		*out = new(string)
+		**out = **in
+	}
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = new(string)
+		**out = **in
+	}
+	if in.Website != nil {
+		in, out := &in.Website, &out.Website
+		*out = new(string)
+		**out = **in
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(string)
+		**out = **in
+	}
+	if in.CommissionRate != nil {
+		in, out := &in.CommissionRate, &out.CommissionRate
+		*out = new(string)
+		**out = **in
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorEditStatus.
+func (in *ValidatorEditStatus) DeepCopy() *ValidatorEditStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatorEditStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidatorInfo) DeepCopyInto(out *ValidatorInfo) {
 	*out = *in
@@ -2949,6 +3804,25 @@ func (in *ValidatorInfo) DeepCopy() *ValidatorInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidatorSigningInfo) DeepCopyInto(out *ValidatorSigningInfo) {
+	*out = *in
+	if in.JailedUntil != nil {
+		in, out := &in.JailedUntil, &out.JailedUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidatorSigningInfo.
+func (in *ValidatorSigningInfo) DeepCopy() *ValidatorSigningInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidatorSigningInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerticalAutoscalingConfig) DeepCopyInto(out *VerticalAutoscalingConfig) {
 	*out = *in
@@ -3070,6 +3944,16 @@ func (in *VerticalAutoscalingRule) DeepCopy() *VerticalAutoscalingRule {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotsConfig) DeepCopyInto(out *VolumeSnapshotsConfig) {
 	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+	if in.HeightSchedule != nil {
+		in, out := &in.HeightSchedule, &out.HeightSchedule
+		*out = new(SnapshotHeightSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Retention != nil {
 		in, out := &in.Retention, &out.Retention
 		*out = new(string)
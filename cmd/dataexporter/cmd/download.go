@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/voluzi/cosmopilot/v3/pkg/dataexporter"
+	"github.com/voluzi/cosmopilot/v3/pkg/environ"
+)
+
+func newDownloadCmd() *cobra.Command {
+	var latest bool
+	var reportPeriod time.Duration
+
+	command := &cobra.Command{
+		Use:   "download <bucket> <name> <dir>",
+		Short: "Downloads a tar archive from external storage and extracts it",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bucket, name, dir := args[0], args[1], args[2]
+			if latest {
+				archive, err := exporter.Latest(bucket, name)
+				if err != nil {
+					return err
+				}
+				log.WithField("name", archive).Info("found latest archive")
+				name = archive
+			}
+			start := time.Now()
+			if err := exporter.Download(bucket, name, dir,
+				dataexporter.WithDownloadReportPeriod(reportPeriod),
+			); err != nil {
+				return err
+			}
+			log.WithField("time-elapsed", time.Since(start)).Info("download successful")
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&latest, "latest",
+		environ.GetBool("LATEST", false),
+		"Treat name as a prefix and download the most recent archive matching it",
+	)
+	command.Flags().DurationVar(&reportPeriod, "report-period",
+		environ.GetDuration("REPORT_PERIOD", dataexporter.DefaultReportPeriod),
+		"Period for progress reporting",
+	)
+	return command
+}
@@ -9,7 +9,7 @@ import (
 var gcsCmd = &cobra.Command{
 	Use:   "gcs",
 	Short: "Google Cloud Storage (GCS) operations",
-	Long:  "Manage uploads and deletions in Google Cloud Storage (GCS).",
+	Long:  "Manage uploads, downloads and deletions in Google Cloud Storage (GCS).",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
 			return err
@@ -23,5 +23,6 @@ var gcsCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(gcsCmd)
 	gcsCmd.AddCommand(newUploadCmd(dataexporter.DefaultChunkSize))
+	gcsCmd.AddCommand(newDownloadCmd())
 	gcsCmd.AddCommand(newDeleteCmd())
 }
@@ -18,7 +18,7 @@ var (
 var s3Cmd = &cobra.Command{
 	Use:   "s3",
 	Short: "Amazon S3 and S3-compatible storage operations",
-	Long:  "Manage uploads and deletions in Amazon S3, MinIO, DigitalOcean Spaces, and compatible object stores.",
+	Long:  "Manage uploads, downloads and deletions in Amazon S3, MinIO, DigitalOcean Spaces, and compatible object stores.",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
 			return err
@@ -48,5 +48,6 @@ func init() {
 		"Use path-style bucket addressing",
 	)
 	s3Cmd.AddCommand(newUploadCmd(dataexporter.DefaultS3ChunkSize))
+	s3Cmd.AddCommand(newDownloadCmd())
 	s3Cmd.AddCommand(newDeleteCmd())
 }
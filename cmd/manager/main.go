@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -110,6 +111,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := ctrlmetrics.Registry.Register(chainnode.NewMetricsCollector(mgr.GetClient(), &runOpts)); err != nil {
+		setupLog.Error(err, "unable to register chainnode metrics")
+		os.Exit(1)
+	}
+
 	chainNodeSetReconciler, err := chainnodeset.New(mgr, clientSet, &runOpts)
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ChainNodeSet")
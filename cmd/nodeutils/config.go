@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"strings"
 
 	"github.com/voluzi/cosmopilot/v3/pkg/environ"
 )
@@ -69,8 +70,63 @@ func init() {
 		"the height at which this server will be halted",
 	)
 
+	flag.StringVar(&signingLease, "signing-lease",
+		environ.GetString("SIGNING_LEASE", ""),
+		"name of the lease that must be held before the node is allowed to sign",
+	)
+
+	flag.StringVar(&podNamespace, "pod-namespace",
+		environ.GetString("POD_NAMESPACE", ""),
+		"namespace of this pod, where the signing lease lives",
+	)
+
+	flag.StringVar(&podUID, "pod-uid",
+		environ.GetString("POD_UID", ""),
+		"UID of this pod, used as identity when holding the signing lease",
+	)
+
+	flag.StringVar(&doubleSignCheckAddress, "double-sign-check-address",
+		environ.GetString("DOUBLE_SIGN_CHECK_ADDRESS", ""),
+		"consensus address of the validator, in hex, to look for in recent blocks before the node is allowed to start",
+	)
+
+	flag.Func("double-sign-check-rpcs",
+		"comma-separated list of RPC endpoints trusted to report recent blocks (env DOUBLE_SIGN_CHECK_RPCS)",
+		func(s string) error {
+			doubleSignCheckRPCs = splitList(s)
+			return nil
+		},
+	)
+	doubleSignCheckRPCs = splitList(environ.GetString("DOUBLE_SIGN_CHECK_RPCS", ""))
+
+	flag.Int64Var(&doubleSignCheckBlocks, "double-sign-check-blocks",
+		environ.GetInt64("DOUBLE_SIGN_CHECK_BLOCKS", 10),
+		"number of recent blocks checked for signatures of the validator",
+	)
+
+	flag.StringVar(&signingStateFile, "signing-state-file",
+		environ.GetString("SIGNING_STATE_FILE", ""),
+		"file with the last signing state recorded for the validator",
+	)
+
+	flag.StringVar(&authToken, "auth-token",
+		environ.GetString("AUTH_TOKEN", ""),
+		"token required by routes that change the state of node-utils or of the node (e.g. /shutdown)",
+	)
+
 	flag.BoolVar(&mockMode, "mock-mode",
 		environ.GetBool("MOCK_MODE", false),
 		"enable mock mode for testing (returns configurable stats instead of real process stats)",
 	)
 }
+
+// splitList splits a comma-separated list, ignoring empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/voluzi/cosmopilot/v3/pkg/environ"
+	"github.com/voluzi/cosmopilot/v3/pkg/proxy"
+)
+
+const (
+	defaultHeightProxyHTTPPorts = "26657,1317,8545,8546"
+	defaultHeightProxyGRPCPorts = "9090"
+	defaultHeightProxyStatus    = 26657
+)
+
+// handleHeightProxyCommand runs a proxy that sends requests for old heights to archive nodes and all other
+// requests to recent (pruned) nodes. It is configured through environment variables set by cosmopilot.
+func handleHeightProxyCommand(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: node-utils height-proxy")
+	}
+
+	cfg, err := heightProxyConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	p, err := proxy.NewHeightProxy(cfg)
+	if err != nil {
+		return err
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		log.Infof("received signal: %v", sig)
+		if err := p.Stop(); err != nil {
+			log.Errorf("failed to stop height proxy: %v", err)
+		}
+	}()
+
+	if err := p.Start(); err != nil && !errors.Is(err, proxy.ErrStopped) {
+		return err
+	}
+	return nil
+}
+
+func heightProxyConfigFromEnv() (proxy.HeightConfig, error) {
+	httpPorts, err := parsePorts(environ.GetString("HTTP_PORTS", defaultHeightProxyHTTPPorts))
+	if err != nil {
+		return proxy.HeightConfig{}, err
+	}
+	grpcPorts, err := parsePorts(environ.GetString("GRPC_PORTS", defaultHeightProxyGRPCPorts))
+	if err != nil {
+		return proxy.HeightConfig{}, err
+	}
+	return proxy.HeightConfig{
+		RecentHosts:  splitList(environ.GetString("RECENT_HOSTS", "")),
+		ArchiveHosts: splitList(environ.GetString("ARCHIVE_HOSTS", "")),
+		RecentBlocks: environ.GetInt64("RECENT_BLOCKS", 0),
+		HTTPPorts:    httpPorts,
+		GRPCPorts:    grpcPorts,
+		StatusPort:   environ.GetInt("STATUS_PORT", defaultHeightProxyStatus),
+	}, nil
+}
+
+func parsePorts(s string) ([]int, error) {
+	var ports []int
+	for _, item := range splitList(s) {
+		port, err := strconv.Atoi(item)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %q", item)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeightProxyConfigFromEnv(t *testing.T) {
+	t.Setenv("RECENT_HOSTS", "set-pruned.default.svc.cluster.local, set-fullnodes.default.svc.cluster.local")
+	t.Setenv("ARCHIVE_HOSTS", "set-archive.default.svc.cluster.local")
+	t.Setenv("RECENT_BLOCKS", "1000")
+
+	cfg, err := heightProxyConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"set-pruned.default.svc.cluster.local", "set-fullnodes.default.svc.cluster.local"}; !reflect.DeepEqual(cfg.RecentHosts, want) {
+		t.Errorf("RecentHosts = %q, want %q", cfg.RecentHosts, want)
+	}
+	if want := []string{"set-archive.default.svc.cluster.local"}; !reflect.DeepEqual(cfg.ArchiveHosts, want) {
+		t.Errorf("ArchiveHosts = %q, want %q", cfg.ArchiveHosts, want)
+	}
+	if cfg.RecentBlocks != 1000 {
+		t.Errorf("RecentBlocks = %d, want 1000", cfg.RecentBlocks)
+	}
+	if want := []int{26657, 1317, 8545, 8546}; !reflect.DeepEqual(cfg.HTTPPorts, want) {
+		t.Errorf("HTTPPorts = %v, want %v", cfg.HTTPPorts, want)
+	}
+	if want := []int{9090}; !reflect.DeepEqual(cfg.GRPCPorts, want) {
+		t.Errorf("GRPCPorts = %v, want %v", cfg.GRPCPorts, want)
+	}
+	if cfg.StatusPort != 26657 {
+		t.Errorf("StatusPort = %d, want 26657", cfg.StatusPort)
+	}
+
+	t.Setenv("GRPC_PORTS", "9090,invalid")
+	if _, err := heightProxyConfigFromEnv(); err == nil {
+		t.Error("expected an error for an invalid port")
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"flag"
 	"fmt"
 	"io"
@@ -32,11 +31,21 @@ var (
 	signerPeerDNS    string
 	nodeBinaryName   string
 	haltHeight       int64
+	signingLease     string
+	podNamespace     string
+	podUID           string
+
+	doubleSignCheckAddress string
+	doubleSignCheckRPCs    []string
+	doubleSignCheckBlocks  int64
+	signingStateFile       string
+
+	authToken string
 )
 
 // subcommands are the standalone entry points this binary implements. They run in containers that
 // mount none of the server's runtime configuration, so they must never reach startServer.
-var subcommands = []string{"help", "mock", "wait-for-dns"}
+var subcommands = []string{"check-signing-state", "height-proxy", "help", "mock", "wait-for-dns"}
 
 // mockCommandArity is the single command contract used before mock dispatch. Keeping command
 // recognition and exact arity together prevents validation from drifting from execution.
@@ -49,16 +58,20 @@ var mockCommandArity = map[string]int{
 // commands are the entry points run dispatches to. Tests replace them to assert which one a given
 // argument list selects.
 type commands struct {
-	waitForDNS func([]string) error
-	mock       func([]string)
-	serve      func() error
+	waitForDNS        func([]string) error
+	checkSigningState func([]string) error
+	heightProxy       func([]string) error
+	mock              func([]string)
+	serve             func() error
 }
 
 func defaultCommands() commands {
 	return commands{
-		waitForDNS: handleWaitForDNSCommand,
-		mock:       handleMockCommand,
-		serve:      startServer,
+		waitForDNS:        handleWaitForDNSCommand,
+		checkSigningState: handleCheckSigningStateCommand,
+		heightProxy:       handleHeightProxyCommand,
+		mock:              handleMockCommand,
+		serve:             startServer,
 	}
 }
 
@@ -90,6 +103,10 @@ func run(args []string, cmds commands) error {
 			return nil
 		case "wait-for-dns":
 			return cmds.waitForDNS(args[1:])
+		case "check-signing-state":
+			return cmds.checkSigningState(args[1:])
+		case "height-proxy":
+			return cmds.heightProxy(args[1:])
 		default:
 			return fmt.Errorf("unknown subcommand %q: this node-utils build implements %s",
 				args[0], strings.Join(subcommands, ", "))
@@ -153,6 +170,10 @@ func startServer() error {
 		nodeutils.WithSignerPeerDNS(signerPeerDNS),
 		nodeutils.WithHaltHeight(haltHeight),
 		nodeutils.WithMockMode(mockMode),
+		nodeutils.WithSigningLease(podNamespace, signingLease, podUID),
+		nodeutils.WithDoubleSignCheck(doubleSignCheckAddress, doubleSignCheckRPCs, doubleSignCheckBlocks),
+		nodeutils.WithSigningStateFile(signingStateFile),
+		nodeutils.WithAuthToken(authToken),
 	)
 	if err != nil {
 		return err
@@ -177,6 +198,12 @@ Usage:
   node-utils mock <command>    Control mock mode (use from kubectl exec)
   node-utils wait-for-dns <hostname> <ip-address> <timeout>
                                Wait until DNS publishes an address
+  node-utils check-signing-state <minimum-state-file>
+                               Restore the signing state in the data directory if it
+                               is behind the one in minimum-state-file
+  node-utils height-proxy      Run a proxy that sends requests for old heights to archive
+                               nodes (configured with RECENT_HOSTS, ARCHIVE_HOSTS and
+                               RECENT_BLOCKS)
   node-utils help              Show this help
 
 Mock Commands (for E2E testing):
@@ -188,6 +215,16 @@ Flags:`)
 	flag.PrintDefaults()
 }
 
+// handleCheckSigningStateCommand restores priv_validator_state.json in the data directory (DATA_DIR) when it is
+// behind the signing state recorded by cosmopilot, so that a validator never signs again at a height it might
+// already have signed.
+func handleCheckSigningStateCommand(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: node-utils check-signing-state <minimum-state-file>")
+	}
+	return nodeutils.CheckSigningState(environ.GetString("DATA_DIR", nodeutils.DefaultDataPath), args[0])
+}
+
 // handleMockCommand processes mock subcommands for controlling mock mode via CLI.
 // This is useful for E2E tests running kubectl exec against distroless containers.
 func handleMockCommand(args []string) {
@@ -206,7 +243,7 @@ func handleMockCommand(args []string) {
 	switch args[0] {
 	case "set-cpu":
 		url := fmt.Sprintf("%s/mock/cpu?millicores=%s", baseURL, args[1])
-		resp, err := http.Post(url, "text/plain", bytes.NewBuffer(nil))
+		resp, err := mockRequest(http.MethodPost, url)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -222,7 +259,7 @@ func handleMockCommand(args []string) {
 	case "set-memory":
 		url := fmt.Sprintf("%s/mock/memory?mib=%s", baseURL, args[1])
 		fmt.Printf("DEBUG: POST %s\n", url)
-		resp, err := http.Post(url, "text/plain", bytes.NewBuffer(nil))
+		resp, err := mockRequest(http.MethodPost, url)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error making request to %s: %v\n", url, err)
 			os.Exit(1)
@@ -238,7 +275,7 @@ func handleMockCommand(args []string) {
 
 	case "get":
 		url := fmt.Sprintf("%s/mock/stats", baseURL)
-		resp, err := http.Get(url)
+		resp, err := mockRequest(http.MethodGet, url)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -257,3 +294,17 @@ func handleMockCommand(args []string) {
 		os.Exit(1)
 	}
 }
+
+// mockRequest sends a request to the mock routes of the local node-utils server, authenticated with the token
+// from AUTH_TOKEN (set in the node-utils container by cosmopilot).
+func mockRequest(method, url string) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	if token := environ.GetString("AUTH_TOKEN", ""); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return http.DefaultClient.Do(req)
+}
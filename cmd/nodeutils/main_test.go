@@ -23,6 +23,14 @@ func testCommands(t *testing.T) commands {
 			t.Fatal("run() dispatched wait-for-dns unexpectedly")
 			return nil
 		},
+		checkSigningState: func([]string) error {
+			t.Fatal("run() dispatched check-signing-state unexpectedly")
+			return nil
+		},
+		heightProxy: func([]string) error {
+			t.Fatal("run() dispatched height-proxy unexpectedly")
+			return nil
+		},
 		mock: func([]string) { t.Fatal("run() dispatched the mock command unexpectedly") },
 		serve: func() error {
 			t.Fatalf("run() reached node-utils server startup, which requires %s", nodeutils.DefaultUpgradesConfig)
@@ -133,6 +141,38 @@ func TestRunDispatchesStandaloneSubcommands(t *testing.T) {
 		}
 	})
 
+	t.Run("check-signing-state", func(t *testing.T) {
+		var forwarded []string
+		cmds := testCommands(t)
+		cmds.checkSigningState = func(args []string) error {
+			forwarded = append([]string(nil), args...)
+			return nil
+		}
+
+		if err := run([]string{"check-signing-state", "/signing-state/priv_validator_state.json"}, cmds); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"/signing-state/priv_validator_state.json"}; !reflect.DeepEqual(forwarded, want) {
+			t.Fatalf("check-signing-state arguments = %q, want %q", forwarded, want)
+		}
+	})
+
+	t.Run("height-proxy", func(t *testing.T) {
+		called := false
+		cmds := testCommands(t)
+		cmds.heightProxy = func(args []string) error {
+			called = len(args) == 0
+			return nil
+		}
+
+		if err := run([]string{"height-proxy"}, cmds); err != nil {
+			t.Fatal(err)
+		}
+		if !called {
+			t.Fatal("run() did not dispatch height-proxy without arguments")
+		}
+	})
+
 	for _, args := range [][]string{{"help"}, {"--help"}, {"-h"}} {
 		t.Run(args[0], func(t *testing.T) {
 			if err := run(args, testCommands(t)); err != nil {
@@ -43,6 +43,7 @@ const sidebars: SidebarsConfig = {
       label: 'Operations',
       collapsed: false,
       items: [
+        'operations/upgrading',
         'operations/troubleshooting',
       ],
     },
@@ -50,6 +50,16 @@ type NodeInfo struct {
 	Identity *string
 }
 
+// ValidatorEdit holds the validator fields changed by an edit-validator transaction.
+// Nil fields are left unchanged.
+type ValidatorEdit struct {
+	Moniker        *string
+	Details        *string
+	Website        *string
+	Identity       *string
+	CommissionRate *string
+}
+
 type AccountAssets struct {
 	Address string
 	Assets  []string
@@ -79,6 +89,13 @@ type InitCommand struct {
 	Args      []string
 	Resources corev1.ResourceRequirements
 	Env       []corev1.EnvVar
+	EnvFrom   []corev1.EnvFromSource
+	// Volumes are added to the init pod. They are only mounted on this command through VolumeMounts.
+	Volumes      []corev1.Volume
+	VolumeMounts []corev1.VolumeMount
+	// ServiceAccountName sets the ServiceAccount the init pod runs as, for commands that authenticate
+	// through workload identity.
+	ServiceAccountName string
 }
 
 type AdditionalVolume struct {
@@ -3,7 +3,9 @@ package chainutils
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/proto/tendermint/p2p"
@@ -12,7 +14,12 @@ import (
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
 	"google.golang.org/grpc"
@@ -24,10 +31,14 @@ type Client struct {
 	rpcClient *http.HTTP
 
 	// gRPC clients
-	grpcConn      *grpc.ClientConn
-	stakingClient stakingTypes.QueryClient
-	nodeClient    tmservice.ServiceClient
-	upgradeClient upgradetypes.QueryClient
+	grpcConn        *grpc.ClientConn
+	stakingClient   stakingTypes.QueryClient
+	nodeClient      tmservice.ServiceClient
+	upgradeClient   upgradetypes.QueryClient
+	slashingClient  slashingtypes.QueryClient
+	govClient       govv1.QueryClient
+	govLegacyClient govv1beta1.QueryClient
+	bankClient      banktypes.QueryClient
 }
 
 func NewClient(host string) (*Client, error) {
@@ -52,11 +63,15 @@ func NewClient(host string) (*Client, error) {
 	}
 
 	return &Client{
-		rpcClient:     tmClient,
-		grpcConn:      grpcConn,
-		stakingClient: stakingTypes.NewQueryClient(grpcConn),
-		nodeClient:    tmservice.NewServiceClient(grpcConn),
-		upgradeClient: upgradetypes.NewQueryClient(grpcConn),
+		rpcClient:       tmClient,
+		grpcConn:        grpcConn,
+		stakingClient:   stakingTypes.NewQueryClient(grpcConn),
+		nodeClient:      tmservice.NewServiceClient(grpcConn),
+		upgradeClient:   upgradetypes.NewQueryClient(grpcConn),
+		slashingClient:  slashingtypes.NewQueryClient(grpcConn),
+		govClient:       govv1.NewQueryClient(grpcConn),
+		govLegacyClient: govv1beta1.NewQueryClient(grpcConn),
+		bankClient:      banktypes.NewQueryClient(grpcConn),
 	}, nil
 }
 
@@ -86,6 +101,64 @@ func (c *Client) GetValidators(ctx context.Context) ([]stakingTypes.Validator, e
 	return response.Validators, nil
 }
 
+func (c *Client) QuerySigningInfo(ctx context.Context, consAddress string) (*slashingtypes.ValidatorSigningInfo, error) {
+	response, err := c.slashingClient.SigningInfo(ctx, &slashingtypes.QuerySigningInfoRequest{
+		ConsAddress: consAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying signing info of %s: %w", consAddress, err)
+	}
+	return &response.ValSigningInfo, nil
+}
+
+func (c *Client) QuerySlashingParams(ctx context.Context) (*slashingtypes.Params, error) {
+	response, err := c.slashingClient.Params(ctx, &slashingtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("querying slashing params: %w", err)
+	}
+	return &response.Params, nil
+}
+
+func (c *Client) QueryBalance(ctx context.Context, address, denom string) (*sdk.Coin, error) {
+	response, err := c.bankClient.Balance(ctx, &banktypes.QueryBalanceRequest{
+		Address: address,
+		Denom:   denom,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying %s balance of %s: %w", denom, address, err)
+	}
+	return response.Balance, nil
+}
+
+// ErrTxIndexDisabled is returned when transactions cannot be looked up because the tx indexer of the node
+// is disabled.
+var ErrTxIndexDisabled = errors.New("transaction indexing is disabled on the node (tx_index.indexer in config.toml)")
+
+// ErrTxFailed is returned when a transaction was included in a block but its execution failed.
+var ErrTxFailed = errors.New("transaction failed")
+
+// TxIncluded returns true if a transaction was included in a block, and an error if its execution failed.
+// Looking up transactions requires the tx indexer of the node to be enabled.
+func (c *Client) TxIncluded(ctx context.Context, hash string) (bool, error) {
+	txHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return false, fmt.Errorf("decoding transaction hash %s: %w", hash, err)
+	}
+
+	result, err := c.rpcClient.Tx(ctx, txHash, false)
+	switch {
+	case err != nil && strings.Contains(err.Error(), "indexing is disabled"):
+		return false, ErrTxIndexDisabled
+	case err != nil && strings.Contains(err.Error(), "not found"):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("querying transaction %s: %w", hash, err)
+	case result.TxResult.Code != 0:
+		return false, fmt.Errorf("%w: %s returned code %d: %s", ErrTxFailed, hash, result.TxResult.Code, result.TxResult.Log)
+	}
+	return true, nil
+}
+
 func (c *Client) GetLatestBlock(ctx context.Context) (*tmtypes.Block, error) {
 	response, err := c.nodeClient.GetLatestBlock(ctx, &tmservice.GetLatestBlockRequest{})
 	if err != nil {
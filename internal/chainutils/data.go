@@ -116,14 +116,19 @@ func (a *App) BuildInitPod(pvc *corev1.PersistentVolumeClaim, additionalVolumes
 
 	// Add additional commands
 	for i, cmd := range initCommands {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, cmd.Volumes...)
+		if cmd.ServiceAccountName != "" {
+			pod.Spec.ServiceAccountName = cmd.ServiceAccountName
+		}
 		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
 			Name:            fmt.Sprintf("init-command-%d", i),
 			Image:           cmd.Image,
 			Command:         cmd.Command,
 			Args:            cmd.Args,
-			VolumeMounts:    initCommandVolumeMounts,
+			VolumeMounts:    append(append([]corev1.VolumeMount{}, initCommandVolumeMounts...), cmd.VolumeMounts...),
 			Resources:       cmd.Resources,
 			Env:             cmd.Env,
+			EnvFrom:         cmd.EnvFrom,
 			SecurityContext: k8s.RestrictedSecurityContext(),
 		})
 	}
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
 )
@@ -168,3 +169,55 @@ func TestBuildGenesisPodPropagatesAppEnvOnlyToChainCLIContainers(t *testing.T) {
 	}
 	assert.Empty(t, requireContainer(t, pod.Spec.Containers, "busybox").Env)
 }
+
+func TestBuildUnjailPod(t *testing.T) {
+	app := newTestAppWithEnv(t, testAppEnv())
+
+	pod := app.buildUnjailPod(&Params{ChainID: "chain", GasPrices: "0.025stake"}, "tcp://node:26657")
+
+	assert.Equal(t, testAppEnv(), requireContainer(t, pod.Spec.InitContainers, "load-account").Env)
+	container := requireContainer(t, pod.Spec.Containers, "unjail")
+	assert.Equal(t, testAppEnv(), container.Env)
+	assert.Subset(t, container.Args, []string{"slashing", "unjail", "--chain-id", "chain", "--gas-prices", "0.025stake", "--node", "tcp://node:26657"})
+}
+
+func TestBuildEditValidatorPod(t *testing.T) {
+	app := newTestAppWithEnv(t, testAppEnv())
+
+	pod := app.buildEditValidatorPod(
+		&ValidatorEdit{Moniker: ptr.To("validator"), CommissionRate: ptr.To("0.05")},
+		&Params{ChainID: "chain", GasPrices: "0.025stake"},
+		"tcp://node:26657",
+	)
+
+	container := requireContainer(t, pod.Spec.Containers, "edit-validator")
+	assert.Equal(t, testAppEnv(), container.Env)
+	assert.Subset(t, container.Args, []string{"edit-validator", "--new-moniker", "validator", "--commission-rate", "0.05"})
+	assert.NotContains(t, container.Args, "--details")
+}
+
+func TestBuildVotePod(t *testing.T) {
+	app := newTestAppWithEnv(t, testAppEnv())
+
+	pod := app.buildVotePod(42, "yes", &Params{ChainID: "chain", GasPrices: "0.025stake"}, "tcp://node:26657")
+
+	assert.Equal(t, testAppEnv(), requireContainer(t, pod.Spec.InitContainers, "load-account").Env)
+	container := requireContainer(t, pod.Spec.Containers, "vote")
+	assert.Equal(t, testAppEnv(), container.Env)
+	assert.Subset(t, container.Args, []string{"gov", "vote", "42", "yes", "--chain-id", "chain", "--gas-prices", "0.025stake", "--node", "tcp://node:26657"})
+}
+
+func TestBuildRestakePods(t *testing.T) {
+	app := newTestAppWithEnv(t, testAppEnv())
+	params := &Params{ChainID: "chain", GasPrices: "0.025stake"}
+
+	pod := app.buildWithdrawRewardsPod("cosmosvaloper1abc", params, "tcp://node:26657")
+	container := requireContainer(t, pod.Spec.Containers, "withdraw-rewards")
+	assert.Equal(t, testAppEnv(), container.Env)
+	assert.Subset(t, container.Args, []string{"distribution", "withdraw-rewards", "cosmosvaloper1abc", "--commission", "--output", "json"})
+
+	pod = app.buildDelegatePod("cosmosvaloper1abc", "1000stake", params, "tcp://node:26657")
+	container = requireContainer(t, pod.Spec.Containers, "delegate")
+	assert.Equal(t, testAppEnv(), container.Env)
+	assert.Subset(t, container.Args, []string{"staking", "delegate", "cosmosvaloper1abc", "1000stake", "--output", "json"})
+}
@@ -0,0 +1,217 @@
+package chainutils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	msgExecLegacyContentTypeURL    = "/cosmos.gov.v1beta1.MsgExecLegacyContent"
+	msgSoftwareUpgradeTypeURL      = "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"
+	softwareUpgradeProposalTypeURL = "/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal"
+	voteOptionPrefix               = "VOTE_OPTION_"
+)
+
+// Proposal is a governance proposal in voting period.
+type Proposal struct {
+	ID    uint64
+	Title string
+	// Type URLs of the messages of the proposal. Legacy proposals report the type URL of their content.
+	Types         []string
+	VotingEndTime time.Time
+}
+
+// IsSoftwareUpgrade returns true if the proposal schedules a software upgrade.
+func (p *Proposal) IsSoftwareUpgrade() bool {
+	for _, t := range p.Types {
+		if t == msgSoftwareUpgradeTypeURL || t == softwareUpgradeProposalTypeURL {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProposalsInVotingPeriod returns the governance proposals currently in voting period. Chains that
+// do not serve gov v1 queries (cosmos-sdk < v0.46) are queried through gov v1beta1.
+func (c *Client) GetProposalsInVotingPeriod(ctx context.Context) ([]Proposal, error) {
+	response, err := c.govClient.Proposals(ctx, &govv1.QueryProposalsRequest{
+		ProposalStatus: govv1.StatusVotingPeriod,
+		Pagination: &query.PageRequest{
+			Limit: paginationLimit,
+		},
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return c.getLegacyProposalsInVotingPeriod(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying proposals in voting period: %w", err)
+	}
+
+	proposals := make([]Proposal, len(response.Proposals))
+	for i, p := range response.Proposals {
+		proposals[i] = proposalFromV1(p)
+	}
+	return proposals, nil
+}
+
+func (c *Client) getLegacyProposalsInVotingPeriod(ctx context.Context) ([]Proposal, error) {
+	response, err := c.govLegacyClient.Proposals(ctx, &govv1beta1.QueryProposalsRequest{
+		ProposalStatus: govv1beta1.StatusVotingPeriod,
+		Pagination: &query.PageRequest{
+			Limit: paginationLimit,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying proposals in voting period: %w", err)
+	}
+
+	proposals := make([]Proposal, len(response.Proposals))
+	for i, p := range response.Proposals {
+		proposals[i] = Proposal{
+			ID:            p.ProposalId,
+			VotingEndTime: p.VotingEndTime,
+		}
+		if p.Content != nil {
+			proposals[i].Title = legacyContentTitle(p.Content)
+			proposals[i].Types = []string{p.Content.TypeUrl}
+		}
+	}
+	return proposals, nil
+}
+
+// QueryVote returns the option voted by voter on a proposal, or an empty string if voter did not vote
+// on it. Options are returned as accepted by the `tx gov vote` command (e.g. `yes` or `no_with_veto`).
+// For weighted votes, the option with the highest weight is returned.
+func (c *Client) QueryVote(ctx context.Context, proposalID uint64, voter string) (string, error) {
+	response, err := c.govClient.Vote(ctx, &govv1.QueryVoteRequest{
+		ProposalId: proposalID,
+		Voter:      voter,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return c.queryLegacyVote(ctx, proposalID, voter)
+	}
+	if isVoteNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("querying vote of %s on proposal %d: %w", voter, proposalID, err)
+	}
+
+	options := make([]govv1.WeightedVoteOption, 0, len(response.Vote.Options))
+	for _, o := range response.Vote.Options {
+		if o != nil {
+			options = append(options, *o)
+		}
+	}
+	return mainVoteOption(options), nil
+}
+
+func (c *Client) queryLegacyVote(ctx context.Context, proposalID uint64, voter string) (string, error) {
+	response, err := c.govLegacyClient.Vote(ctx, &govv1beta1.QueryVoteRequest{
+		ProposalId: proposalID,
+		Voter:      voter,
+	})
+	if isVoteNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("querying vote of %s on proposal %d: %w", voter, proposalID, err)
+	}
+
+	if len(response.Vote.Options) == 0 {
+		//nolint:staticcheck // Option is still populated by cosmos-sdk < v0.43.
+		return voteOptionString(govv1.VoteOption(response.Vote.Option)), nil
+	}
+	options := make([]govv1.WeightedVoteOption, len(response.Vote.Options))
+	for i, o := range response.Vote.Options {
+		options[i] = govv1.WeightedVoteOption{Option: govv1.VoteOption(o.Option), Weight: o.Weight.String()}
+	}
+	return mainVoteOption(options), nil
+}
+
+// isVoteNotFound returns true if err is the error returned by x/gov when a voter has not voted on a
+// proposal. It is reported as an invalid argument, so the message must be checked.
+func isVoteNotFound(err error) bool {
+	return err != nil &&
+		(status.Code(err) == codes.NotFound || status.Code(err) == codes.InvalidArgument) &&
+		strings.Contains(err.Error(), "not found")
+}
+
+func proposalFromV1(p *govv1.Proposal) Proposal {
+	proposal := Proposal{
+		ID:    p.Id,
+		Title: p.Title,
+		Types: make([]string, 0, len(p.Messages)),
+	}
+	if p.VotingEndTime != nil {
+		proposal.VotingEndTime = *p.VotingEndTime
+	}
+
+	for _, msg := range p.Messages {
+		if msg == nil {
+			continue
+		}
+		if msg.TypeUrl != msgExecLegacyContentTypeURL {
+			proposal.Types = append(proposal.Types, msg.TypeUrl)
+			continue
+		}
+		var exec govv1.MsgExecLegacyContent
+		if err := exec.Unmarshal(msg.Value); err != nil || exec.Content == nil {
+			proposal.Types = append(proposal.Types, msg.TypeUrl)
+			continue
+		}
+		proposal.Types = append(proposal.Types, exec.Content.TypeUrl)
+		if proposal.Title == "" {
+			proposal.Title = legacyContentTitle(exec.Content)
+		}
+	}
+	return proposal
+}
+
+// legacyContentTitle returns the title of a legacy proposal content. All cosmos-sdk content types
+// declare the title as their first field, so the content is decoded as a text proposal, which skips
+// any other field.
+func legacyContentTitle(content *codectypes.Any) string {
+	var text govv1beta1.TextProposal
+	if err := text.Unmarshal(content.Value); err != nil {
+		return ""
+	}
+	return text.Title
+}
+
+// mainVoteOption returns the option with the highest weight.
+func mainVoteOption(options []govv1.WeightedVoteOption) string {
+	var (
+		main   govv1.VoteOption
+		weight = sdk.ZeroDec()
+	)
+	for _, o := range options {
+		w, err := sdk.NewDecFromStr(o.Weight)
+		if err != nil {
+			continue
+		}
+		if main == govv1.OptionEmpty || w.GT(weight) {
+			main = o.Option
+			weight = w
+		}
+	}
+	return voteOptionString(main)
+}
+
+// voteOptionString converts a vote option to the format accepted by the `tx gov vote` command.
+func voteOptionString(option govv1.VoteOption) string {
+	if option == govv1.OptionEmpty {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(option.String(), voteOptionPrefix))
+}
@@ -0,0 +1,81 @@
+package chainutils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProposalFromV1(t *testing.T) {
+	votingEnd := time.Now().UTC()
+
+	content, err := (&upgradetypes.SoftwareUpgradeProposal{ //nolint:staticcheck
+		Title:       "v2 upgrade",
+		Description: "upgrade to v2",
+		Plan:        upgradetypes.Plan{Name: "v2", Height: 1000},
+	}).Marshal()
+	require.NoError(t, err)
+	exec, err := (&govv1.MsgExecLegacyContent{
+		Content:   &codectypes.Any{TypeUrl: softwareUpgradeProposalTypeURL, Value: content},
+		Authority: "gov",
+	}).Marshal()
+	require.NoError(t, err)
+
+	t.Run("legacy content", func(t *testing.T) {
+		proposal := proposalFromV1(&govv1.Proposal{
+			Id:            3,
+			Messages:      []*codectypes.Any{{TypeUrl: msgExecLegacyContentTypeURL, Value: exec}},
+			VotingEndTime: &votingEnd,
+		})
+		assert.Equal(t, uint64(3), proposal.ID)
+		assert.Equal(t, "v2 upgrade", proposal.Title)
+		assert.Equal(t, []string{softwareUpgradeProposalTypeURL}, proposal.Types)
+		assert.Equal(t, votingEnd, proposal.VotingEndTime)
+		assert.True(t, proposal.IsSoftwareUpgrade())
+	})
+
+	t.Run("messages", func(t *testing.T) {
+		proposal := proposalFromV1(&govv1.Proposal{
+			Id:       4,
+			Title:    "Community spend",
+			Messages: []*codectypes.Any{{TypeUrl: "/cosmos.distribution.v1beta1.MsgCommunityPoolSpend"}},
+		})
+		assert.Equal(t, "Community spend", proposal.Title)
+		assert.Equal(t, []string{"/cosmos.distribution.v1beta1.MsgCommunityPoolSpend"}, proposal.Types)
+		assert.True(t, proposal.VotingEndTime.IsZero())
+		assert.False(t, proposal.IsSoftwareUpgrade())
+	})
+
+	t.Run("text proposal", func(t *testing.T) {
+		text, err := (&govv1beta1.TextProposal{Title: "Signal", Description: "text"}).Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, "Signal", legacyContentTitle(&codectypes.Any{Value: text}))
+	})
+}
+
+func TestMainVoteOption(t *testing.T) {
+	assert.Equal(t, "", mainVoteOption(nil))
+	assert.Equal(t, "no_with_veto", mainVoteOption([]govv1.WeightedVoteOption{
+		{Option: govv1.OptionNoWithVeto, Weight: "1.0"},
+	}))
+	assert.Equal(t, "abstain", mainVoteOption([]govv1.WeightedVoteOption{
+		{Option: govv1.OptionYes, Weight: "0.3"},
+		{Option: govv1.OptionAbstain, Weight: "0.7"},
+	}))
+}
+
+func TestIsVoteNotFound(t *testing.T) {
+	assert.True(t, isVoteNotFound(status.Error(codes.InvalidArgument, "voter: cosmos1abc not found for proposal: 1")))
+	assert.False(t, isVoteNotFound(status.Error(codes.InvalidArgument, "proposal id can not be 0")))
+	assert.False(t, isVoteNotFound(errors.New("connection refused")))
+	assert.False(t, isVoteNotFound(nil))
+}
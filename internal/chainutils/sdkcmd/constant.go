@@ -2,6 +2,8 @@ package sdkcmd
 
 const (
 	Home                    = "home"
+	Moniker                 = "moniker"
+	NewMoniker              = "new-moniker"
 	Details                 = "details"
 	Website                 = "website"
 	Identity                = "identity"
@@ -11,4 +13,5 @@ const (
 	MinSelfDelegation       = "min-self-delegation"
 	Node                    = "node"
 	OutputDocument          = "output-document"
+	Output                  = "output"
 )
@@ -46,6 +46,22 @@ type SDK interface {
 	// CreateValidatorArgs returns arguments for creating a validator on an existing chain.
 	CreateValidatorArgs(account, pubKey, moniker, stakeAmount, chainID, gasPrices string, options ...*ArgOption) []string
 
+	// EditValidatorArgs returns arguments for editing the description and commission rate of a validator.
+	// The moniker is left unchanged when nil.
+	EditValidatorArgs(account, chainID, gasPrices string, moniker *string, options ...*ArgOption) []string
+
+	// UnjailArgs returns arguments for unjailing a validator.
+	UnjailArgs(account, chainID, gasPrices string, options ...*ArgOption) []string
+
+	// VoteArgs returns arguments for voting on a governance proposal with the given option.
+	VoteArgs(account, chainID, gasPrices string, proposalID uint64, option string, options ...*ArgOption) []string
+
+	// WithdrawRewardsArgs returns arguments for withdrawing the delegation rewards and commission of a validator.
+	WithdrawRewardsArgs(account, validatorAddress, chainID, gasPrices string, options ...*ArgOption) []string
+
+	// DelegateArgs returns arguments for delegating amount to a validator.
+	DelegateArgs(account, validatorAddress, amount, chainID, gasPrices string, options ...*ArgOption) []string
+
 	// GenesisSetUnbondingTimeCmd returns a shell command to set the unbonding time in the genesis file.
 	GenesisSetUnbondingTimeCmd(unbondingTime, genesisFile string) string
 
@@ -2,6 +2,7 @@ package sdkcmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
@@ -83,6 +84,77 @@ func (sdk *v0_45) CreateValidatorArgs(account, pubKey, moniker, stakeAmount, cha
 	return append(args, sdk.options.GlobalArgs...)
 }
 
+func (sdk *v0_45) EditValidatorArgs(account, chainID, gasPrices string, moniker *string, options ...*ArgOption) []string {
+	return sdk.editValidatorArgs(Moniker, account, chainID, gasPrices, moniker, options...)
+}
+
+func (sdk *v0_45) editValidatorArgs(monikerFlag, account, chainID, gasPrices string, moniker *string, options ...*ArgOption) []string {
+	args := []string{
+		"tx", "staking", "edit-validator",
+		"--chain-id", chainID,
+		"--gas-prices", gasPrices,
+		"--from", account,
+		"--keyring-backend", "test",
+		"--yes",
+	}
+	args = applyArgOption(args, WithOptionalArg(monikerFlag, moniker))
+	args = applyArgOptions(args, options)
+	return append(args, sdk.options.GlobalArgs...)
+}
+
+func (sdk *v0_45) UnjailArgs(account, chainID, gasPrices string, options ...*ArgOption) []string {
+	args := []string{
+		"tx", "slashing", "unjail",
+		"--chain-id", chainID,
+		"--gas-prices", gasPrices,
+		"--from", account,
+		"--keyring-backend", "test",
+		"--yes",
+	}
+	args = applyArgOptions(args, options)
+	return append(args, sdk.options.GlobalArgs...)
+}
+
+func (sdk *v0_45) VoteArgs(account, chainID, gasPrices string, proposalID uint64, option string, options ...*ArgOption) []string {
+	args := []string{
+		"tx", "gov", "vote", strconv.FormatUint(proposalID, 10), option,
+		"--chain-id", chainID,
+		"--gas-prices", gasPrices,
+		"--from", account,
+		"--keyring-backend", "test",
+		"--yes",
+	}
+	args = applyArgOptions(args, options)
+	return append(args, sdk.options.GlobalArgs...)
+}
+
+func (sdk *v0_45) WithdrawRewardsArgs(account, validatorAddress, chainID, gasPrices string, options ...*ArgOption) []string {
+	args := []string{
+		"tx", "distribution", "withdraw-rewards", validatorAddress,
+		"--commission",
+		"--chain-id", chainID,
+		"--gas-prices", gasPrices,
+		"--from", account,
+		"--keyring-backend", "test",
+		"--yes",
+	}
+	args = applyArgOptions(args, options)
+	return append(args, sdk.options.GlobalArgs...)
+}
+
+func (sdk *v0_45) DelegateArgs(account, validatorAddress, amount, chainID, gasPrices string, options ...*ArgOption) []string {
+	args := []string{
+		"tx", "staking", "delegate", validatorAddress, amount,
+		"--chain-id", chainID,
+		"--gas-prices", gasPrices,
+		"--from", account,
+		"--keyring-backend", "test",
+		"--yes",
+	}
+	args = applyArgOptions(args, options)
+	return append(args, sdk.options.GlobalArgs...)
+}
+
 func (sdk *v0_45) GenesisSetUnbondingTimeCmd(unbondingTime, genesisFile string) string {
 	return fmt.Sprintf("jq '.app_state.staking.params.unbonding_time = %q' %s > /tmp/genesis.tmp && mv /tmp/genesis.tmp %s",
 		unbondingTime, genesisFile, genesisFile,
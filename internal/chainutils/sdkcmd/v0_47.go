@@ -56,6 +56,11 @@ func (sdk *v0_47) CollectGenTxsArgs() []string {
 	)
 }
 
+// EditValidatorArgs uses --new-moniker, as --moniker was renamed for edit-validator in v0.46.
+func (sdk *v0_47) EditValidatorArgs(account, chainID, gasPrices string, moniker *string, options ...*ArgOption) []string {
+	return sdk.editValidatorArgs(NewMoniker, account, chainID, gasPrices, moniker, options...)
+}
+
 func (sdk *v0_47) GenesisSetVotingPeriodCmd(votingPeriod, genesisFile string) string {
 	return fmt.Sprintf("jq '.app_state.gov.params.voting_period = %q' %s > /tmp/genesis.tmp && mv /tmp/genesis.tmp %s",
 		votingPeriod, genesisFile, genesisFile,
@@ -3,11 +3,15 @@ package chainutils
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -15,13 +19,97 @@ import (
 	"github.com/voluzi/cosmopilot/v3/internal/k8s"
 )
 
+// annotationAccountLoaded is set on tx pods once the account mnemonic was fed to their load-account init container.
+const annotationAccountLoaded = "cosmopilot.voluzi.com/account-loaded"
+
 func (a *App) buildCreateValidatorPod(
 	pubKey string,
 	nodeInfo *NodeInfo,
 	params *Params,
 	node string,
 ) *corev1.Pod {
+	return a.buildTxPod("create-validator", a.cmd.CreateValidatorArgs(
+		defaultAccountName,
+		pubKey,
+		nodeInfo.Moniker,
+		params.StakeAmount,
+		params.ChainID,
+		params.GasPrices,
+		sdkcmd.WithArg(sdkcmd.CommissionMaxChangeRate, params.CommissionMaxChangeRate),
+		sdkcmd.WithArg(sdkcmd.CommissionMaxRate, params.CommissionMaxRate),
+		sdkcmd.WithArg(sdkcmd.CommissionRate, params.CommissionRate),
+		sdkcmd.WithOptionalArg(sdkcmd.MinSelfDelegation, params.MinSelfDelegation),
+		sdkcmd.WithOptionalArg(sdkcmd.Details, nodeInfo.Details),
+		sdkcmd.WithOptionalArg(sdkcmd.Website, nodeInfo.Website),
+		sdkcmd.WithOptionalArg(sdkcmd.Identity, nodeInfo.Identity),
+		sdkcmd.WithArg(sdkcmd.Node, node),
+		sdkcmd.WithArg(sdkcmd.Output, "json"),
+	))
+}
+
+func (a *App) buildEditValidatorPod(edit *ValidatorEdit, params *Params, node string) *corev1.Pod {
+	return a.buildTxPod("edit-validator", a.cmd.EditValidatorArgs(
+		defaultAccountName,
+		params.ChainID,
+		params.GasPrices,
+		edit.Moniker,
+		sdkcmd.WithOptionalArg(sdkcmd.Details, edit.Details),
+		sdkcmd.WithOptionalArg(sdkcmd.Website, edit.Website),
+		sdkcmd.WithOptionalArg(sdkcmd.Identity, edit.Identity),
+		sdkcmd.WithOptionalArg(sdkcmd.CommissionRate, edit.CommissionRate),
+		sdkcmd.WithArg(sdkcmd.Node, node),
+		sdkcmd.WithArg(sdkcmd.Output, "json"),
+	))
+}
 
+func (a *App) buildUnjailPod(params *Params, node string) *corev1.Pod {
+	return a.buildTxPod("unjail", a.cmd.UnjailArgs(
+		defaultAccountName,
+		params.ChainID,
+		params.GasPrices,
+		sdkcmd.WithArg(sdkcmd.Node, node),
+		sdkcmd.WithArg(sdkcmd.Output, "json"),
+	))
+}
+
+func (a *App) buildVotePod(proposalID uint64, option string, params *Params, node string) *corev1.Pod {
+	return a.buildTxPod("vote", a.cmd.VoteArgs(
+		defaultAccountName,
+		params.ChainID,
+		params.GasPrices,
+		proposalID,
+		option,
+		sdkcmd.WithArg(sdkcmd.Node, node),
+		sdkcmd.WithArg(sdkcmd.Output, "json"),
+	))
+}
+
+func (a *App) buildWithdrawRewardsPod(validatorAddress string, params *Params, node string) *corev1.Pod {
+	return a.buildTxPod("withdraw-rewards", a.cmd.WithdrawRewardsArgs(
+		defaultAccountName,
+		validatorAddress,
+		params.ChainID,
+		params.GasPrices,
+		sdkcmd.WithArg(sdkcmd.Node, node),
+		sdkcmd.WithArg(sdkcmd.Output, "json"),
+	))
+}
+
+func (a *App) buildDelegatePod(validatorAddress, amount string, params *Params, node string) *corev1.Pod {
+	return a.buildTxPod("delegate", a.cmd.DelegateArgs(
+		defaultAccountName,
+		validatorAddress,
+		amount,
+		params.ChainID,
+		params.GasPrices,
+		sdkcmd.WithArg(sdkcmd.Node, node),
+		sdkcmd.WithArg(sdkcmd.Output, "json"),
+	))
+}
+
+// buildTxPod builds a pod that loads the validator account in an init container and then runs
+// the app with args to broadcast a transaction.
+func (a *App) buildTxPod(name string, args []string) *corev1.Pod {
 	var (
 		dataVolumeMount = corev1.VolumeMount{
 			Name:      "data",
@@ -31,7 +119,7 @@ func (a *App) buildCreateValidatorPod(
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-create-validator", a.owner.GetName()),
+			Name:      fmt.Sprintf("%s-%s", a.owner.GetName(), name),
 			Namespace: a.owner.GetNamespace(),
 		},
 		Spec: corev1.PodSpec{
@@ -63,26 +151,11 @@ func (a *App) buildCreateValidatorPod(
 			},
 			Containers: []corev1.Container{
 				{
-					Name:            "create-validator",
+					Name:            name,
 					Image:           a.image,
 					ImagePullPolicy: a.pullPolicy,
 					Command:         []string{a.binary},
-					Args: a.cmd.CreateValidatorArgs(
-						defaultAccountName,
-						pubKey,
-						nodeInfo.Moniker,
-						params.StakeAmount,
-						params.ChainID,
-						params.GasPrices,
-						sdkcmd.WithArg(sdkcmd.CommissionMaxChangeRate, params.CommissionMaxChangeRate),
-						sdkcmd.WithArg(sdkcmd.CommissionMaxRate, params.CommissionMaxRate),
-						sdkcmd.WithArg(sdkcmd.CommissionRate, params.CommissionRate),
-						sdkcmd.WithOptionalArg(sdkcmd.MinSelfDelegation, params.MinSelfDelegation),
-						sdkcmd.WithOptionalArg(sdkcmd.Details, nodeInfo.Details),
-						sdkcmd.WithOptionalArg(sdkcmd.Website, nodeInfo.Website),
-						sdkcmd.WithOptionalArg(sdkcmd.Identity, nodeInfo.Identity),
-						sdkcmd.WithArg(sdkcmd.Node, node),
-					),
+					Args:            args,
 					Env:             a.appEnv(),
 					VolumeMounts:    []corev1.VolumeMount{dataVolumeMount},
 					SecurityContext: k8s.RestrictedSecurityContext(),
@@ -97,6 +170,8 @@ func (a *App) buildCreateValidatorPod(
 	return pod
 }
 
+// CreateValidator broadcasts a create-validator transaction and returns its hash. An error is returned if the
+// transaction was rejected by the node.
 func (a *App) CreateValidator(
 	ctx context.Context,
 	pubKey string,
@@ -104,11 +179,48 @@ func (a *App) CreateValidator(
 	nodeInfo *NodeInfo,
 	params *Params,
 	node string,
-) error {
-	pod := a.buildCreateValidatorPod(pubKey, nodeInfo, params, node)
+) (string, error) {
+	return a.runTxPod(ctx, a.buildCreateValidatorPod(pubKey, nodeInfo, params, node), account)
+}
 
+// EditValidator broadcasts an edit-validator transaction and returns its hash. An error is returned if the
+// transaction was rejected by the node.
+func (a *App) EditValidator(ctx context.Context, account *Account, edit *ValidatorEdit, params *Params, node string) (string, error) {
+	return a.runTxPod(ctx, a.buildEditValidatorPod(edit, params, node), account)
+}
+
+// Unjail broadcasts an unjail transaction and returns its hash. An error is returned if the transaction was
+// rejected by the node.
+func (a *App) Unjail(ctx context.Context, account *Account, params *Params, node string) (string, error) {
+	return a.runTxPod(ctx, a.buildUnjailPod(params, node), account)
+}
+
+// Vote broadcasts a vote transaction and returns its hash. An error is returned if the transaction was
+// rejected by the node.
+func (a *App) Vote(ctx context.Context, account *Account, proposalID uint64, option string, params *Params, node string) (string, error) {
+	return a.runTxPod(ctx, a.buildVotePod(proposalID, option, params, node), account)
+}
+
+// WithdrawRewards progresses a pod withdrawing the delegation rewards and commission of a validator without
+// waiting for it. It returns the hash of the transaction once the pod completed, and an empty hash before
+// that. Pods created before since are from a previous run and are replaced.
+func (a *App) WithdrawRewards(ctx context.Context, account *Account, validatorAddress string, params *Params, node string, since time.Time) (string, error) {
+	return a.progressTxPod(ctx, a.buildWithdrawRewardsPod(validatorAddress, params, node), account, since)
+}
+
+// Delegate progresses a pod delegating amount to a validator without waiting for it. It returns the hash of
+// the transaction once the pod completed, and an empty hash before that. Pods created before since are from a
+// previous run and are replaced.
+func (a *App) Delegate(ctx context.Context, account *Account, validatorAddress, amount string, params *Params, node string, since time.Time) (string, error) {
+	return a.progressTxPod(ctx, a.buildDelegatePod(validatorAddress, amount, params, node), account, since)
+}
+
+// runTxPod runs a pod that broadcasts a transaction with JSON output, feeding the account mnemonic
+// to its load-account init container, and returns the hash of the transaction. Broadcasting succeeds even
+// when the node rejects the transaction, so the code in the output is checked as well.
+func (a *App) runTxPod(ctx context.Context, pod *corev1.Pod, account *Account) (string, error) {
 	if err := controllerutil.SetControllerReference(a.owner, pod, a.scheme); err != nil {
-		return err
+		return "", err
 	}
 
 	ph := k8s.NewPodHelper(a.client, a.restConfig, pod)
@@ -121,21 +233,117 @@ func (a *App) CreateValidator(
 
 	// Create the pod
 	if err := ph.Create(ctx); err != nil {
-		return err
+		return "", err
 	}
 
 	// Wait for load-account container to be running
 	if err := ph.WaitForInitContainerRunning(ctx, "load-account", time.Minute); err != nil {
-		return err
+		return "", err
 	}
 
 	// Attach to load-account container to insert mnemonic
 	var input bytes.Buffer
 	input.WriteString(fmt.Sprintf("%s\n", account.Mnemonic))
 	if _, _, err := ph.Attach(ctx, "load-account", &input); err != nil {
-		return err
+		return "", err
 	}
 
 	// Wait for the pod to be completed
-	return ph.WaitForPodSucceeded(ctx, time.Minute)
+	if err := ph.WaitForPodSucceeded(ctx, time.Minute); err != nil {
+		return "", err
+	}
+
+	logs, err := ph.GetLogs(ctx, pod.Spec.Containers[0].Name)
+	if err != nil {
+		return "", err
+	}
+	return parseTxHash(logs)
+}
+
+// progressTxPod moves a pod that broadcasts a transaction with JSON output one step forward on each call:
+// it creates the pod, feeds the account mnemonic to its load-account init container once it is running,
+// and returns the hash of the transaction once the pod succeeded. An empty hash is returned while the pod
+// is in progress. Completed pods are deleted.
+func (a *App) progressTxPod(ctx context.Context, pod *corev1.Pod, account *Account, since time.Time) (string, error) {
+	pods := a.client.CoreV1().Pods(pod.GetNamespace())
+
+	current, err := pods.Get(ctx, pod.GetName(), metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	if err == nil && current.CreationTimestamp.Time.Before(since.Truncate(time.Second)) {
+		return "", pods.Delete(ctx, current.GetName(), metav1.DeleteOptions{})
+	}
+	if errors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(a.owner, pod, a.scheme); err != nil {
+			return "", err
+		}
+		_, err = pods.Create(ctx, pod, metav1.CreateOptions{})
+		return "", err
+	}
+
+	ph := k8s.NewPodHelper(a.client, a.restConfig, current)
+	switch current.Status.Phase {
+	case corev1.PodSucceeded:
+		defer func() { _ = ph.Delete(ctx) }()
+		logs, err := ph.GetLogs(ctx, current.Spec.Containers[0].Name)
+		if err != nil {
+			return "", err
+		}
+		return parseTxHash(logs)
+
+	case corev1.PodFailed:
+		defer func() { _ = ph.Delete(ctx) }()
+		return "", fmt.Errorf("pod %s failed: %s", current.GetName(), ph.GetFailureReason(ctx))
+	}
+
+	if current.Annotations[annotationAccountLoaded] == "true" {
+		return "", nil
+	}
+	for _, c := range current.Status.InitContainerStatuses {
+		if c.Name != "load-account" || c.State.Running == nil {
+			continue
+		}
+
+		// Attach to load-account container to insert mnemonic
+		var input bytes.Buffer
+		input.WriteString(fmt.Sprintf("%s\n", account.Mnemonic))
+		if _, _, err := ph.Attach(ctx, "load-account", &input); err != nil {
+			return "", err
+		}
+
+		// Stdin is only read once, so the mnemonic must not be sent again
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, annotationAccountLoaded)
+		_, err := pods.Patch(ctx, current.GetName(), types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		return "", err
+	}
+	return "", nil
+}
+
+// txResponse contains the fields of the JSON output of tx commands used by cosmopilot.
+type txResponse struct {
+	TxHash string `json:"txhash"`
+	Code   uint32 `json:"code"`
+	RawLog string `json:"raw_log"`
+}
+
+// parseTxHash returns the transaction hash from the output of a tx command. Commands may print other
+// lines (such as gas estimates) before the JSON response, so the last JSON line with a hash is used.
+func parseTxHash(output string) (string, error) {
+	lines := strings.Split(output, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var response txResponse
+		if err := json.Unmarshal([]byte(line), &response); err != nil || response.TxHash == "" {
+			continue
+		}
+		if response.Code != 0 {
+			return "", fmt.Errorf("transaction %s failed with code %d: %s", response.TxHash, response.Code, response.RawLog)
+		}
+		return response.TxHash, nil
+	}
+	return "", fmt.Errorf("could not find transaction hash in output: %s", output)
 }
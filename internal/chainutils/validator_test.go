@@ -0,0 +1,37 @@
+package chainutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseTxHash(t *testing.T) {
+	hash, err := parseTxHash("gas estimate: 180000\n{\"height\":\"0\",\"txhash\":\"ABCDEF\",\"code\":0,\"raw_log\":\"[]\"}")
+	require.NoError(t, err)
+	assert.Equal(t, "ABCDEF", hash)
+
+	_, err = parseTxHash("{\"height\":\"0\",\"txhash\":\"ABCDEF\",\"code\":5,\"raw_log\":\"insufficient funds\"}")
+	assert.ErrorContains(t, err, "insufficient funds")
+
+	_, err = parseTxHash("Error: rpc error")
+	assert.Error(t, err)
+}
+
+func TestTxPodsUseJSONOutput(t *testing.T) {
+	a := newTestGenesisApp(t)
+	params := &Params{ChainID: "test-chain", GasPrices: "0.025stake", StakeAmount: "1stake"}
+	node := "tcp://node:26657"
+
+	for _, pod := range []*corev1.Pod{
+		a.buildCreateValidatorPod("pubkey", &NodeInfo{Moniker: "validator"}, params, node),
+		a.buildEditValidatorPod(&ValidatorEdit{}, params, node),
+		a.buildUnjailPod(params, node),
+		a.buildVotePod(1, "yes", params, node),
+	} {
+		assert.Contains(t, strings.Join(pod.Spec.Containers[0].Args, " "), "--output json", pod.GetName())
+	}
+}
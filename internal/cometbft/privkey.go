@@ -122,3 +122,17 @@ func PubKeyToString(pk cryptotypes.PubKey) (string, error) {
 	}
 	return string(b), nil
 }
+
+// GetConsensusAddress returns the consensus address, in hex, of the given public key in the format returned by
+// GetPubKey.
+func GetConsensusAddress(pubKey string) (string, error) {
+	reg := types.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(reg)
+	c := codec.NewProtoCodec(reg)
+
+	var pk cryptotypes.PubKey
+	if err := c.UnmarshalInterfaceJSON([]byte(pubKey), &pk); err != nil {
+		return "", err
+	}
+	return pk.Address().String(), nil
+}
@@ -25,6 +25,15 @@ func TestGetPubKey(t *testing.T) {
 	}
 }
 
+func TestGetConsensusAddress(t *testing.T) {
+	address, err := GetConsensusAddress("{\"@type\":\"/cosmos.crypto.ed25519.PubKey\",\"key\":\"WSNiGcovSATN09MKkaqFDOQgypn1FPDhVwfYIPFVp34=\"}")
+	assert.NoError(t, err)
+	assert.Equal(t, "0E85BC4610C7710A01CA6CC98E2CC5CFE9935690", address)
+
+	_, err = GetConsensusAddress("invalid")
+	assert.Error(t, err)
+}
+
 func TestLoadPrivKeyRejectsMalformedTypedKey(t *testing.T) {
 	key := []byte(`{
 		"address":"0000000000000000000000000000000000000000",
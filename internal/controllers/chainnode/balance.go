@@ -0,0 +1,154 @@
+package chainnode
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+// updateBalances queries the balances of the validator account in the denoms of the configured minimums,
+// reports them in status and sets the LowBalance condition when any of them is below its minimum. Both are
+// removed when low balance alerts are disabled.
+func (r *Reconciler) updateBalances(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	logger := log.FromContext(ctx)
+
+	if !chainNode.ShouldCheckBalances() {
+		removed := apiMeta.RemoveStatusCondition(&chainNode.Status.Conditions, appsv1.ConditionLowBalance)
+		if removed || chainNode.Status.Balances != nil {
+			chainNode.Status.Balances = nil
+			return r.Status().Update(ctx, chainNode)
+		}
+		return nil
+	}
+
+	minimums, err := sdk.ParseCoinsNormalized(strings.Join(chainNode.Spec.Validator.LowBalance.MinBalances, ","))
+	if err != nil {
+		logger.Error(err, "invalid minimum balances")
+		if r.updateInvalidMinBalancesCondition(chainNode, err) {
+			return r.Status().Update(ctx, chainNode)
+		}
+		return nil
+	}
+
+	// The account address is only reported in status once the validator exists, but the account must be
+	// funded before that to submit the create-validator transaction.
+	address := chainNode.Status.AccountAddress
+	if address == "" {
+		account, err := r.getValidatorAccount(ctx, chainNode)
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("waiting for validator account before checking balances")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		address = account.Address
+	}
+
+	client, err := r.getChainNodeClient(chainNode)
+	if err != nil {
+		return err
+	}
+
+	balances := make(sdk.Coins, 0, len(minimums))
+	for _, minimum := range minimums {
+		balance, err := client.QueryBalance(ctx, address, minimum.Denom)
+		if err != nil {
+			return err
+		}
+		if balance == nil {
+			balance = &sdk.Coin{Denom: minimum.Denom, Amount: sdk.ZeroInt()}
+		}
+		balances = append(balances, *balance)
+	}
+
+	balanceStrings := make([]string, len(balances))
+	for i, balance := range balances {
+		balanceStrings[i] = balance.String()
+	}
+
+	conditionChanged := r.updateLowBalanceCondition(chainNode, address, getLowBalances(balances, minimums))
+	if conditionChanged || !slices.Equal(chainNode.Status.Balances, balanceStrings) {
+		chainNode.Status.Balances = balanceStrings
+		return r.Status().Update(ctx, chainNode)
+	}
+	return nil
+}
+
+// updateLowBalanceCondition sets the LowBalance condition and emits events when it transitions. It
+// returns true if the condition changed.
+func (r *Reconciler) updateLowBalanceCondition(chainNode *appsv1.ChainNode, address string, low []string) bool {
+	wasLow := apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionLowBalance)
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionLowBalance,
+		Status:             metav1.ConditionFalse,
+		Reason:             appsv1.ReasonBalanceAboveMinimum,
+		Message:            fmt.Sprintf("balances of %s are above their minimum", address),
+		ObservedGeneration: chainNode.Generation,
+	}
+
+	if len(low) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = appsv1.ReasonBalanceBelowMinimum
+		condition.Message = fmt.Sprintf("balances of %s below minimum: %s", address, strings.Join(low, ", "))
+		if !wasLow {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonLowBalance,
+				"Validator account %s has low balance: %s", address, strings.Join(low, ", "),
+			)
+		}
+	} else if wasLow {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeNormal,
+			appsv1.ReasonBalanceRecovered,
+			"Validator account %s balances are back above their minimum", address,
+		)
+	}
+
+	return apiMeta.SetStatusCondition(&chainNode.Status.Conditions, condition)
+}
+
+// updateInvalidMinBalancesCondition sets the LowBalance condition to unknown, emitting an event when the
+// minimum balances first fail to parse. Returns true if the condition changed.
+func (r *Reconciler) updateInvalidMinBalancesCondition(chainNode *appsv1.ChainNode, err error) bool {
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionLowBalance,
+		Status:             metav1.ConditionUnknown,
+		Reason:             appsv1.ReasonInvalidMinBalances,
+		Message:            fmt.Sprintf("invalid minimum balances: %v", err),
+		ObservedGeneration: chainNode.Generation,
+	}
+	changed := apiMeta.SetStatusCondition(&chainNode.Status.Conditions, condition)
+	if changed {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonInvalidMinBalances,
+			"Balances are not checked because minimum balances are invalid: %v", err,
+		)
+	}
+	return changed
+}
+
+// getLowBalances returns a description of each balance below its minimum. Balances must be in the same
+// order as minimums.
+func getLowBalances(balances, minimums sdk.Coins) []string {
+	var low []string
+	for i, minimum := range minimums {
+		if balance := balances[i]; balance.IsLT(minimum) {
+			low = append(low, fmt.Sprintf("%s (minimum: %s)", balance, minimum))
+		}
+	}
+	return low
+}
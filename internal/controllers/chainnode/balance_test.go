@@ -0,0 +1,62 @@
+package chainnode
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+func TestGetLowBalances(t *testing.T) {
+	minimums := sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000), sdk.NewInt64Coin("uosmo", 500))
+
+	low := getLowBalances(sdk.Coins{sdk.NewInt64Coin("uatom", 1000), sdk.NewInt64Coin("uosmo", 600)}, minimums)
+	assert.Empty(t, low)
+
+	low = getLowBalances(sdk.Coins{sdk.NewInt64Coin("uatom", 999), sdk.NewInt64Coin("uosmo", 0)}, minimums)
+	assert.Equal(t, []string{"999uatom (minimum: 1000uatom)", "0uosmo (minimum: 500uosmo)"}, low)
+}
+
+func TestUpdateLowBalanceCondition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+	chainNode := &appsv1.ChainNode{}
+
+	// Balances above minimum: condition is set to false without events.
+	assert.True(t, r.updateLowBalanceCondition(chainNode, "cosmos1abc", nil))
+	assert.True(t, apiMeta.IsStatusConditionFalse(chainNode.Status.Conditions, appsv1.ConditionLowBalance))
+	assert.Empty(t, recorder.Events)
+
+	// Dropping below minimum emits a warning once.
+	assert.True(t, r.updateLowBalanceCondition(chainNode, "cosmos1abc", []string{"10uatom (minimum: 1000uatom)"}))
+	assert.True(t, apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionLowBalance))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonLowBalance)
+
+	r.updateLowBalanceCondition(chainNode, "cosmos1abc", []string{"5uatom (minimum: 1000uatom)"})
+	assert.Empty(t, recorder.Events)
+
+	// Recovering emits a normal event.
+	assert.True(t, r.updateLowBalanceCondition(chainNode, "cosmos1abc", nil))
+	assert.True(t, apiMeta.IsStatusConditionFalse(chainNode.Status.Conditions, appsv1.ConditionLowBalance))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonBalanceRecovered)
+}
+
+func TestUpdateInvalidMinBalancesCondition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+	chainNode := &appsv1.ChainNode{}
+
+	// Invalid minimums emit a warning once.
+	assert.True(t, r.updateInvalidMinBalancesCondition(chainNode, errors.New("invalid decimal coin expression: uatom")))
+	condition := apiMeta.FindStatusCondition(chainNode.Status.Conditions, appsv1.ConditionLowBalance)
+	assert.Equal(t, appsv1.ReasonInvalidMinBalances, condition.Reason)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonInvalidMinBalances)
+
+	assert.False(t, r.updateInvalidMinBalancesCondition(chainNode, errors.New("invalid decimal coin expression: uatom")))
+	assert.Empty(t, recorder.Events)
+}
@@ -24,8 +24,14 @@ const (
 	timeoutPodRunning              = 5 * time.Minute
 	timeoutPodDeleted              = 2 * time.Minute
 	timeoutWaitServiceIP           = 5 * time.Minute
+	timeoutUpgradeSnapshot         = 10 * time.Minute
 	minimumTimeBeforeFirstSnapshot = 1 * time.Minute
 	rpcProbeTimeout                = 10 * time.Second
+	signingLeaseCheckPeriod        = 5 * time.Second
+	upgradeInfoFetchTimeout        = 30 * time.Second
+
+	// maxUpgradeInfoSize limits the size of downloaded plan info and chain-registry files.
+	maxUpgradeInfoSize = 10 << 20
 
 	// Probe configuration constants
 	startupProbePeriodSeconds      = 5
@@ -37,6 +43,10 @@ const (
 	readinessProbePeriodSeconds    = 10
 	readinessProbeTimeoutSeconds   = 5
 
+	// readyToSignStartupFailureThreshold allows node-utils 5 minutes to acquire the signing lease and check
+	// recent blocks before it is restarted.
+	readyToSignStartupFailureThreshold = 150
+
 	nodeUtilsContainerName = "node-utils"
 	nodeUtilsPortName      = "node-utils"
 	nodeUtilsPort          = 8000
@@ -70,6 +80,8 @@ const (
 
 	volumeSnapshot = "volume-snapshot"
 
+	imagePrePullContainerName = "prepull"
+
 	VolumeSnapshotDataSourceKind     = "VolumeSnapshot"
 	VolumeSnapshotDataSourceApiGroup = "snapshot.storage.k8s.io"
 
@@ -71,6 +71,7 @@ type Reconciler struct {
 	Scheme               *runtime.Scheme
 	configCache          *ttlcache.Cache[string, map[string]interface{}]
 	nodeClients          *ttlcache.Cache[string, *chainutils.Client]
+	validatorTxs         *ttlcache.Cache[string, validatorTx]
 	recorder             record.EventRecorder
 	opts                 *controllers.ControllerRunOptions
 	disruptionLocks      *lockManager
@@ -105,6 +106,11 @@ func New(mgr ctrl.Manager, clientSet *kubernetes.Clientset, opts *controllers.Co
 		}
 	})
 
+	// Transactions sent from validator accounts, which are tracked until they are included in a block
+	txCache := ttlcache.New(
+		ttlcache.WithDisableTouchOnHit[string, validatorTx](),
+	)
+
 	r := &Reconciler{
 		Client:             mgr.GetClient(),
 		APIReader:          mgr.GetAPIReader(),
@@ -113,6 +119,7 @@ func New(mgr ctrl.Manager, clientSet *kubernetes.Clientset, opts *controllers.Co
 		Scheme:             mgr.GetScheme(),
 		configCache:        cfgCache,
 		nodeClients:        clientsCache,
+		validatorTxs:       txCache,
 		recorder:           mgr.GetEventRecorderFor("chainnode-controller"),
 		opts:               opts,
 		disruptionLocks:    newLockManager(),
@@ -124,6 +131,7 @@ func New(mgr ctrl.Manager, clientSet *kubernetes.Clientset, opts *controllers.Co
 	}
 	go cfgCache.Start()
 	go clientsCache.Start()
+	go txCache.Start()
 	return r, nil
 }
 
@@ -141,14 +149,16 @@ func (r *Reconciler) reservationReader() client.Reader {
 //+kubebuilder:rbac:groups=cosmopilot.voluzi.com,resources=chainnodesets/finalizers,verbs=update
 //+kubebuilder:rbac:groups=cosmopilot.voluzi.com,resources=consensuskeyreservations,verbs=get;list;watch;create;delete
 //+kubebuilder:rbac:groups="",resources=pods;persistentvolumeclaims;configmaps;secrets;services,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=pods/exec;pods/attach,verbs=create
 //+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
-//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;patch;create;update;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;patch;create;update;delete
 //+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;patch;create;update;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=get;list;watch;create;update;patch;delete
@@ -169,6 +179,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if err := r.Get(ctx, req.NamespacedName, chainNode); err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
+			deleteChainNodeMetrics(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "unable to fetch chainnode")
@@ -391,6 +402,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	logger.V(1).Info("ensure monitoring")
+	if err = r.ensureMonitoring(ctx, chainNode); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Prepare the desired signing path before publishing config that enables it. A failed or pending
 	// transition leaves the existing ConfigMap and pod template on their current signing mode.
 	logger.V(1).Info("ensure signing configs")
@@ -416,9 +432,47 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	logger.V(1).Info("ensure upgrade images are pre-pulled")
+	if err = r.ensureUpgradeImagePrePull(ctx, chainNode); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	logger.V(1).Info("ensure upgrade rehearsal")
+	if err = r.ensureUpgradeRehearsal(ctx, chainNode); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Roll back upgrades that did not get past the upgrade height
+	logger.V(1).Info("check upgrade health")
+	if rolledBack, err := r.checkUpgradeHealth(ctx, chainNode); err != nil {
+		return ctrl.Result{}, err
+	} else if rolledBack {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	// Ensure pod is running
+	logger.V(1).Info("ensure node-utils token")
+	if err = r.ensureNodeUtilsToken(ctx, chainNode); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if chainNode.ShouldUseSigningLease() {
+		logger.V(1).Info("ensure signing lease")
+		if err = r.ensureSigningLease(ctx, chainNode); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.V(1).Info("ensure double-sign check rpcs")
+	if err = r.ensureDoubleSignCheckRPCs(ctx, chainNode); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	logger.V(1).Info("ensure pod")
 	if err = r.ensurePod(ctx, app, chainNode, configHash); err != nil {
+		if stderrors.Is(err, errSigningLeaseHeld) {
+			return ctrl.Result{RequeueAfter: signingLeaseCheckPeriod}, nil
+		}
 		return ctrl.Result{}, err
 	}
 	// Keep tmKMS assets while the live pod still references them; disruption protection may defer
@@ -475,6 +529,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	// Check balances before submitting any transaction, so that an account without funds is reported
+	// even when create-validator keeps failing.
+	if chainNode.Status.Phase == appsv1.PhaseChainNodeRunning && chainNode.Spec.Validator != nil {
+		logger.V(1).Info("checking validator account balances")
+		if err = r.updateBalances(ctx, chainNode); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	if chainNode.Status.Phase == appsv1.PhaseChainNodeRunning && chainNode.ShouldCreateValidator() {
 		logger.V(1).Info("creating validator tx")
 		if err = r.createValidator(ctx, app, chainNode); err != nil {
@@ -490,6 +553,42 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if chainNode.Status.Phase == appsv1.PhaseChainNodeRunning && chainNode.IsValidator() {
+		logger.V(1).Info("checking pending validator tx")
+		if err = r.checkValidatorTx(ctx, chainNode); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if chainNode.Status.Phase == appsv1.PhaseChainNodeRunning && chainNode.ShouldEditValidator() {
+		logger.V(1).Info("reconciling validator info")
+		if err = r.reconcileValidatorInfo(ctx, app, chainNode); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if chainNode.ShouldAutoUnjail() {
+		logger.V(1).Info("checking auto-unjail")
+		if err = r.autoUnjail(ctx, app, chainNode); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if chainNode.Status.Phase == appsv1.PhaseChainNodeRunning && chainNode.ShouldTrackProposals() {
+		logger.V(1).Info("reconciling governance proposals")
+		if err = r.reconcileGovernance(ctx, app, chainNode); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	restakePending := false
+	if chainNode.Status.Phase == appsv1.PhaseChainNodeRunning && chainNode.ShouldAutoRestake() {
+		logger.V(1).Info("checking auto-restake")
+		if restakePending, err = r.autoRestake(ctx, app, chainNode); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	logger.Info("finishing reconcile")
 	if staleSnapshotJobReplaced {
 		// Come back promptly to recreate the replaced Job: Jobs are not watched, so nothing else would
@@ -504,6 +603,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if dashboardRoutesPending {
 		return ctrl.Result{RequeueAfter: dashboardRouteCheckPeriod}, nil
 	}
+	if restakePending {
+		return ctrl.Result{RequeueAfter: restakeCheckPeriod}, nil
+	}
+	if r.hasPendingValidatorTx(chainNode) {
+		return ctrl.Result{RequeueAfter: validatorTxCheckPeriod}, nil
+	}
 	return ctrl.Result{RequeueAfter: chainNode.GetReconcilePeriod()}, nil
 }
 
@@ -0,0 +1,148 @@
+package chainnode
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/cometbft"
+	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
+)
+
+// withDoubleSignCheck configures the node-utils container to check recent blocks for signatures of the validator
+// before allowing the application to start. The signing state recorded by cosmopilot is mounted as well, so
+// that signatures made by this node before a data restore are not mistaken for signatures made elsewhere.
+func withDoubleSignCheck(container *corev1.Container, chainNode *appsv1.ChainNode) error {
+	address, err := cometbft.GetConsensusAddress(chainNode.Status.PubKey)
+	if err != nil {
+		return fmt.Errorf("failed to get consensus address of %s: %w", chainNode.GetName(), err)
+	}
+
+	cfg := chainNode.Spec.Validator.DoubleSignCheck
+	container.Env = append(container.Env,
+		corev1.EnvVar{
+			Name:  "DOUBLE_SIGN_CHECK_ADDRESS",
+			Value: address,
+		},
+		corev1.EnvVar{
+			Name:  "DOUBLE_SIGN_CHECK_RPCS",
+			Value: strings.Join(cfg.RPCs, ","),
+		},
+		corev1.EnvVar{
+			Name:  "DOUBLE_SIGN_CHECK_BLOCKS",
+			Value: strconv.FormatInt(cfg.GetBlocks(), 10),
+		},
+		corev1.EnvVar{
+			Name:  "SIGNING_STATE_FILE",
+			Value: signingStateMountPath + "/" + nodeutils.PrivValidatorStateFilename,
+		},
+	)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      signingStateVolumeName,
+		MountPath: signingStateMountPath,
+		ReadOnly:  true,
+	})
+	return nil
+}
+
+// updateDoubleSignCheckCondition sets the ValidatorKeyInUse condition from the last check of recent blocks made by
+// node-utils, and removes it when the check is disabled.
+func (r *Reconciler) updateDoubleSignCheckCondition(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	if !chainNode.ShouldCheckDoubleSign() {
+		if apiMeta.RemoveStatusCondition(&chainNode.Status.Conditions, appsv1.ConditionValidatorKeyInUse) {
+			return r.Status().Update(ctx, chainNode)
+		}
+		return nil
+	}
+
+	// Without trusted RPC endpoints the check cannot run. The condition is set by ensureDoubleSignCheckRPCs.
+	if len(chainNode.Spec.Validator.DoubleSignCheck.RPCs) == 0 {
+		return nil
+	}
+
+	check, err := nodeutils.NewClient(chainNode.GetNodeFQDN()).GetDoubleSignCheck(ctx)
+	if err != nil {
+		return err
+	}
+
+	if r.setDoubleSignCheckCondition(chainNode, check) {
+		return r.Status().Update(ctx, chainNode)
+	}
+	return nil
+}
+
+// ensureDoubleSignCheckRPCs sets the ValidatorKeyInUse condition to unknown, emitting an event when first set, if
+// the double-sign check has no trusted RPC endpoints. Only the ChainNodeSet controller adds endpoints of its own, so
+// a standalone ChainNode without any would never be allowed to start signing.
+func (r *Reconciler) ensureDoubleSignCheckRPCs(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	if !chainNode.ShouldCheckDoubleSign() || len(chainNode.Spec.Validator.DoubleSignCheck.RPCs) > 0 {
+		return nil
+	}
+	if r.setMissingDoubleSignRPCsCondition(chainNode) {
+		return r.Status().Update(ctx, chainNode)
+	}
+	return nil
+}
+
+// setMissingDoubleSignRPCsCondition sets the ValidatorKeyInUse condition to unknown because the double-sign check
+// has no trusted RPC endpoints. It returns true if the condition changed.
+func (r *Reconciler) setMissingDoubleSignRPCsCondition(chainNode *appsv1.ChainNode) bool {
+	changed := apiMeta.SetStatusCondition(&chainNode.Status.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionValidatorKeyInUse,
+		Status:             metav1.ConditionUnknown,
+		Reason:             appsv1.ReasonDoubleSignCheckFailed,
+		Message:            "no trusted RPC endpoints configured in .spec.validator.doubleSignCheck.rpcs",
+		ObservedGeneration: chainNode.Generation,
+	})
+	if changed {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonDoubleSignCheckFailed,
+			"Validator start-up is blocked because .spec.validator.doubleSignCheck.rpcs is empty",
+		)
+	}
+	return changed
+}
+
+// setDoubleSignCheckCondition sets the ValidatorKeyInUse condition and emits an event when the key is found signing
+// elsewhere. It returns true if the condition changed.
+func (r *Reconciler) setDoubleSignCheckCondition(chainNode *appsv1.ChainNode, check *nodeutils.DoubleSignCheck) bool {
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionValidatorKeyInUse,
+		Status:             metav1.ConditionFalse,
+		Reason:             appsv1.ReasonKeyNotSigningElsewhere,
+		Message:            "no signatures of the validator found in recent blocks",
+		ObservedGeneration: chainNode.Generation,
+	}
+
+	switch {
+	case check.Passed:
+		// Defaults above
+
+	case check.Error != "":
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = appsv1.ReasonDoubleSignCheckFailed
+		condition.Message = check.Error
+
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = appsv1.ReasonKeySigningElsewhere
+		condition.Message = fmt.Sprintf("validator signed block %d reported by %s while this node was not running", check.Height, check.RPC)
+		if !apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionValidatorKeyInUse) {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonValidatorKeyInUse,
+				"Validator key signed block %d while this node was not running: start-up is blocked until it stops signing",
+				check.Height,
+			)
+		}
+	}
+
+	return apiMeta.SetStatusCondition(&chainNode.Status.Conditions, condition)
+}
@@ -0,0 +1,99 @@
+package chainnode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
+)
+
+func doubleSignCheckTestChainNode() *appsv1.ChainNode {
+	chainNode := signingLeaseTestChainNode()
+	chainNode.Spec.Validator.DoubleSignCheck = &appsv1.DoubleSignCheckConfig{
+		RPCs: []string{"https://rpc-1.example.com:443", "https://rpc-2.example.com:443"},
+	}
+	chainNode.Status.PubKey = "{\"@type\":\"/cosmos.crypto.ed25519.PubKey\",\"key\":\"WSNiGcovSATN09MKkaqFDOQgypn1FPDhVwfYIPFVp34=\"}"
+	return chainNode
+}
+
+func TestShouldCheckDoubleSign(t *testing.T) {
+	chainNode := doubleSignCheckTestChainNode()
+	assert.True(t, chainNode.ShouldCheckDoubleSign())
+
+	chainNode.Spec.Validator.TmKMS = &appsv1.TmKMS{}
+	assert.False(t, chainNode.ShouldCheckDoubleSign())
+
+	chainNode = doubleSignCheckTestChainNode()
+	chainNode.Spec.Validator.DoubleSignCheck = nil
+	assert.False(t, chainNode.ShouldCheckDoubleSign())
+}
+
+func TestWithDoubleSignCheck(t *testing.T) {
+	chainNode := doubleSignCheckTestChainNode()
+	container := &corev1.Container{}
+	require.NoError(t, withDoubleSignCheck(container, chainNode))
+
+	env := map[string]string{}
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	assert.Equal(t, "0E85BC4610C7710A01CA6CC98E2CC5CFE9935690", env["DOUBLE_SIGN_CHECK_ADDRESS"])
+	assert.Equal(t, "https://rpc-1.example.com:443,https://rpc-2.example.com:443", env["DOUBLE_SIGN_CHECK_RPCS"])
+	assert.Equal(t, "10", env["DOUBLE_SIGN_CHECK_BLOCKS"])
+	assert.Equal(t, "/signing-state/priv_validator_state.json", env["SIGNING_STATE_FILE"])
+	require.Len(t, container.VolumeMounts, 1)
+	assert.Equal(t, signingStateVolumeName, container.VolumeMounts[0].Name)
+
+	chainNode.Status.PubKey = ""
+	assert.Error(t, withDoubleSignCheck(&corev1.Container{}, chainNode))
+}
+
+func TestSetDoubleSignCheckCondition(t *testing.T) {
+	chainNode := doubleSignCheckTestChainNode()
+	r := signingLeaseTestReconciler(t)
+	recorder := r.recorder.(*record.FakeRecorder)
+
+	assert.True(t, r.setDoubleSignCheckCondition(chainNode, &nodeutils.DoubleSignCheck{Error: "no trusted RPC endpoint could be reached"}))
+	condition := apiMeta.FindStatusCondition(chainNode.Status.Conditions, appsv1.ConditionValidatorKeyInUse)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, appsv1.ReasonDoubleSignCheckFailed, condition.Reason)
+
+	check := &nodeutils.DoubleSignCheck{Height: 98, RPC: "https://rpc-1.example.com:443"}
+	assert.True(t, r.setDoubleSignCheckCondition(chainNode, check))
+	assert.True(t, apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionValidatorKeyInUse))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonValidatorKeyInUse)
+
+	// The event is only emitted when the condition transitions
+	assert.False(t, r.setDoubleSignCheckCondition(chainNode, check))
+	assert.Empty(t, recorder.Events)
+
+	assert.True(t, r.setDoubleSignCheckCondition(chainNode, &nodeutils.DoubleSignCheck{Passed: true}))
+	condition = apiMeta.FindStatusCondition(chainNode.Status.Conditions, appsv1.ConditionValidatorKeyInUse)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, appsv1.ReasonKeyNotSigningElsewhere, condition.Reason)
+}
+
+func TestSetMissingDoubleSignRPCsCondition(t *testing.T) {
+	chainNode := doubleSignCheckTestChainNode()
+	r := signingLeaseTestReconciler(t)
+	recorder := r.recorder.(*record.FakeRecorder)
+
+	assert.True(t, r.setMissingDoubleSignRPCsCondition(chainNode))
+	condition := apiMeta.FindStatusCondition(chainNode.Status.Conditions, appsv1.ConditionValidatorKeyInUse)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, appsv1.ReasonDoubleSignCheckFailed, condition.Reason)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonDoubleSignCheckFailed)
+
+	// The event is only emitted when the condition changes
+	assert.False(t, r.setMissingDoubleSignRPCsCondition(chainNode))
+	assert.Empty(t, recorder.Events)
+}
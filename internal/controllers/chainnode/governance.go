@@ -0,0 +1,142 @@
+package chainnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+)
+
+// proposalVoteCooldown is the minimum time between votes submitted on the same proposal, giving the
+// previous one time to be included in a block before the vote of the validator is evaluated again.
+const proposalVoteCooldown = 5 * time.Minute
+
+// reconcileGovernance reports the governance proposals in voting period in status and votes on them
+// according to the voting policy of the validator.
+func (r *Reconciler) reconcileGovernance(ctx context.Context, app *chainutils.App, chainNode *appsv1.ChainNode) error {
+	logger := log.FromContext(ctx)
+	cfg := chainNode.Spec.Validator.Governance
+
+	if chainNode.Status.AccountAddress == "" {
+		logger.V(1).Info("waiting for validator account address before tracking proposals")
+		return nil
+	}
+
+	client, err := r.getChainNodeClient(chainNode)
+	if err != nil {
+		return err
+	}
+
+	proposals, err := client.GetProposalsInVotingPeriod(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		account  *chainutils.Account
+		voteErrs error
+		now      = time.Now()
+	)
+	tracked := make([]appsv1.GovernanceProposal, len(proposals))
+	for i, p := range proposals {
+		vote, err := client.QueryVote(ctx, p.ID, chainNode.Status.AccountAddress)
+		if err != nil {
+			return err
+		}
+		tracked[i] = appsv1.GovernanceProposal{
+			ID:            p.ID,
+			Title:         p.Title,
+			Types:         p.Types,
+			VotingEndTime: metav1.NewTime(p.VotingEndTime),
+			Vote:          appsv1.VoteOption(vote),
+		}
+		if previous := getTrackedProposal(chainNode, p.ID); previous != nil {
+			tracked[i].VoteSubmittedAt = previous.VoteSubmittedAt
+			tracked[i].VoteError = previous.VoteError
+		} else {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeNormal,
+				appsv1.ReasonProposalVotingPeriod,
+				"proposal %d (%s) is in voting period until %s", p.ID, p.Title, p.VotingEndTime.UTC().Format(time.RFC3339))
+		}
+
+		option := cfg.GetVote(p.ID, p.IsSoftwareUpgrade(), tracked[i].Vote)
+		if option == nil || !shouldSubmitVote(&tracked[i], now) {
+			continue
+		}
+		if r.validatorAccountBusy(chainNode) {
+			logger.V(1).Info("waiting for previous validator tx before submitting vote tx", "proposal", p.ID)
+			continue
+		}
+
+		if account == nil {
+			if account, err = r.getValidatorAccount(ctx, chainNode); err != nil {
+				return err
+			}
+		}
+
+		logger.Info("submitting vote tx", "proposal", p.ID, "option", *option)
+		tracked[i].VoteSubmittedAt = &metav1.Time{Time: now}
+		hash, voteErr := app.Vote(ctx, account, p.ID, string(*option),
+			&chainutils.Params{
+				ChainID:   chainNode.Status.ChainID,
+				GasPrices: cfg.GasPrices,
+			},
+			fmt.Sprintf("tcp://%s:%d", chainNode.GetNodeFQDN(), chainutils.RpcPort),
+		)
+		if voteErr != nil {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonProposalVoteFailure,
+				"failed to vote %s on proposal %d: %s", *option, p.ID, voteErr.Error())
+			tracked[i].VoteError = voteErr.Error()
+			voteErrs = errors.Join(voteErrs, voteErr)
+			continue
+		}
+		tracked[i].VoteError = ""
+		r.trackValidatorTx(chainNode, validatorTx{hash: hash, kind: validatorTxVote, proposalID: p.ID})
+
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeNormal,
+			appsv1.ReasonProposalVoteSuccess,
+			"successfully submitted vote %s on proposal %d in tx %s", *option, p.ID, hash)
+	}
+
+	if !equality.Semantic.DeepEqual(chainNode.Status.Proposals, tracked) {
+		if len(tracked) == 0 {
+			tracked = nil
+		}
+		chainNode.Status.Proposals = tracked
+		if updateErr := r.Status().Update(ctx, chainNode); updateErr != nil {
+			return errors.Join(voteErrs, updateErr)
+		}
+	}
+	return voteErrs
+}
+
+// shouldSubmitVote returns true if a vote can be submitted on a proposal: its voting period is not over
+// and no vote was submitted on it by cosmopilot within the cooldown period.
+func shouldSubmitVote(proposal *appsv1.GovernanceProposal, now time.Time) bool {
+	if !now.Before(proposal.VotingEndTime.Time) {
+		return false
+	}
+	return proposal.VoteSubmittedAt == nil || now.Sub(proposal.VoteSubmittedAt.Time) >= proposalVoteCooldown
+}
+
+// getTrackedProposal returns the proposal with the given ID from status, or nil if it is not tracked.
+func getTrackedProposal(chainNode *appsv1.ChainNode, id uint64) *appsv1.GovernanceProposal {
+	for i := range chainNode.Status.Proposals {
+		if chainNode.Status.Proposals[i].ID == id {
+			return &chainNode.Status.Proposals[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package chainnode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+func TestGovernanceConfigGetVote(t *testing.T) {
+	cfg := &appsv1.GovernanceConfig{
+		SoftwareUpgradeVote: ptr.To(appsv1.VoteYes),
+		DefaultVote:         ptr.To(appsv1.VoteAbstain),
+		Votes: []appsv1.ProposalVote{
+			{ProposalID: 7, Option: appsv1.VoteNo},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		cfg             *appsv1.GovernanceConfig
+		proposalID      uint64
+		softwareUpgrade bool
+		current         appsv1.VoteOption
+		want            *appsv1.VoteOption
+	}{
+		{"declared vote", cfg, 7, true, "", ptr.To(appsv1.VoteNo)},
+		{"declared vote replaces current vote", cfg, 7, false, appsv1.VoteYes, ptr.To(appsv1.VoteNo)},
+		{"declared vote already cast", cfg, 7, false, appsv1.VoteNo, nil},
+		{"software upgrade vote", cfg, 8, true, "", ptr.To(appsv1.VoteYes)},
+		{"default vote", cfg, 8, false, "", ptr.To(appsv1.VoteAbstain)},
+		{"default vote keeps current vote", cfg, 8, true, appsv1.VoteNo, nil},
+		{
+			"software upgrade falls back to default vote",
+			&appsv1.GovernanceConfig{DefaultVote: ptr.To(appsv1.VoteAbstain)},
+			8, true, "", ptr.To(appsv1.VoteAbstain),
+		},
+		{"track only", &appsv1.GovernanceConfig{}, 8, true, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cfg.GetVote(tt.proposalID, tt.softwareUpgrade, tt.current))
+		})
+	}
+}
+
+func TestShouldSubmitVote(t *testing.T) {
+	now := time.Now()
+
+	proposal := func(votingEnd time.Time, submitted *time.Time) *appsv1.GovernanceProposal {
+		p := &appsv1.GovernanceProposal{ID: 1, VotingEndTime: metav1.NewTime(votingEnd)}
+		if submitted != nil {
+			p.VoteSubmittedAt = &metav1.Time{Time: *submitted}
+		}
+		return p
+	}
+
+	assert.True(t, shouldSubmitVote(proposal(now.Add(time.Hour), nil), now))
+	assert.False(t, shouldSubmitVote(proposal(now.Add(-time.Second), nil), now))
+	assert.False(t, shouldSubmitVote(proposal(now.Add(time.Hour), ptr.To(now.Add(-time.Minute))), now))
+	assert.True(t, shouldSubmitVote(proposal(now.Add(time.Hour), ptr.To(now.Add(-proposalVoteCooldown))), now))
+}
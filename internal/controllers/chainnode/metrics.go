@@ -0,0 +1,236 @@
+package chainnode
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+const (
+	metricsNamespace = "cosmopilot"
+	metricsSubsystem = "chainnode"
+
+	metricsCollectTimeout = 10 * time.Second
+
+	tarballExportSucceeded = "succeeded"
+	tarballExportFailed    = "failed"
+)
+
+var (
+	tarballExports = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "tarball_exports_total",
+		Help:      "Number of finished tarball export attempts by result.",
+	}, []string{"namespace", "chainnode", "result"})
+
+	tarballExportDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "tarball_export_duration_seconds",
+		Help:      "Time taken by finished tarball export attempts by result.",
+		Buckets:   []float64{60, 300, 600, 1800, 3600, 7200, 14400, 28800, 57600, 86400},
+	}, []string{"namespace", "chainnode", "result"})
+
+	vpaDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "vpa_decisions_total",
+		Help:      "Number of resource request changes decided by vertical autoscaling.",
+	}, []string{"namespace", "chainnode", "resource", "direction"})
+
+	// chainNodePhases are the phases exposed by the phase metric, so that a single series per phase exists
+	// for each node.
+	chainNodePhases = []appsv1.ChainNodePhase{
+		appsv1.PhaseChainNodeInitData,
+		appsv1.PhaseChainNodeInitGenesis,
+		appsv1.PhaseChainNodeStarting,
+		appsv1.PhaseChainNodeRunning,
+		appsv1.PhaseChainNodeSyncing,
+		appsv1.PhaseChainNodeStateSyncing,
+		appsv1.PhaseChainNodeRestarting,
+		appsv1.PhaseChainNodeStopped,
+		appsv1.PhaseChainNodeError,
+		appsv1.PhaseChainNodeSnapshotting,
+		appsv1.PhaseChainNodeUpgrading,
+	}
+)
+
+func init() {
+	metrics.Registry.MustRegister(tarballExports, tarballExportDuration, vpaDecisions)
+}
+
+// observeTarballExport records a finished tarball export attempt. The duration is only observed when the
+// start time of the attempt is known.
+func observeTarballExport(chainNode *appsv1.ChainNode, start time.Time, result string) {
+	tarballExports.WithLabelValues(chainNode.GetNamespace(), chainNode.GetName(), result).Inc()
+	if !start.IsZero() {
+		tarballExportDuration.WithLabelValues(chainNode.GetNamespace(), chainNode.GetName(), result).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// observeVpaDecision records a change of the resource requests of a node by vertical autoscaling.
+func observeVpaDecision(chainNode *appsv1.ChainNode, resourceName corev1.ResourceName, direction appsv1.ScalingDirection) {
+	vpaDecisions.WithLabelValues(chainNode.GetNamespace(), chainNode.GetName(), string(resourceName), string(direction)).Inc()
+}
+
+// deleteChainNodeMetrics removes the series of a node that no longer exists.
+func deleteChainNodeMetrics(key types.NamespacedName) {
+	labels := prometheus.Labels{"namespace": key.Namespace, "chainnode": key.Name}
+	tarballExports.DeletePartialMatch(labels)
+	tarballExportDuration.DeletePartialMatch(labels)
+	vpaDecisions.DeletePartialMatch(labels)
+}
+
+// metricsCollector exposes the state the controller already keeps on ChainNodes as gauges, read from the
+// cache at scrape time, so that it survives operator restarts and is never out of date.
+type metricsCollector struct {
+	client client.Reader
+	opts   *controllers.ControllerRunOptions
+
+	phase              *prometheus.Desc
+	latestHeight       *prometheus.Desc
+	pvcSize            *prometheus.Desc
+	dataUsage          *prometheus.Desc
+	validatorBonded    *prometheus.Desc
+	validatorJailed    *prometheus.Desc
+	lastSnapshot       *prometheus.Desc
+	pendingUpgrades    *prometheus.Desc
+	nextUpgradeHeight  *prometheus.Desc
+	vpaResourceRequest *prometheus.Desc
+	vpaResourceLimit   *prometheus.Desc
+}
+
+// NewMetricsCollector returns a collector of metrics about the ChainNodes managed by this worker, to be
+// registered with the controller-runtime metrics registry.
+func NewMetricsCollector(c client.Reader, opts *controllers.ControllerRunOptions) prometheus.Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, metricsSubsystem, name),
+			help,
+			append([]string{"namespace", "chainnode"}, labels...),
+			nil,
+		)
+	}
+	return &metricsCollector{
+		client:             c,
+		opts:               opts,
+		phase:              desc("phase", "Whether the node is in the given phase (1) or not (0).", "phase"),
+		latestHeight:       desc("latest_height", "Latest block height of the node."),
+		pvcSize:            desc("pvc_size_bytes", "Size of the data volume of the node in bytes."),
+		dataUsage:          desc("data_usage_ratio", "Fraction of the data volume of the node in use."),
+		validatorBonded:    desc("validator_bonded", "Whether the validator is bonded (1) or not (0)."),
+		validatorJailed:    desc("validator_jailed", "Whether the validator is jailed (1) or not (0)."),
+		lastSnapshot:       desc("last_snapshot_timestamp_seconds", "Unix time of the last successful volume snapshot of the node."),
+		pendingUpgrades:    desc("pending_upgrades", "Number of upgrades of the node that were not applied yet."),
+		nextUpgradeHeight:  desc("next_upgrade_height", "Height of the next upgrade of the node."),
+		vpaResourceRequest: desc("vpa_resource_request", "Resource request set by vertical autoscaling, in cores or bytes.", "resource"),
+		vpaResourceLimit:   desc("vpa_resource_limit", "Resource limit set by vertical autoscaling, in cores or bytes.", "resource"),
+	}
+}
+
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.phase
+	ch <- c.latestHeight
+	ch <- c.pvcSize
+	ch <- c.dataUsage
+	ch <- c.validatorBonded
+	ch <- c.validatorJailed
+	ch <- c.lastSnapshot
+	ch <- c.pendingUpgrades
+	ch <- c.nextUpgradeHeight
+	ch <- c.vpaResourceRequest
+	ch <- c.vpaResourceLimit
+}
+
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsCollectTimeout)
+	defer cancel()
+
+	chainNodes := &appsv1.ChainNodeList{}
+	if err := c.client.List(ctx, chainNodes); err != nil {
+		ctrllog.Log.WithName("metrics").Error(err, "failed to list chainnodes")
+		return
+	}
+	for i := range chainNodes.Items {
+		if c.opts.MatchesWorker(chainNodes.Items[i].Labels) {
+			c.collectChainNode(ch, &chainNodes.Items[i])
+		}
+	}
+}
+
+func (c *metricsCollector) collectChainNode(ch chan<- prometheus.Metric, chainNode *appsv1.ChainNode) {
+	gauge := func(desc *prometheus.Desc, value float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value,
+			append([]string{chainNode.GetNamespace(), chainNode.GetName()}, labels...)...)
+	}
+
+	for _, phase := range chainNodePhases {
+		gauge(c.phase, boolToFloat(chainNode.Status.Phase == phase), string(phase))
+	}
+	gauge(c.latestHeight, float64(chainNode.Status.LatestHeight))
+
+	if size, err := resource.ParseQuantity(chainNode.Status.PvcSize); err == nil {
+		gauge(c.pvcSize, size.AsApproximateFloat64())
+	}
+	if usage, err := strconv.Atoi(strings.TrimSuffix(chainNode.Status.DataUsage, "%")); err == nil {
+		gauge(c.dataUsage, float64(usage)/100)
+	}
+
+	if chainNode.IsValidator() {
+		gauge(c.validatorBonded, boolToFloat(chainNode.Status.ValidatorStatus == appsv1.ValidatorStatusBonded))
+		gauge(c.validatorJailed, boolToFloat(chainNode.Status.Jailed))
+	}
+
+	if last := getLastSnapshotTime(chainNode); chainNode.SnapshotsEnabled() && !last.IsZero() {
+		gauge(c.lastSnapshot, float64(last.Unix()))
+	}
+
+	var pending int
+	var next int64
+	for _, upgrade := range chainNode.Status.Upgrades {
+		if upgrade.Status != appsv1.UpgradeScheduled && upgrade.Status != appsv1.UpgradeImageMissing {
+			continue
+		}
+		pending++
+		if next == 0 || upgrade.Height < next {
+			next = upgrade.Height
+		}
+	}
+	gauge(c.pendingUpgrades, float64(pending))
+	if pending > 0 {
+		gauge(c.nextUpgradeHeight, float64(next))
+	}
+
+	if chainNode.Spec.VPA.IsEnabled() {
+		resources := getVpaLastAppliedResourcesOrFallback(chainNode)
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if q, ok := resources.Requests[name]; ok {
+				gauge(c.vpaResourceRequest, q.AsApproximateFloat64(), string(name))
+			}
+			if q, ok := resources.Limits[name]; ok {
+				gauge(c.vpaResourceLimit, q.AsApproximateFloat64(), string(name))
+			}
+		}
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package chainnode
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	validator := &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "validator",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controllers.AnnotationLastPvcSnapshot: "20261016120000",
+				controllers.AnnotationVPAResources:    `{"requests":{"cpu":"1500m","memory":"4Gi"}}`,
+			},
+		},
+		Spec: appsv1.ChainNodeSpec{
+			Validator:   &appsv1.ValidatorConfig{},
+			Persistence: &appsv1.Persistence{Snapshots: &appsv1.VolumeSnapshotsConfig{Frequency: "24h"}},
+			VPA:         &appsv1.VerticalAutoscalingConfig{Enabled: true},
+		},
+		Status: appsv1.ChainNodeStatus{
+			Phase:           appsv1.PhaseChainNodeRunning,
+			LatestHeight:    1000,
+			PvcSize:         "100Gi",
+			DataUsage:       "42%",
+			ValidatorStatus: appsv1.ValidatorStatusBonded,
+			Upgrades: []appsv1.Upgrade{
+				{Height: 900, Status: appsv1.UpgradeCompleted},
+				{Height: 1500, Status: appsv1.UpgradeScheduled},
+				{Height: 1200, Status: appsv1.UpgradeImageMissing},
+			},
+		},
+	}
+	otherWorker := &appsv1.ChainNode{ObjectMeta: metav1.ObjectMeta{
+		Name:      "other",
+		Namespace: "default",
+		Labels:    map[string]string{controllers.LabelWorkerName: "other"},
+	}}
+
+	c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(validator, otherWorker).Build()
+	collector := NewMetricsCollector(c, &controllers.ControllerRunOptions{})
+
+	expected := `
+# HELP cosmopilot_chainnode_data_usage_ratio Fraction of the data volume of the node in use.
+# TYPE cosmopilot_chainnode_data_usage_ratio gauge
+cosmopilot_chainnode_data_usage_ratio{chainnode="validator",namespace="default"} 0.42
+# HELP cosmopilot_chainnode_last_snapshot_timestamp_seconds Unix time of the last successful volume snapshot of the node.
+# TYPE cosmopilot_chainnode_last_snapshot_timestamp_seconds gauge
+cosmopilot_chainnode_last_snapshot_timestamp_seconds{chainnode="validator",namespace="default"} 1.792152e+09
+# HELP cosmopilot_chainnode_latest_height Latest block height of the node.
+# TYPE cosmopilot_chainnode_latest_height gauge
+cosmopilot_chainnode_latest_height{chainnode="validator",namespace="default"} 1000
+# HELP cosmopilot_chainnode_next_upgrade_height Height of the next upgrade of the node.
+# TYPE cosmopilot_chainnode_next_upgrade_height gauge
+cosmopilot_chainnode_next_upgrade_height{chainnode="validator",namespace="default"} 1200
+# HELP cosmopilot_chainnode_pending_upgrades Number of upgrades of the node that were not applied yet.
+# TYPE cosmopilot_chainnode_pending_upgrades gauge
+cosmopilot_chainnode_pending_upgrades{chainnode="validator",namespace="default"} 2
+# HELP cosmopilot_chainnode_pvc_size_bytes Size of the data volume of the node in bytes.
+# TYPE cosmopilot_chainnode_pvc_size_bytes gauge
+cosmopilot_chainnode_pvc_size_bytes{chainnode="validator",namespace="default"} 1.073741824e+11
+# HELP cosmopilot_chainnode_validator_bonded Whether the validator is bonded (1) or not (0).
+# TYPE cosmopilot_chainnode_validator_bonded gauge
+cosmopilot_chainnode_validator_bonded{chainnode="validator",namespace="default"} 1
+# HELP cosmopilot_chainnode_validator_jailed Whether the validator is jailed (1) or not (0).
+# TYPE cosmopilot_chainnode_validator_jailed gauge
+cosmopilot_chainnode_validator_jailed{chainnode="validator",namespace="default"} 0
+# HELP cosmopilot_chainnode_vpa_resource_request Resource request set by vertical autoscaling, in cores or bytes.
+# TYPE cosmopilot_chainnode_vpa_resource_request gauge
+cosmopilot_chainnode_vpa_resource_request{chainnode="validator",namespace="default",resource="cpu"} 1.5
+cosmopilot_chainnode_vpa_resource_request{chainnode="validator",namespace="default",resource="memory"} 4.294967296e+09
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"cosmopilot_chainnode_data_usage_ratio",
+		"cosmopilot_chainnode_last_snapshot_timestamp_seconds",
+		"cosmopilot_chainnode_latest_height",
+		"cosmopilot_chainnode_next_upgrade_height",
+		"cosmopilot_chainnode_pending_upgrades",
+		"cosmopilot_chainnode_pvc_size_bytes",
+		"cosmopilot_chainnode_validator_bonded",
+		"cosmopilot_chainnode_validator_jailed",
+		"cosmopilot_chainnode_vpa_resource_request",
+	))
+
+	// One series per phase, only the current one set
+	assert.Equal(t, len(chainNodePhases), testutil.CollectAndCount(collector, "cosmopilot_chainnode_phase"))
+}
+
+func TestTarballExportMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, snapshotv1.AddToScheme(scheme))
+
+	chainNode := &appsv1.ChainNode{ObjectMeta: metav1.ObjectMeta{Name: "metrics-node", Namespace: "default"}}
+	snapshot := &snapshotv1.VolumeSnapshot{ObjectMeta: metav1.ObjectMeta{
+		Name:        "snapshot",
+		Namespace:   "default",
+		Annotations: map[string]string{},
+	}}
+	setTarballExportStarted(snapshot)
+	snapshot.Annotations[controllers.AnnotationTarballExportStartTime] = time.Now().Add(-time.Hour).UTC().Format(timeLayout)
+
+	c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(snapshot).Build()
+	reconciler := &Reconciler{Client: c}
+
+	_, err := reconciler.recordTarballExportFailure(context.Background(), chainNode, snapshot)
+	require.NoError(t, err)
+
+	stored := &snapshotv1.VolumeSnapshot{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "snapshot", Namespace: "default"}, stored))
+	assert.NotContains(t, stored.Annotations, controllers.AnnotationTarballExportStartTime)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(tarballExports.WithLabelValues("default", "metrics-node", tarballExportFailed)))
+	histogram := &dto.Metric{}
+	require.NoError(t, tarballExportDuration.WithLabelValues("default", "metrics-node", tarballExportFailed).(prometheus.Metric).Write(histogram))
+	assert.Equal(t, uint64(1), histogram.GetHistogram().GetSampleCount())
+	assert.InDelta(t, time.Hour.Seconds(), histogram.GetHistogram().GetSampleSum(), 60)
+
+	deleteChainNodeMetrics(types.NamespacedName{Name: "metrics-node", Namespace: "default"})
+	assert.False(t, tarballExports.DeleteLabelValues("default", "metrics-node", tarballExportFailed))
+	assert.False(t, tarballExportDuration.DeleteLabelValues("default", "metrics-node", tarballExportFailed))
+}
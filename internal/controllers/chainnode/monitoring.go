@@ -0,0 +1,205 @@
+package chainnode
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+	"github.com/voluzi/cosmopilot/v3/internal/cosmoguard"
+)
+
+const (
+	// peersZeroFor is how long a node must have no peers before alerting.
+	peersZeroFor = 5 * time.Minute
+
+	// diskUsageFor is how long the data volume must stay above the usage threshold before alerting.
+	diskUsageFor = 5 * time.Minute
+)
+
+// ensureMonitoring reconciles the Prometheus Operator resources of the node: a monitor for the app and
+// node-utils metrics, a monitor for the standalone CosmoGuard when the node has one, and a PrometheusRule
+// with the default alerts. Resources that are no longer wanted are removed. Nothing is done when the
+// Prometheus Operator CRDs are not installed.
+func (r *Reconciler) ensureMonitoring(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	cfg := chainNode.Spec.Monitoring
+
+	if err := r.ensureMonitor(ctx, chainNode, r.getNodeMonitorParams(chainNode), cfg != nil); err != nil {
+		return fmt.Errorf("failed to ensure node monitor for %s: %w", chainNode.GetName(), err)
+	}
+
+	guardMonitored := cfg != nil && r.standaloneGuardManaged(chainNode)
+	if err := r.ensureMonitor(ctx, chainNode, r.getGuardMonitorParams(chainNode), guardMonitored); err != nil {
+		return fmt.Errorf("failed to ensure cosmoguard monitor for %s: %w", chainNode.GetName(), err)
+	}
+
+	rule, err := r.getPrometheusRuleSpec(chainNode)
+	if err != nil {
+		return err
+	}
+	if !cfg.AlertsEnabled() {
+		return controllers.DeleteOwnedMonitoringObject(ctx, r.Client, chainNode, rule)
+	}
+	applied, err := controllers.EnsurePrometheusRule(ctx, r.Client, rule)
+	if err != nil {
+		return fmt.Errorf("failed to ensure prometheus rule for %s: %w", chainNode.GetName(), err)
+	}
+	if !applied {
+		log.FromContext(ctx).V(1).Info("prometheus operator CRDs not installed, skipping monitoring")
+	}
+	return nil
+}
+
+// ensureMonitor creates the ServiceMonitor or PodMonitor described by params, depending on the monitoring
+// config of the node, and removes the other kind. Both are removed when enabled is false.
+func (r *Reconciler) ensureMonitor(ctx context.Context, chainNode *appsv1.ChainNode, params controllers.MonitorParams, enabled bool) error {
+	serviceMonitor := params.ServiceMonitor()
+	podMonitor := params.PodMonitor()
+	for _, obj := range []client.Object{serviceMonitor, podMonitor} {
+		if err := controllerutil.SetControllerReference(chainNode, obj, r.Scheme); err != nil {
+			return err
+		}
+	}
+
+	usePodMonitor := chainNode.Spec.Monitoring.UsePodMonitor()
+	if !enabled || usePodMonitor {
+		if err := controllers.DeleteOwnedMonitoringObject(ctx, r.Client, chainNode, serviceMonitor); err != nil {
+			return err
+		}
+	}
+	if !enabled || !usePodMonitor {
+		if err := controllers.DeleteOwnedMonitoringObject(ctx, r.Client, chainNode, podMonitor); err != nil {
+			return err
+		}
+	}
+	if !enabled {
+		return nil
+	}
+
+	var err error
+	if usePodMonitor {
+		_, err = controllers.EnsurePodMonitor(ctx, r.Client, podMonitor)
+	} else {
+		_, err = controllers.EnsureServiceMonitor(ctx, r.Client, serviceMonitor)
+	}
+	return err
+}
+
+// getNodeMonitorParams returns the monitor of the app and node-utils metrics. A ServiceMonitor scrapes the
+// internal Service of the node, which publishes not-ready addresses, so that a stalled node is still scraped.
+// A PodMonitor selects the same pods as the node Service.
+func (r *Reconciler) getNodeMonitorParams(chainNode *appsv1.ChainNode) controllers.MonitorParams {
+	selector := map[string]string{
+		controllers.LabelChainNode: chainNode.GetName(),
+		controllers.LabelSeed:      controllers.StringValueFalse,
+	}
+	if chainNode.Spec.Monitoring.UsePodMonitor() {
+		selector = WithChainNodeLabels(chainNode, map[string]string{
+			controllers.LabelNodeID:  chainNode.Status.NodeID,
+			controllers.LabelChainID: chainNode.Status.ChainID,
+		})
+	}
+	return controllers.MonitorParams{
+		Name:      chainNode.GetName(),
+		Namespace: chainNode.GetNamespace(),
+		Labels:    WithChainNodeLabels(chainNode, chainNode.Spec.Monitoring.GetLabels()),
+		Selector:  selector,
+		Ports:     []string{chainutils.PrometheusPortName, nodeUtilsPortName},
+		Interval:  chainNode.Spec.Monitoring.GetInterval(),
+	}
+}
+
+// getGuardMonitorParams returns the monitor of the metrics of the standalone CosmoGuard of the node.
+func (r *Reconciler) getGuardMonitorParams(chainNode *appsv1.ChainNode) controllers.MonitorParams {
+	return controllers.MonitorParams{
+		Name:      chainNode.CosmoGuardName(),
+		Namespace: chainNode.GetNamespace(),
+		Labels:    WithChainNodeLabels(chainNode, chainNode.Spec.Monitoring.GetLabels()),
+		Selector:  cosmoguard.InstanceLabels(chainNode.CosmoGuardName()),
+		Ports:     []string{controllers.CosmoGuardMetricsPortName},
+		Interval:  chainNode.Spec.Monitoring.GetInterval(),
+	}
+}
+
+// getPrometheusRuleSpec returns the PrometheusRule with the default alerts of the node. Node metrics are
+// matched by the namespace and pod labels Prometheus attaches to scraped targets, and the jailed alert relies
+// on the operator metrics, which keep their own namespace label only when scraped with honorLabels.
+func (r *Reconciler) getPrometheusRuleSpec(chainNode *appsv1.ChainNode) (*monitoringv1.PrometheusRule, error) {
+	alerts := chainNode.Spec.Monitoring.GetAlerts()
+	target := fmt.Sprintf(`namespace=%q, pod=%q`, chainNode.GetNamespace(), chainNode.GetName())
+	volume := fmt.Sprintf(`namespace=%q, persistentvolumeclaim=%q`, chainNode.GetNamespace(), chainNode.GetName())
+	stalledFor := controllers.PrometheusDuration(alerts.GetHeightStalledFor())
+
+	rules := []monitoringv1.Rule{
+		{
+			Alert: "CosmopilotNodeHeightStalled",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`max by (namespace, pod) (changes({__name__=~"(cometbft|tendermint)_consensus_height", %s}[%s])) == 0`, target, stalledFor)),
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Node %s/%s is not producing blocks", chainNode.GetNamespace(), chainNode.GetName()),
+				"description": fmt.Sprintf("The block height of %s has not changed in %s.", chainNode.GetName(), stalledFor),
+			},
+		},
+		{
+			Alert: "CosmopilotNodeNoPeers",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`max by (namespace, pod) ({__name__=~"(cometbft|tendermint)_p2p_peers", %s}) == 0`, target)),
+			For:    ptr.To(controllers.PrometheusDuration(peersZeroFor)),
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Node %s/%s has no peers", chainNode.GetNamespace(), chainNode.GetName()),
+				"description": fmt.Sprintf("%s has not been connected to any peer for %s.", chainNode.GetName(), controllers.PrometheusDuration(peersZeroFor)),
+			},
+		},
+		{
+			Alert: "CosmopilotNodeDiskNearFull",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`max by (namespace, persistentvolumeclaim) (kubelet_volume_stats_used_bytes{%s} / kubelet_volume_stats_capacity_bytes{%s}) * 100 > %d`,
+				volume, volume, alerts.GetDiskUsagePercent())),
+			For:    ptr.To(controllers.PrometheusDuration(diskUsageFor)),
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Data volume of node %s/%s is almost full", chainNode.GetNamespace(), chainNode.GetName()),
+				"description": fmt.Sprintf("More than %d%% of the data volume of %s is in use.", alerts.GetDiskUsagePercent(), chainNode.GetName()),
+			},
+		},
+	}
+
+	if chainNode.IsValidator() {
+		// Operator metrics scraped without honorLabels have the namespace of the ChainNode in exported_namespace
+		rules = append(rules, monitoringv1.Rule{
+			Alert: "CosmopilotValidatorJailed",
+			Expr: intstr.FromString(fmt.Sprintf(
+				`max by (chainnode) (cosmopilot_chainnode_validator_jailed{namespace=%[1]q, chainnode=%[2]q} or cosmopilot_chainnode_validator_jailed{exported_namespace=%[1]q, chainnode=%[2]q}) == 1`,
+				chainNode.GetNamespace(), chainNode.GetName())),
+			Labels: map[string]string{"severity": "critical"},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("Validator %s/%s is jailed", chainNode.GetNamespace(), chainNode.GetName()),
+				"description": fmt.Sprintf("%s is jailed and not signing blocks.", chainNode.GetName()),
+			},
+		})
+	}
+
+	rule := &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chainNode.GetName(),
+			Namespace: chainNode.GetNamespace(),
+			Labels:    WithChainNodeLabels(chainNode, chainNode.Spec.Monitoring.GetLabels()),
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{{Name: chainNode.GetName(), Rules: rules}},
+		},
+	}
+	return rule, controllerutil.SetControllerReference(chainNode, rule, r.Scheme)
+}
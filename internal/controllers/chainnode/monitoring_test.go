@@ -0,0 +1,131 @@
+package chainnode
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func newMonitoringTestReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, monitoringv1.AddToScheme(scheme))
+	c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &Reconciler{Client: c, Scheme: scheme}
+}
+
+func monitoredChainNode() *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "validator", Namespace: "default", UID: types.UID("chainnode-uid")},
+		Spec: appsv1.ChainNodeSpec{
+			Validator: &appsv1.ValidatorConfig{},
+			Monitoring: &appsv1.MonitoringConfig{
+				Labels: map[string]string{"release": "prometheus"},
+				Alerts: &appsv1.MonitoringAlertsConfig{HeightStalledFor: ptr.To("10m")},
+			},
+		},
+	}
+}
+
+func TestEnsureMonitoring(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "default", Name: "validator"}
+
+	chainNode := monitoredChainNode()
+	r := newMonitoringTestReconciler(t, chainNode)
+
+	require.NoError(t, r.ensureMonitoring(ctx, chainNode))
+
+	serviceMonitor := &monitoringv1.ServiceMonitor{}
+	require.NoError(t, r.Get(ctx, key, serviceMonitor))
+	assert.True(t, metav1.IsControlledBy(serviceMonitor, chainNode))
+	assert.Equal(t, "prometheus", serviceMonitor.Labels["release"])
+	assert.Equal(t, map[string]string{
+		controllers.LabelChainNode: "validator",
+		controllers.LabelSeed:      controllers.StringValueFalse,
+	}, serviceMonitor.Spec.Selector.MatchLabels)
+	require.Len(t, serviceMonitor.Spec.Endpoints, 2)
+	assert.Equal(t, chainutils.PrometheusPortName, serviceMonitor.Spec.Endpoints[0].Port)
+	assert.Equal(t, nodeUtilsPortName, serviceMonitor.Spec.Endpoints[1].Port)
+	assert.Equal(t, monitoringv1.Duration("30s"), serviceMonitor.Spec.Endpoints[0].Interval)
+
+	rule := &monitoringv1.PrometheusRule{}
+	require.NoError(t, r.Get(ctx, key, rule))
+	require.Len(t, rule.Spec.Groups, 1)
+	var alerts []string
+	for _, alert := range rule.Spec.Groups[0].Rules {
+		alerts = append(alerts, alert.Alert)
+	}
+	assert.Equal(t, []string{
+		"CosmopilotNodeHeightStalled",
+		"CosmopilotNodeNoPeers",
+		"CosmopilotNodeDiskNearFull",
+		"CosmopilotValidatorJailed",
+	}, alerts)
+	assert.Contains(t, rule.Spec.Groups[0].Rules[0].Expr.String(), `pod="validator"}[10m]`)
+	assert.Contains(t, rule.Spec.Groups[0].Rules[2].Expr.String(), "> 90")
+	assert.Contains(t, rule.Spec.Groups[0].Rules[3].Expr.String(), `exported_namespace="default", chainnode="validator"`)
+
+	// Switching to PodMonitors replaces the ServiceMonitor
+	chainNode.Spec.Monitoring.PodMonitor = ptr.To(true)
+	require.NoError(t, r.ensureMonitoring(ctx, chainNode))
+	assert.True(t, errors.IsNotFound(r.Get(ctx, key, &monitoringv1.ServiceMonitor{})))
+	podMonitor := &monitoringv1.PodMonitor{}
+	require.NoError(t, r.Get(ctx, key, podMonitor))
+	assert.Len(t, podMonitor.Spec.PodMetricsEndpoints, 2)
+
+	// Disabling alerts removes the rule only
+	chainNode.Spec.Monitoring.Alerts.Enabled = ptr.To(false)
+	require.NoError(t, r.ensureMonitoring(ctx, chainNode))
+	assert.True(t, errors.IsNotFound(r.Get(ctx, key, &monitoringv1.PrometheusRule{})))
+	require.NoError(t, r.Get(ctx, key, &monitoringv1.PodMonitor{}))
+
+	// Removing the monitoring block removes everything
+	chainNode.Spec.Monitoring = nil
+	require.NoError(t, r.ensureMonitoring(ctx, chainNode))
+	assert.True(t, errors.IsNotFound(r.Get(ctx, key, &monitoringv1.PodMonitor{})))
+	assert.True(t, errors.IsNotFound(r.Get(ctx, key, &monitoringv1.ServiceMonitor{})))
+}
+
+func TestEnsureMonitoringKeepsForeignResources(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "default", Name: "validator"}
+
+	foreign := &monitoringv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{
+		Name:      "validator",
+		Namespace: "default",
+		Labels:    map[string]string{"owner": "user"},
+	}}
+
+	t.Run("not overwritten", func(t *testing.T) {
+		chainNode := monitoredChainNode()
+		r := newMonitoringTestReconciler(t, chainNode, foreign.DeepCopy())
+		assert.ErrorContains(t, r.ensureMonitoring(ctx, chainNode), "managed by another owner")
+	})
+
+	t.Run("not deleted", func(t *testing.T) {
+		chainNode := monitoredChainNode()
+		chainNode.Spec.Monitoring = nil
+		r := newMonitoringTestReconciler(t, chainNode, foreign.DeepCopy())
+		require.NoError(t, r.ensureMonitoring(ctx, chainNode))
+
+		current := &monitoringv1.ServiceMonitor{}
+		require.NoError(t, r.Get(ctx, key, current))
+		assert.Equal(t, "user", current.Labels["owner"])
+	})
+}
@@ -0,0 +1,80 @@
+package chainnode
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
+)
+
+// ensureNodeUtilsToken creates the Secret with the token required by node-utils on routes that change its state
+// or the state of the node (e.g. /shutdown). The token is generated once and kept for the life of the ChainNode.
+func (r *Reconciler) ensureNodeUtilsToken(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	name := getNodeUtilsTokenSecretName(chainNode)
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: chainNode.GetNamespace(), Name: name}, secret)
+	if err == nil {
+		// Refuse a same-named Secret we don't own: anyone able to create it would know the token.
+		if !metav1.IsControlledBy(secret, chainNode) {
+			return fmt.Errorf("node-utils secret %q exists but is not owned by this ChainNode; refusing to use it", name)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	token, err := nodeutils.GenerateAuthToken()
+	if err != nil {
+		return err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: chainNode.GetNamespace(),
+			Labels:    WithChainNodeLabels(chainNode),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{nodeutils.AuthTokenSecretKey: []byte(token)},
+	}
+	if err := controllerutil.SetControllerReference(chainNode, secret, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, secret)
+}
+
+// getNodeUtilsToken returns the token required by node-utils, or an empty string if the Secret does not exist
+// (e.g. for pods created before node-utils required it).
+func (r *Reconciler) getNodeUtilsToken(ctx context.Context, chainNode *appsv1.ChainNode) (string, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: chainNode.GetNamespace(), Name: getNodeUtilsTokenSecretName(chainNode)}, secret)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[nodeutils.AuthTokenSecretKey]), nil
+}
+
+// getNodeUtilsTokenEnv returns the environment variable that passes the token to the node-utils container.
+func getNodeUtilsTokenEnv(chainNode *appsv1.ChainNode) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: "AUTH_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: getNodeUtilsTokenSecretName(chainNode)},
+			Key:                  nodeutils.AuthTokenSecretKey,
+		}},
+	}
+}
+
+func getNodeUtilsTokenSecretName(chainNode *appsv1.ChainNode) string {
+	return fmt.Sprintf("%s-node-utils", chainNode.GetName())
+}
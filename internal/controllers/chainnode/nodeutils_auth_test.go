@@ -0,0 +1,71 @@
+package chainnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
+)
+
+func TestEnsureNodeUtilsToken(t *testing.T) {
+	ctx := context.Background()
+	chainNode := signingLeaseTestChainNode()
+	r := signingLeaseTestReconciler(t, chainNode)
+
+	// No token before the Secret exists
+	token, err := r.getNodeUtilsToken(ctx, chainNode)
+	require.NoError(t, err)
+	assert.Empty(t, token)
+
+	require.NoError(t, r.ensureNodeUtilsToken(ctx, chainNode))
+	token, err = r.getNodeUtilsToken(ctx, chainNode)
+	require.NoError(t, err)
+	assert.Len(t, token, 64)
+
+	// Reconciling again keeps the same token
+	require.NoError(t, r.ensureNodeUtilsToken(ctx, chainNode))
+	again, err := r.getNodeUtilsToken(ctx, chainNode)
+	require.NoError(t, err)
+	assert.Equal(t, token, again)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "validator-node-utils"}, secret))
+	assert.True(t, metav1.IsControlledBy(secret, chainNode))
+}
+
+func TestEnsureNodeUtilsTokenRefusesForeignSecret(t *testing.T) {
+	chainNode := signingLeaseTestChainNode()
+	r := signingLeaseTestReconciler(t, chainNode, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "validator-node-utils"},
+		Data:       map[string][]byte{nodeutils.AuthTokenSecretKey: []byte("known")},
+	})
+
+	err := r.ensureNodeUtilsToken(context.Background(), chainNode)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not owned by this ChainNode")
+}
+
+func TestNodeUtilsContainerHasToken(t *testing.T) {
+	chainNode := signingLeaseTestChainNode()
+	chainNode.Spec.App.App = "gaiad"
+	r := signingLeaseTestReconciler(t)
+
+	container := r.buildNodeUtilsInitContainer(chainNode)
+	var env *corev1.EnvVar
+	for i := range container.Env {
+		if container.Env[i].Name == "AUTH_TOKEN" {
+			env = &container.Env[i]
+		}
+	}
+	require.NotNil(t, env)
+	require.NotNil(t, env.ValueFrom)
+	require.NotNil(t, env.ValueFrom.SecretKeyRef)
+	assert.Equal(t, "validator-node-utils", env.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, nodeutils.AuthTokenSecretKey, env.ValueFrom.SecretKeyRef.Key)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"path"
 	"reflect"
@@ -147,21 +148,46 @@ func (r *Reconciler) ensurePod(ctx context.Context, _ *chainutils.App, chainNode
 		return r.recreatePod(ctx, chainNode, pod, false)
 	}
 
+	logger.V(1).Info("updating double-sign check condition")
+	if err = r.updateDoubleSignCheckCondition(ctx, chainNode); err != nil {
+		return fmt.Errorf("failed to update double-sign check condition for %s: %w", chainNode.GetName(), err)
+	}
+
 	logger.V(1).Info("updating latest height")
 	if err = r.updateLatestHeight(ctx, chainNode); err != nil {
 		return fmt.Errorf("failed to update latest height for %s: %w", chainNode.GetName(), err)
 	}
 
+	if chainNode.ShouldTrackSigningState() {
+		logger.V(1).Info("updating signing state")
+		if err = r.updateSigningState(ctx, chainNode); err != nil {
+			return fmt.Errorf("failed to update signing state for %s: %w", chainNode.GetName(), err)
+		}
+	}
+
 	// Check if the node is waiting for an upgrade
 	logger.V(1).Info("checking if an upgrade is required")
-	requiresUpgrade, err := r.requiresUpgrade(ctx, chainNode)
+	requiredUpgrade, err := r.getRequiredUpgrade(ctx, chainNode)
 	if err != nil {
 		return fmt.Errorf("failed to check if upgrade is required for %s: %w", chainNode.GetName(), err)
 	}
 
-	if requiresUpgrade {
+	if requiredUpgrade.MustUpgrade {
+		// Prefer the upgrade height reported by node-utils, which might be behind on traces
+		height := chainNode.Status.LatestHeight
+		if requiredUpgrade.Height > 0 {
+			height = requiredUpgrade.Height
+		}
+
+		// Node-utils knows the plan from upgrade-info.json, so it can be scheduled even if we missed it
+		if requiredUpgrade.Name != "" {
+			if err := r.addRequiredUpgrade(ctx, chainNode, requiredUpgrade); err != nil {
+				return fmt.Errorf("failed to add required upgrade for %s: %w", chainNode.GetName(), err)
+			}
+		}
+
 		// Get upgrade from scheduled upgrades list
-		upgrade := r.getUpgrade(chainNode, chainNode.Status.LatestHeight)
+		upgrade := r.getUpgrade(chainNode, height)
 
 		logger.V(1).Info("upgrade is required", "upgrade", upgrade)
 
@@ -171,9 +197,9 @@ func (r *Reconciler) ensurePod(ctx context.Context, _ *chainutils.App, chainNode
 				corev1.EventTypeWarning,
 				appsv1.ReasonUpgradeMissingData,
 				"Missing upgrade or image for upgrade at height %d",
-				chainNode.Status.LatestHeight,
+				height,
 			)
-			return fmt.Errorf("missing upgrade or image for height %d", chainNode.Status.LatestHeight)
+			return fmt.Errorf("missing upgrade or image for height %d", height)
 		}
 
 		logger.Info("upgrading node", "pod", pod.GetName())
@@ -181,6 +207,13 @@ func (r *Reconciler) ensurePod(ctx context.Context, _ *chainutils.App, chainNode
 			return fmt.Errorf("failed to set upgrade status for %s: %w", chainNode.GetName(), err)
 		}
 
+		// Track the upgrade so that it is rolled back if the node does not get past the upgrade height
+		if chainNode.Spec.App.ShouldRollbackFailedUpgrades() {
+			if err := r.setUpgradePendingVerification(ctx, chainNode, upgrade.Height); err != nil {
+				return fmt.Errorf("failed to track upgrade for %s: %w", chainNode.GetName(), err)
+			}
+		}
+
 		// Set upgrading label to true
 		modifiedPod := currentPod.DeepCopy()
 		if modifiedPod.Labels == nil {
@@ -207,7 +240,14 @@ func (r *Reconciler) ensurePod(ctx context.Context, _ *chainutils.App, chainNode
 			return fmt.Errorf("failed to get pod spec after config update for %s: %w", chainNode.GetName(), err)
 		}
 
-		if upgraded, err := r.upgradePod(ctx, chainNode, pod, upgrade.Image); err != nil {
+		if upgraded, err := r.upgradePod(ctx, chainNode, pod, upgrade); err != nil {
+			if stderrors.Is(err, errSigningLeaseHeld) {
+				// The upgrade is retried once the node halts again at the upgrade height with the lease released.
+				if statusErr := r.setUpgradeStatus(ctx, chainNode, upgrade, appsv1.UpgradeScheduled); statusErr != nil {
+					return statusErr
+				}
+				return err
+			}
 			r.recorder.Eventf(chainNode,
 				corev1.EventTypeWarning,
 				appsv1.ReasonUpgradeFailed,
@@ -241,6 +281,13 @@ func (r *Reconciler) ensurePod(ctx context.Context, _ *chainutils.App, chainNode
 		return r.setUpgradeStatus(ctx, chainNode, upgrade, appsv1.UpgradeCompleted)
 	}
 
+	// node-utils halts the node at the heights of snapshot height schedules. The pod is kept until the
+	// snapshot is taken, and replaced afterwards.
+	if height := getSnapshotHaltHeight(chainNode); height > 0 {
+		logger.Info("node halted for scheduled snapshot", "height", height)
+		return nil
+	}
+
 	// A terminated application with a live node-utils sidecar gets one chance to report a
 	// scheduled upgrade above. If no upgrade is required, recreate it as an ordinary failure.
 	if podInFailedState(chainNode, currentPod) {
@@ -303,6 +350,10 @@ func (r *Reconciler) createPod(ctx context.Context, chainNode *appsv1.ChainNode,
 		return fmt.Errorf("failed to update phase for %s: %w", chainNode.GetName(), err)
 	}
 
+	if err := r.checkSigningLeaseReleased(ctx, chainNode); err != nil {
+		return err
+	}
+
 	ph := k8s.NewPodHelper(r.ClientSet, r.RestConfig, pod)
 	if err := ph.Create(ctx); err != nil {
 		return fmt.Errorf("failed to create pod %s: %w", pod.GetName(), err)
@@ -436,9 +487,30 @@ func (r *Reconciler) buildNodeUtilsInitContainer(chainNode *appsv1.ChainNode) co
 			Value: strconv.FormatInt(chainNode.Spec.Config.GetHaltHeight(), 10),
 		},
 	)
+	if chainNode.ShouldUseSigningLease() {
+		env = append(env,
+			corev1.EnvVar{
+				Name:  "SIGNING_LEASE",
+				Value: chainNode.GetSigningLeaseName(),
+			},
+			corev1.EnvVar{
+				Name: "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.namespace",
+				}},
+			},
+			corev1.EnvVar{
+				Name: "POD_UID",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.uid",
+				}},
+			},
+		)
+	}
+	env = append(env, getNodeUtilsTokenEnv(chainNode))
 	env = append(env, chainNode.Spec.Config.GetNodeUtilsEnv()...)
 
-	return corev1.Container{
+	container := corev1.Container{
 		Name:            nodeUtilsContainerName,
 		Image:           r.opts.NodeUtilsImage,
 		ImagePullPolicy: corev1.PullIfNotPresent,
@@ -483,6 +555,26 @@ func (r *Reconciler) buildNodeUtilsInitContainer(chainNode *appsv1.ChainNode) co
 			PeriodSeconds:    2,
 		},
 	}
+
+	// Sidecar containers block the start of the application until their startup probe succeeds, so the
+	// application only starts signing once the signing lease is held and no recent signatures were found.
+	if chainNode.ShouldUseSigningLease() || chainNode.ShouldCheckDoubleSign() {
+		container.StartupProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/ready_to_sign",
+					Port: intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: nodeUtilsPort,
+					},
+					Scheme: "HTTP",
+				},
+			},
+			FailureThreshold: readyToSignStartupFailureThreshold,
+			PeriodSeconds:    2,
+		}
+	}
+	return container
 }
 
 func signerPeerDNS(chainNode *appsv1.ChainNode) string {
@@ -732,7 +824,7 @@ func (r *Reconciler) getPodSpec(ctx context.Context, chainNode *appsv1.ChainNode
 		Spec: corev1.PodSpec{
 			ShareProcessNamespace:         ptr.To(true),
 			RestartPolicy:                 corev1.RestartPolicyNever,
-			ServiceAccountName:            chainNode.Spec.Config.GetServiceAccountName(),
+			ServiceAccountName:            getPodServiceAccountName(chainNode),
 			PriorityClassName:             r.opts.GetNodesPriorityClassName(),
 			Affinity:                      chainNode.Spec.Affinity,
 			NodeSelector:                  chainNode.Spec.NodeSelector,
@@ -750,6 +842,18 @@ func (r *Reconciler) getPodSpec(ctx context.Context, chainNode *appsv1.ChainNode
 			r.buildCosmosignerDiscoveryInitContainer(chainNode, cosmosignerTarget))
 	}
 
+	// The signing state on disk must be checked before the application starts signing
+	if chainNode.ShouldTrackSigningState() {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, getSigningStateVolume(chainNode))
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, r.buildCheckSigningStateInitContainer(appSecurityContext))
+	}
+
+	if chainNode.ShouldCheckDoubleSign() {
+		if err := withDoubleSignCheck(&pod.Spec.InitContainers[0], chainNode); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, volume := range chainNode.GetPersistenceAdditionalVolumes() {
 		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
 			Name: volume.Name,
@@ -1014,6 +1118,10 @@ func (r *Reconciler) recreatePod(ctx context.Context, chainNode *appsv1.ChainNod
 	}
 	logger.V(1).Info("pod deleted", "pod", pod.GetName())
 
+	if err := r.checkSigningLeaseReleased(ctx, chainNode); err != nil {
+		return err
+	}
+
 	ph = k8s.NewPodHelper(r.ClientSet, r.RestConfig, pod)
 	if err := ph.Create(ctx); err != nil {
 		return fmt.Errorf("failed to recreate pod %s: %w", pod.GetName(), err)
@@ -1036,7 +1144,7 @@ func (r *Reconciler) recreatePod(ctx context.Context, chainNode *appsv1.ChainNod
 	return r.setNodePhase(ctx, chainNode)
 }
 
-func (r *Reconciler) upgradePod(ctx context.Context, chainNode *appsv1.ChainNode, pod *corev1.Pod, image string) (bool, error) {
+func (r *Reconciler) upgradePod(ctx context.Context, chainNode *appsv1.ChainNode, pod *corev1.Pod, upgrade *appsv1.Upgrade) (bool, error) {
 	logger := log.FromContext(ctx)
 
 	logger.Info("upgrading pod", "pod", pod.GetName())
@@ -1060,6 +1168,19 @@ func (r *Reconciler) upgradePod(ctx context.Context, chainNode *appsv1.ChainNode
 		return false, err
 	}
 
+	// With the node stopped at the upgrade height, take a snapshot of its data before the new image
+	// starts migrating it.
+	if chainNode.ShouldSnapshotBeforeUpgrade() {
+		if err := r.ensureUpgradeSnapshot(ctx, chainNode, upgrade); err != nil {
+			return false, err
+		}
+	}
+
+	if err := r.checkSigningLeaseReleased(ctx, chainNode); err != nil {
+		return false, err
+	}
+
+	image := upgrade.Image
 	ph = k8s.NewPodHelper(r.ClientSet, r.RestConfig, pod)
 	pod.Spec.Containers[0].Image = image
 	if err := ph.Create(ctx); err != nil {
@@ -1364,7 +1485,11 @@ func nodeUtilsIsInFailedState(pod *corev1.Pod) bool {
 }
 
 func (r *Reconciler) stopNodeUtilsContainer(ctx context.Context, chainNode *appsv1.ChainNode) error {
-	return nodeutils.NewClient(chainNode.GetNodeFQDN()).ShutdownNodeUtilsServer(ctx)
+	token, err := r.getNodeUtilsToken(ctx, chainNode)
+	if err != nil {
+		return err
+	}
+	return nodeutils.NewClient(chainNode.GetNodeFQDN(), nodeutils.WithToken(token)).ShutdownNodeUtilsServer(ctx)
 }
 
 func isPodTerminating(pod *corev1.Pod) bool {
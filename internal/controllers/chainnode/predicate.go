@@ -18,7 +18,7 @@ type GenerationChangedPredicate struct {
 
 var temporaryPodSuffixes = []string{
 	"config-generator", "data-init", "init-data", "genesis-init", "tmkms-vault-upload", "tmkms-generate-identity",
-	"write-file", "create-validator", "signer-pubkey", "signer-import",
+	"write-file", "create-validator", "signer-pubkey", "signer-import", "image-prepull",
 }
 
 func isTemporaryPodName(name string) bool {
@@ -20,6 +20,7 @@ import (
 	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
 	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
 	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+	"github.com/voluzi/cosmopilot/v3/internal/datasnapshot"
 	"github.com/voluzi/cosmopilot/v3/internal/resourcecleanup"
 	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
 )
@@ -87,6 +88,19 @@ func (r *Reconciler) initializeData(ctx context.Context, app *chainutils.App, ch
 	}
 
 	initCommands := r.buildInitCommands(chainNode)
+	if chainNode.ShouldRestoreFromTarball() {
+		restoreCommand, err := r.buildTarballRestoreCommand(chainNode)
+		if err != nil {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonDataInitFailed,
+				"Cannot restore data from tarball: %v", err,
+			)
+			return ctrl.Result{}, err
+		}
+		// Extract the archive before user commands so they operate on the restored data.
+		initCommands = append([]*chainutils.InitCommand{restoreCommand}, initCommands...)
+	}
 	additionalVolumes := r.buildAdditionalVolumes(chainNode)
 	initTimeout := chainNode.GetPersistenceInitTimeout()
 
@@ -156,6 +170,29 @@ func (r *Reconciler) buildInitCommands(chainNode *appsv1.ChainNode) []*chainutil
 	return initCommands
 }
 
+// buildTarballRestoreCommand constructs the init command that downloads and extracts the tarball
+// configured in .spec.persistence.restoreFromTarball. Without an explicit name, the most recent archive
+// for the node's chain is restored.
+func (r *Reconciler) buildTarballRestoreCommand(chainNode *appsv1.ChainNode) (*chainutils.InitCommand, error) {
+	cfg := chainNode.Spec.Persistence.RestoreFromTarball
+	if cfg.Name != nil {
+		return datasnapshot.RestoreCommand(cfg, r.opts.GetDataExporterImage(), *cfg.Name, false), nil
+	}
+	if cfg.Prefix != nil {
+		return datasnapshot.RestoreCommand(cfg, r.opts.GetDataExporterImage(), *cfg.Prefix, true), nil
+	}
+
+	chainID := chainNode.Status.ChainID
+	if chainID == "" && chainNode.Spec.Genesis != nil && chainNode.Spec.Genesis.ChainID != nil {
+		chainID = *chainNode.Spec.Genesis.ChainID
+	}
+	if chainID == "" {
+		return nil, fmt.Errorf("chain ID is not known yet: set .spec.persistence.restoreFromTarball.name, .spec.persistence.restoreFromTarball.prefix or .spec.genesis.chainID")
+	}
+	// Exported tarballs are named <chain-id>-<timestamp>[-<suffix>].
+	return datasnapshot.RestoreCommand(cfg, r.opts.GetDataExporterImage(), chainID+"-", true), nil
+}
+
 // buildAdditionalVolumes constructs the additional volumes from the ChainNode spec.
 func (r *Reconciler) buildAdditionalVolumes(chainNode *appsv1.ChainNode) []chainutils.AdditionalVolume {
 	additionalVolumes := make([]chainutils.AdditionalVolume, len(chainNode.GetPersistenceAdditionalVolumes()))
@@ -202,16 +239,17 @@ func (r *Reconciler) ensureDataVolume(ctx context.Context, app *chainutils.App,
 			return nil, ctrl.Result{}, err
 		}
 
-		if chainNode.ShouldRestoreFromSnapshot() {
+		restoreSnapshot := getRestoreSnapshotName(chainNode)
+		if restoreSnapshot != "" {
 			snapshot := &snapshotv1.VolumeSnapshot{}
 			err = r.Get(ctx, types.NamespacedName{
 				Namespace: chainNode.GetNamespace(),
-				Name:      chainNode.Spec.Persistence.RestoreFromSnapshot.Name,
+				Name:      restoreSnapshot,
 			}, snapshot)
 			if err != nil {
 				return nil, ctrl.Result{}, err
 			}
-			if snapshot.Status.RestoreSize != nil {
+			if snapshot.Status != nil && snapshot.Status.RestoreSize != nil {
 				storageSize = *snapshot.Status.RestoreSize
 			} else {
 				logger.Info("could not grab restore size from snapshot. Falling back to .persistence.size", "size", storageSize)
@@ -228,6 +266,14 @@ func (r *Reconciler) ensureDataVolume(ctx context.Context, app *chainutils.App,
 					return nil, ctrl.Result{}, err
 				}
 			}
+		} else if chainNode.ShouldRestoreFromTarball() {
+			// Tarballs do not record their height, so rely on the one in the spec to pick the app version.
+			if height := chainNode.Spec.Persistence.RestoreFromTarball.GetHeight(); chainNode.Status.LatestHeight != height {
+				chainNode.Status.LatestHeight = height
+				if err = r.Status().Update(ctx, chainNode); err != nil {
+					return nil, ctrl.Result{}, err
+				}
+			}
 		} else {
 			// In case the PVC was deleted on an existing node, lets set latest height to 0 to make sure state-sync
 			// configuration can be applied if necessary.
@@ -247,7 +293,7 @@ func (r *Reconciler) ensureDataVolume(ctx context.Context, app *chainutils.App,
 				Namespace: chainNode.GetNamespace(),
 				Labels:    WithChainNodeLabels(chainNode),
 				Annotations: map[string]string{
-					controllers.AnnotationDataInitialized: strconv.FormatBool(chainNode.ShouldRestoreFromSnapshot()),
+					controllers.AnnotationDataInitialized: strconv.FormatBool(restoreSnapshot != ""),
 					controllers.AnnotationDataHeight:      strconv.FormatInt(chainNode.Status.LatestHeight, 10),
 				},
 			},
@@ -264,11 +310,11 @@ func (r *Reconciler) ensureDataVolume(ctx context.Context, app *chainutils.App,
 			},
 		}
 
-		if chainNode.ShouldRestoreFromSnapshot() {
+		if restoreSnapshot != "" {
 			pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
 				APIGroup: ptr.To(VolumeSnapshotDataSourceApiGroup),
 				Kind:     VolumeSnapshotDataSourceKind,
-				Name:     chainNode.Spec.Persistence.RestoreFromSnapshot.Name,
+				Name:     restoreSnapshot,
 			}
 		}
 		if _, _, err := resourcecleanup.PrepareGeneratedResource(pvc, chainNode, r.Scheme, resourcecleanup.ClassDataVolumes, true); err != nil {
@@ -279,6 +325,15 @@ func (r *Reconciler) ensureDataVolume(ctx context.Context, app *chainutils.App,
 			return nil, ctrl.Result{}, err
 		}
 
+		// The data from an upgrade rollback is restored now
+		if _, ok := chainNode.Annotations[controllers.AnnotationUpgradeRollbackSnapshot]; ok {
+			logger.Info("restored pre-upgrade data", "snapshot", restoreSnapshot)
+			delete(chainNode.Annotations, controllers.AnnotationUpgradeRollbackSnapshot)
+			if err = r.Update(ctx, chainNode); err != nil {
+				return nil, ctrl.Result{}, err
+			}
+		}
+
 		chainNode.Status.PvcSize = storageSize.String()
 		if err = r.Status().Update(ctx, chainNode); err != nil {
 			return nil, ctrl.Result{}, err
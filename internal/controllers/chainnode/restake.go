@@ -0,0 +1,209 @@
+package chainnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+)
+
+const (
+	// maxRestakeRuns is the number of auto-restake runs kept in status.
+	maxRestakeRuns = 10
+
+	// restakeRetryPeriod is the time after which a failed auto-restake run is retried.
+	restakeRetryPeriod = time.Hour
+
+	// restakeTimeout is the time an auto-restake run has to submit its transactions and see them included
+	// in a block.
+	restakeTimeout = 10 * time.Minute
+
+	// restakeCheckPeriod is the time after which an auto-restake run in progress is checked again.
+	restakeCheckPeriod = 10 * time.Second
+)
+
+// autoRestake periodically withdraws the rewards and commission of the validator and delegates the
+// balance above the configured reserve back to it. Runs progress one step on each reconcile and never
+// wait for pods or transactions, so it returns true while a run is in progress for the reconcile to be
+// requeued. Each finished run is recorded in status.
+func (r *Reconciler) autoRestake(ctx context.Context, app *chainutils.App, chainNode *appsv1.ChainNode) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	run := chainNode.Status.RestakeInProgress
+	if run == nil {
+		if !isRestakeDue(chainNode, time.Now()) {
+			return false, nil
+		}
+		if r.hasPendingValidatorTx(chainNode) {
+			logger.V(1).Info("waiting for previous validator tx before starting auto-restake")
+			return false, nil
+		}
+		run = &appsv1.RestakeRun{Time: metav1.Now()}
+	}
+	previous := run.DeepCopy()
+
+	done, err := r.restake(ctx, app, chainNode, run)
+	if err == nil && !done && time.Since(run.Time.Time) > restakeTimeout {
+		err = fmt.Errorf("run did not finish within %s", restakeTimeout)
+	}
+
+	if err == nil && !done {
+		if chainNode.Status.RestakeInProgress != nil && equality.Semantic.DeepEqual(previous, run) {
+			return true, nil
+		}
+		chainNode.Status.RestakeInProgress = run
+		return true, r.Status().Update(ctx, chainNode)
+	}
+
+	if errors.Is(err, chainutils.ErrTxIndexDisabled) {
+		err = fmt.Errorf("auto-restake requires the tx indexer to look up its transactions: %w", err)
+	}
+
+	if err != nil {
+		logger.Error(err, "auto-restake failed")
+		run.Error = err.Error()
+		if run.DelegateTxHash == "" {
+			run.DelegatedAmount = ""
+		}
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonAutoRestakeFailure,
+			"failed to restake rewards: %s", err.Error())
+	} else if run.DelegatedAmount != "" {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeNormal,
+			appsv1.ReasonAutoRestakeSuccess,
+			"successfully restaked %s", run.DelegatedAmount)
+	} else {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeNormal,
+			appsv1.ReasonAutoRestakeSuccess,
+			"rewards withdrawn but balance does not exceed the reserve, nothing was restaked")
+	}
+
+	chainNode.Status.RestakeInProgress = nil
+	chainNode.Status.Restakes = appendRestakeRun(chainNode.Status.Restakes, *run)
+	return false, r.Status().Update(ctx, chainNode)
+}
+
+// restake moves run one step forward: it withdraws rewards and commission, waits for the withdrawal to be
+// included in a block, and delegates the balance above the reserve. Transaction hashes and the amount being
+// delegated are recorded in run. Returns true once the run is finished.
+func (r *Reconciler) restake(ctx context.Context, app *chainutils.App, chainNode *appsv1.ChainNode, run *appsv1.RestakeRun) (bool, error) {
+	logger := log.FromContext(ctx)
+	cfg := chainNode.Spec.Validator.AutoRestake
+
+	reserve, err := sdk.ParseCoinNormalized(cfg.Reserve)
+	if err != nil {
+		return false, fmt.Errorf("parsing reserve: %w", err)
+	}
+
+	client, err := r.getChainNodeClient(chainNode)
+	if err != nil {
+		return false, err
+	}
+
+	params := &chainutils.Params{
+		ChainID:   chainNode.Status.ChainID,
+		GasPrices: cfg.GasPrices,
+	}
+	node := fmt.Sprintf("tcp://%s:%d", chainNode.GetNodeFQDN(), chainutils.RpcPort)
+
+	switch {
+	case run.WithdrawTxHash == "":
+		account, err := r.getValidatorAccount(ctx, chainNode)
+		if err != nil {
+			return false, err
+		}
+		logger.Info("submitting withdraw-rewards tx")
+		if run.WithdrawTxHash, err = app.WithdrawRewards(ctx, account, chainNode.Status.ValidatorAddress, params, node, run.Time.Time); err != nil {
+			return false, fmt.Errorf("withdrawing rewards: %w", err)
+		}
+		return false, nil
+
+	case run.DelegatedAmount == "":
+		included, err := client.TxIncluded(ctx, run.WithdrawTxHash)
+		if err != nil {
+			return false, fmt.Errorf("withdrawing rewards: %w", err)
+		}
+		if !included {
+			return false, nil
+		}
+
+		account, err := r.getValidatorAccount(ctx, chainNode)
+		if err != nil {
+			return false, err
+		}
+		balance, err := client.QueryBalance(ctx, account.Address, reserve.Denom)
+		if err != nil {
+			return false, err
+		}
+		amount, ok := getRestakeAmount(balance, reserve)
+		if !ok {
+			logger.Info("balance does not exceed the reserve", "balance", balance, "reserve", reserve)
+			return true, nil
+		}
+		run.DelegatedAmount = amount.String()
+		return false, nil
+
+	case run.DelegateTxHash == "":
+		account, err := r.getValidatorAccount(ctx, chainNode)
+		if err != nil {
+			return false, err
+		}
+		logger.Info("submitting delegate tx", "amount", run.DelegatedAmount)
+		if run.DelegateTxHash, err = app.Delegate(ctx, account, chainNode.Status.ValidatorAddress, run.DelegatedAmount, params, node, run.Time.Time); err != nil {
+			return false, fmt.Errorf("delegating %s: %w", run.DelegatedAmount, err)
+		}
+		return false, nil
+
+	default:
+		included, err := client.TxIncluded(ctx, run.DelegateTxHash)
+		if err != nil {
+			return false, fmt.Errorf("delegating %s: %w", run.DelegatedAmount, err)
+		}
+		return included, nil
+	}
+}
+
+// isRestakeDue returns true if the configured frequency elapsed since the last auto-restake run, or if
+// the last run failed and the retry period elapsed.
+func isRestakeDue(chainNode *appsv1.ChainNode, now time.Time) bool {
+	runs := chainNode.Status.Restakes
+	if len(runs) == 0 {
+		return true
+	}
+
+	last := runs[len(runs)-1]
+	wait := chainNode.Spec.Validator.AutoRestake.GetFrequency()
+	if last.Error != "" && restakeRetryPeriod < wait {
+		wait = restakeRetryPeriod
+	}
+	return now.Sub(last.Time.Time) >= wait
+}
+
+// getRestakeAmount returns the part of balance above reserve, and false if there is none.
+func getRestakeAmount(balance *sdk.Coin, reserve sdk.Coin) (sdk.Coin, bool) {
+	if balance == nil || balance.Denom != reserve.Denom || !balance.Amount.GT(reserve.Amount) {
+		return sdk.Coin{}, false
+	}
+	return balance.Sub(reserve), true
+}
+
+// appendRestakeRun appends run to runs, keeping only the most recent maxRestakeRuns.
+func appendRestakeRun(runs []appsv1.RestakeRun, run appsv1.RestakeRun) []appsv1.RestakeRun {
+	runs = append(runs, run)
+	if len(runs) > maxRestakeRuns {
+		runs = runs[len(runs)-maxRestakeRuns:]
+	}
+	return runs
+}
@@ -0,0 +1,65 @@
+package chainnode
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+func TestIsRestakeDue(t *testing.T) {
+	now := time.Now()
+
+	chainNode := func(runs ...appsv1.RestakeRun) *appsv1.ChainNode {
+		return &appsv1.ChainNode{
+			Spec: appsv1.ChainNodeSpec{
+				Validator: &appsv1.ValidatorConfig{
+					AutoRestake: &appsv1.AutoRestakeConfig{Frequency: ptr.To("12h")},
+				},
+			},
+			Status: appsv1.ChainNodeStatus{Restakes: runs},
+		}
+	}
+	run := func(ago time.Duration, err string) appsv1.RestakeRun {
+		return appsv1.RestakeRun{Time: metav1.NewTime(now.Add(-ago)), Error: err}
+	}
+
+	assert.True(t, isRestakeDue(chainNode(), now))
+	assert.False(t, isRestakeDue(chainNode(run(11*time.Hour, "")), now))
+	assert.False(t, isRestakeDue(chainNode(run(13*time.Hour, ""), run(11*time.Hour, "")), now))
+	assert.True(t, isRestakeDue(chainNode(run(12*time.Hour, "")), now))
+	assert.False(t, isRestakeDue(chainNode(run(30*time.Minute, "timeout")), now))
+	assert.True(t, isRestakeDue(chainNode(run(time.Hour, "timeout")), now))
+}
+
+func TestGetRestakeAmount(t *testing.T) {
+	reserve := sdk.NewInt64Coin("stake", 1000)
+
+	amount, ok := getRestakeAmount(ptr.To(sdk.NewInt64Coin("stake", 5000)), reserve)
+	assert.True(t, ok)
+	assert.Equal(t, "4000stake", amount.String())
+
+	_, ok = getRestakeAmount(ptr.To(sdk.NewInt64Coin("stake", 1000)), reserve)
+	assert.False(t, ok)
+
+	_, ok = getRestakeAmount(ptr.To(sdk.NewInt64Coin("other", 5000)), reserve)
+	assert.False(t, ok)
+
+	_, ok = getRestakeAmount(nil, reserve)
+	assert.False(t, ok)
+}
+
+func TestAppendRestakeRun(t *testing.T) {
+	var runs []appsv1.RestakeRun
+	for i := 0; i < maxRestakeRuns+3; i++ {
+		runs = appendRestakeRun(runs, appsv1.RestakeRun{WithdrawTxHash: string(rune('A' + i))})
+	}
+	assert.Len(t, runs, maxRestakeRuns)
+	assert.Equal(t, "D", runs[0].WithdrawTxHash)
+	assert.Equal(t, string(rune('A'+maxRestakeRuns+2)), runs[len(runs)-1].WithdrawTxHash)
+}
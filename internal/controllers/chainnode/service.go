@@ -386,11 +386,14 @@ func (r *Reconciler) getInternalServiceSpec(ctx context.Context, chainNode *apps
 			Name:      fmt.Sprintf("%s-internal", chainNode.GetName()),
 			Namespace: chainNode.GetNamespace(),
 			Labels: WithChainNodeLabels(chainNode, map[string]string{
-				controllers.LabelPeer:      controllers.StringValueTrue,
+				// Upgrade rehearsal nodes are short-lived, so other nodes must not pick them up as peers.
+				controllers.LabelPeer:      strconv.FormatBool(!isUpgradeRehearsalNode(chainNode)),
 				controllers.LabelSeed:      controllers.StringValueFalse,
 				controllers.LabelNodeID:    chainNode.Status.NodeID,
 				controllers.LabelChainID:   chainNode.Status.ChainID,
 				controllers.LabelValidator: strconv.FormatBool(chainNode.IsValidator()),
+				// Selected by the ServiceMonitor of the node, which must keep scraping it while not ready.
+				controllers.LabelChainNode: chainNode.GetName(),
 			}),
 		},
 		Spec: corev1.ServiceSpec{
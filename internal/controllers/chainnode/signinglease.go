@@ -0,0 +1,233 @@
+package chainnode
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+// ensureSigningLease creates the Lease that the validator pod must hold while signing, and grants the pod
+// service account permission to acquire and renew it. The Lease is created here so that the pod does not
+// need permission to create leases.
+func (r *Reconciler) ensureSigningLease(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	logger := log.FromContext(ctx)
+
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chainNode.GetSigningLeaseName(),
+			Namespace: chainNode.GetNamespace(),
+			Labels:    WithChainNodeLabels(chainNode),
+		},
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(lease), &coordinationv1.Lease{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get signing lease %s: %w", lease.GetName(), err)
+		}
+		if err := controllerutil.SetControllerReference(chainNode, lease, r.Scheme); err != nil {
+			return err
+		}
+		logger.Info("creating signing lease", "lease", lease.GetName())
+		if err := r.Create(ctx, lease); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create signing lease %s: %w", lease.GetName(), err)
+		}
+	}
+
+	// A service account is only created when the user did not provide one
+	if chainNode.Spec.Config.GetServiceAccountName() == "" {
+		sa := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      getPodServiceAccountName(chainNode),
+				Namespace: chainNode.GetNamespace(),
+				Labels:    WithChainNodeLabels(chainNode),
+			},
+		}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sa), &corev1.ServiceAccount{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to get service account %s: %w", sa.GetName(), err)
+			}
+			if err := controllerutil.SetControllerReference(chainNode, sa, r.Scheme); err != nil {
+				return err
+			}
+			logger.Info("creating service account", "sa", sa.GetName())
+			if err := r.Create(ctx, sa); err != nil {
+				return fmt.Errorf("failed to create service account %s: %w", sa.GetName(), err)
+			}
+		}
+	}
+
+	role, err := r.getSigningLeaseRoleSpec(chainNode)
+	if err != nil {
+		return err
+	}
+	if err := r.ensureSigningLeaseRole(ctx, role); err != nil {
+		return err
+	}
+
+	binding, err := r.getSigningLeaseRoleBindingSpec(chainNode)
+	if err != nil {
+		return err
+	}
+	return r.ensureSigningLeaseRoleBinding(ctx, binding)
+}
+
+func (r *Reconciler) ensureSigningLeaseRole(ctx context.Context, role *rbacv1.Role) error {
+	logger := log.FromContext(ctx).WithValues("role", role.GetName())
+
+	currentRole := &rbacv1.Role{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(role), currentRole); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("creating role")
+			return r.Create(ctx, role)
+		}
+		return fmt.Errorf("failed to get role %s: %w", role.GetName(), err)
+	}
+	if err := requireSameControllerOwner(currentRole, role, "Role"); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(currentRole.Rules, role.Rules) {
+		logger.Info("updating role")
+		role.ObjectMeta.ResourceVersion = currentRole.ObjectMeta.ResourceVersion
+		return r.Update(ctx, role)
+	}
+	return nil
+}
+
+func (r *Reconciler) ensureSigningLeaseRoleBinding(ctx context.Context, binding *rbacv1.RoleBinding) error {
+	logger := log.FromContext(ctx).WithValues("rolebinding", binding.GetName())
+
+	currentBinding := &rbacv1.RoleBinding{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(binding), currentBinding); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("creating role binding")
+			return r.Create(ctx, binding)
+		}
+		return fmt.Errorf("failed to get role binding %s: %w", binding.GetName(), err)
+	}
+	if err := requireSameControllerOwner(currentBinding, binding, "RoleBinding"); err != nil {
+		return err
+	}
+
+	// The role reference is immutable and never changes, so only subjects need to be kept in sync
+	if !reflect.DeepEqual(currentBinding.Subjects, binding.Subjects) {
+		logger.Info("updating role binding")
+		binding.ObjectMeta.ResourceVersion = currentBinding.ObjectMeta.ResourceVersion
+		return r.Update(ctx, binding)
+	}
+	return nil
+}
+
+func (r *Reconciler) getSigningLeaseRoleSpec(chainNode *appsv1.ChainNode) (*rbacv1.Role, error) {
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chainNode.GetSigningLeaseName(),
+			Namespace: chainNode.GetNamespace(),
+			Labels:    WithChainNodeLabels(chainNode),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{coordinationv1.GroupName},
+				Resources:     []string{"leases"},
+				ResourceNames: []string{chainNode.GetSigningLeaseName()},
+				Verbs:         []string{"get", "update"},
+			},
+		},
+	}
+	return role, controllerutil.SetControllerReference(chainNode, role, r.Scheme)
+}
+
+func (r *Reconciler) getSigningLeaseRoleBindingSpec(chainNode *appsv1.ChainNode) (*rbacv1.RoleBinding, error) {
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      chainNode.GetSigningLeaseName(),
+			Namespace: chainNode.GetNamespace(),
+			Labels:    WithChainNodeLabels(chainNode),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     chainNode.GetSigningLeaseName(),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      getPodServiceAccountName(chainNode),
+				Namespace: chainNode.GetNamespace(),
+			},
+		},
+	}
+	return binding, controllerutil.SetControllerReference(chainNode, binding, r.Scheme)
+}
+
+// getPodServiceAccountName returns the service account of the node pod. Pods holding the signing lease get a
+// dedicated service account unless one is provided, so that other pods in the namespace cannot take the lease.
+func getPodServiceAccountName(chainNode *appsv1.ChainNode) string {
+	if name := chainNode.Spec.Config.GetServiceAccountName(); name != "" || !chainNode.ShouldUseSigningLease() {
+		return name
+	}
+	return chainNode.GetName()
+}
+
+// errSigningLeaseHeld reports that a live pod still holds the signing lease. Callers should requeue instead of
+// failing, as the lease is released when that pod stops or expires when it stops renewing it.
+var errSigningLeaseHeld = stderrors.New("signing lease is held by another pod")
+
+// checkSigningLeaseReleased returns errSigningLeaseHeld while a pod holds a live signing lease, so that a new
+// validator pod is never started while a previous one might still be signing (e.g. when it is stuck terminating
+// on a partitioned node).
+func (r *Reconciler) checkSigningLeaseReleased(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	if !chainNode.ShouldUseSigningLease() {
+		return nil
+	}
+
+	lease := &coordinationv1.Lease{}
+	key := types.NamespacedName{Namespace: chainNode.GetNamespace(), Name: chainNode.GetSigningLeaseName()}
+	if err := r.reservationReader().Get(ctx, key, lease); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get signing lease %s: %w", key.Name, err)
+	}
+
+	holder := getLiveSigningLeaseHolder(lease, time.Now())
+	if holder == "" {
+		return nil
+	}
+	log.FromContext(ctx).Info("waiting for signing lease to be released", "holder", holder)
+	r.recorder.Eventf(chainNode,
+		corev1.EventTypeWarning,
+		appsv1.ReasonSigningLeaseHeld,
+		"Waiting for pod %s to release the signing lease before starting validator",
+		holder,
+	)
+	return fmt.Errorf("signing lease %s is still held by pod %s: %w", key.Name, holder, errSigningLeaseHeld)
+}
+
+// getLiveSigningLeaseHolder returns the UID of the pod holding the signing lease, or an empty string if the
+// lease was released or expired.
+func getLiveSigningLeaseHolder(lease *coordinationv1.Lease, now time.Time) string {
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder == "" || lease.Spec.RenewTime == nil {
+		return ""
+	}
+	duration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)) * time.Second
+	if now.After(lease.Spec.RenewTime.Add(duration)) {
+		return ""
+	}
+	return holder
+}
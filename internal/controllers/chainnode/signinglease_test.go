@@ -0,0 +1,160 @@
+package chainnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func signingLeaseTestReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, coordinationv1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+
+	return &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Scheme:   scheme,
+		recorder: record.NewFakeRecorder(10),
+		opts:     &controllers.ControllerRunOptions{},
+	}
+}
+
+func signingLeaseTestChainNode() *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "validator", Namespace: "default", UID: "chainnode-uid"},
+		Spec: appsv1.ChainNodeSpec{
+			Validator: &appsv1.ValidatorConfig{},
+		},
+	}
+}
+
+func TestShouldUseSigningLease(t *testing.T) {
+	chainNode := signingLeaseTestChainNode()
+	assert.True(t, chainNode.ShouldUseSigningLease())
+
+	chainNode.Spec.Validator.SigningLease = ptr.To(false)
+	assert.False(t, chainNode.ShouldUseSigningLease())
+
+	chainNode = signingLeaseTestChainNode()
+	chainNode.Spec.RemoteSignerTarget = true
+	assert.False(t, chainNode.ShouldUseSigningLease())
+
+	chainNode = signingLeaseTestChainNode()
+	chainNode.Spec.Validator = nil
+	assert.False(t, chainNode.ShouldUseSigningLease())
+}
+
+func TestGetPodServiceAccountName(t *testing.T) {
+	chainNode := signingLeaseTestChainNode()
+	assert.Equal(t, "validator", getPodServiceAccountName(chainNode))
+
+	chainNode.Spec.Config = &appsv1.Config{ServiceAccountName: ptr.To("custom")}
+	assert.Equal(t, "custom", getPodServiceAccountName(chainNode))
+
+	chainNode = signingLeaseTestChainNode()
+	chainNode.Spec.Validator = nil
+	assert.Equal(t, "", getPodServiceAccountName(chainNode))
+}
+
+func TestGetLiveSigningLeaseHolder(t *testing.T) {
+	now := time.Now()
+	lease := func(holder string, renewed time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(holder),
+				LeaseDurationSeconds: ptr.To(int32(30)),
+				RenewTime:            &metav1.MicroTime{Time: renewed},
+			},
+		}
+	}
+
+	assert.Equal(t, "", getLiveSigningLeaseHolder(&coordinationv1.Lease{}, now))
+	assert.Equal(t, "", getLiveSigningLeaseHolder(lease("", now), now))
+	assert.Equal(t, "pod-uid", getLiveSigningLeaseHolder(lease("pod-uid", now.Add(-10*time.Second)), now))
+	assert.Equal(t, "", getLiveSigningLeaseHolder(lease("pod-uid", now.Add(-31*time.Second)), now))
+}
+
+func TestEnsureSigningLease(t *testing.T) {
+	ctx := context.Background()
+	chainNode := signingLeaseTestChainNode()
+	r := signingLeaseTestReconciler(t, chainNode)
+
+	require.NoError(t, r.ensureSigningLease(ctx, chainNode))
+	// Reconciling again must not fail on existing resources
+	require.NoError(t, r.ensureSigningLease(ctx, chainNode))
+
+	key := types.NamespacedName{Namespace: "default", Name: "validator-signing"}
+	require.NoError(t, r.Get(ctx, key, &coordinationv1.Lease{}))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "validator"}, &corev1.ServiceAccount{}))
+
+	role := &rbacv1.Role{}
+	require.NoError(t, r.Get(ctx, key, role))
+	require.Len(t, role.Rules, 1)
+	assert.Equal(t, []string{"validator-signing"}, role.Rules[0].ResourceNames)
+	assert.Equal(t, []string{"get", "update"}, role.Rules[0].Verbs)
+
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, r.Get(ctx, key, binding))
+	assert.Equal(t, "validator", binding.Subjects[0].Name)
+
+	// A service account provided by the user is bound instead of creating one
+	chainNode.Spec.Config = &appsv1.Config{ServiceAccountName: ptr.To("custom")}
+	require.NoError(t, r.ensureSigningLease(ctx, chainNode))
+	require.NoError(t, r.Get(ctx, key, binding))
+	assert.Equal(t, "custom", binding.Subjects[0].Name)
+	assert.True(t, errors.IsNotFound(r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "custom"}, &corev1.ServiceAccount{})))
+}
+
+func TestCheckSigningLeaseReleased(t *testing.T) {
+	ctx := context.Background()
+	chainNode := signingLeaseTestChainNode()
+
+	// No lease yet
+	r := signingLeaseTestReconciler(t, chainNode)
+	require.NoError(t, r.checkSigningLeaseReleased(ctx, chainNode))
+
+	// Lease held by a pod that stopped renewing it
+	r = signingLeaseTestReconciler(t, chainNode, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "validator-signing"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("old-pod-uid"),
+			LeaseDurationSeconds: ptr.To(int32(30)),
+			RenewTime:            &metav1.MicroTime{Time: time.Now().Add(-time.Minute)},
+		},
+	})
+	require.NoError(t, r.checkSigningLeaseReleased(ctx, chainNode))
+
+	// Lease held by a live pod
+	r = signingLeaseTestReconciler(t, chainNode, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "validator-signing"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("old-pod-uid"),
+			LeaseDurationSeconds: ptr.To(int32(30)),
+			RenewTime:            &metav1.MicroTime{Time: time.Now()},
+		},
+	})
+	err := r.checkSigningLeaseReleased(ctx, chainNode)
+	require.ErrorIs(t, err, errSigningLeaseHeld)
+	assert.Contains(t, err.Error(), "old-pod-uid")
+	assert.Contains(t, <-r.recorder.(*record.FakeRecorder).Events, appsv1.ReasonSigningLeaseHeld)
+}
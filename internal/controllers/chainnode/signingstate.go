@@ -0,0 +1,151 @@
+package chainnode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
+)
+
+const (
+	signingStateVolumeName = "signing-state"
+	signingStateMountPath  = "/signing-state"
+)
+
+// updateSigningState records the highest signing state reported by node-utils in the status and in the
+// signing-state ConfigMap, from where it is restored before the node starts if the state on disk went back.
+func (r *Reconciler) updateSigningState(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	reported, err := nodeutils.NewClient(chainNode.GetNodeFQDN()).GetSigningState(ctx)
+	if err != nil {
+		return err
+	}
+
+	state, changed := getHighestSigningState(chainNode.Status.SigningState, reported)
+	if state == nil {
+		return nil
+	}
+
+	// The ConfigMap is written first, so that it is never behind the status
+	cm, err := r.getSigningStateConfigMapSpec(chainNode, state)
+	if err != nil {
+		return err
+	}
+	if err := r.ensureConfigMap(ctx, cm); err != nil {
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+	log.FromContext(ctx).V(1).Info("updating signing state", "height", state.Height, "round", state.Round, "step", state.Step)
+	chainNode.Status.SigningState = state
+	return r.Status().Update(ctx, chainNode)
+}
+
+// getHighestSigningState returns the highest of the recorded and reported signing states, and whether it differs
+// from the recorded one.
+func getHighestSigningState(recorded *appsv1.SigningState, reported *nodeutils.SigningState) (*appsv1.SigningState, bool) {
+	if reported == nil {
+		return recorded, false
+	}
+	if recorded != nil && !toNodeUtilsSigningState(recorded).IsBehind(*reported) {
+		return recorded, false
+	}
+	return &appsv1.SigningState{
+		Height: reported.Height,
+		Round:  reported.Round,
+		Step:   int32(reported.Step),
+	}, true
+}
+
+func toNodeUtilsSigningState(state *appsv1.SigningState) nodeutils.SigningState {
+	return nodeutils.SigningState{
+		Height: state.Height,
+		Round:  state.Round,
+		Step:   int8(state.Step),
+	}
+}
+
+func (r *Reconciler) getSigningStateConfigMapSpec(chainNode *appsv1.ChainNode, state *appsv1.SigningState) (*corev1.ConfigMap, error) {
+	b, err := json.MarshalIndent(toNodeUtilsSigningState(state), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signing state: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getSigningStateConfigMapName(chainNode),
+			Namespace: chainNode.GetNamespace(),
+			Labels:    WithChainNodeLabels(chainNode),
+		},
+		Data: map[string]string{
+			nodeutils.PrivValidatorStateFilename: string(b),
+		},
+	}
+	return cm, controllerutil.SetControllerReference(chainNode, cm, r.Scheme)
+}
+
+func getSigningStateConfigMapName(chainNode *appsv1.ChainNode) string {
+	return fmt.Sprintf("%s-signing-state", chainNode.GetName())
+}
+
+// buildCheckSigningStateInitContainer returns an init container that restores priv_validator_state.json from the
+// signing-state ConfigMap when the state on disk is behind it (e.g. after restoring data from a snapshot).
+func (r *Reconciler) buildCheckSigningStateInitContainer(securityContext *corev1.SecurityContext) corev1.Container {
+	return corev1.Container{
+		Name:                     "check-signing-state",
+		Image:                    r.opts.NodeUtilsImage,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		SecurityContext:          securityContext,
+		Args: []string{
+			"check-signing-state",
+			signingStateMountPath + "/" + nodeutils.PrivValidatorStateFilename,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "data",
+				MountPath: nodeutils.DefaultDataPath,
+			},
+			{
+				Name:      signingStateVolumeName,
+				MountPath: signingStateMountPath,
+				ReadOnly:  true,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    initContainerCpuResources,
+				corev1.ResourceMemory: initContainerMemoryResources,
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    initContainerCpuResources,
+				corev1.ResourceMemory: initContainerMemoryResources,
+			},
+		},
+	}
+}
+
+// getSigningStateVolume returns the volume with the signing-state ConfigMap. It is optional, because the
+// ConfigMap is only created once the validator signed something.
+func getSigningStateVolume(chainNode *appsv1.ChainNode) corev1.Volume {
+	return corev1.Volume{
+		Name: signingStateVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: getSigningStateConfigMapName(chainNode),
+				},
+				Optional: ptr.To(true),
+			},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package chainnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
+)
+
+func TestShouldTrackSigningState(t *testing.T) {
+	chainNode := signingLeaseTestChainNode()
+	assert.True(t, chainNode.ShouldTrackSigningState())
+
+	chainNode.Spec.RemoteSignerTarget = true
+	assert.False(t, chainNode.ShouldTrackSigningState())
+
+	chainNode = signingLeaseTestChainNode()
+	chainNode.Spec.Validator = nil
+	assert.False(t, chainNode.ShouldTrackSigningState())
+}
+
+func TestGetHighestSigningState(t *testing.T) {
+	recorded := &appsv1.SigningState{Height: 100, Round: 1, Step: 2}
+
+	state, changed := getHighestSigningState(nil, nil)
+	assert.Nil(t, state)
+	assert.False(t, changed)
+
+	state, changed = getHighestSigningState(recorded, nil)
+	assert.Equal(t, recorded, state)
+	assert.False(t, changed)
+
+	// A state reported after restoring older data must not lower the recorded one
+	state, changed = getHighestSigningState(recorded, &nodeutils.SigningState{Height: 90, Round: 0, Step: 3})
+	assert.Equal(t, recorded, state)
+	assert.False(t, changed)
+
+	state, changed = getHighestSigningState(recorded, &nodeutils.SigningState{Height: 100, Round: 1, Step: 3})
+	assert.Equal(t, &appsv1.SigningState{Height: 100, Round: 1, Step: 3}, state)
+	assert.True(t, changed)
+
+	state, changed = getHighestSigningState(nil, &nodeutils.SigningState{Height: 10})
+	assert.Equal(t, &appsv1.SigningState{Height: 10}, state)
+	assert.True(t, changed)
+}
+
+func TestSigningStateConfigMap(t *testing.T) {
+	ctx := context.Background()
+	chainNode := signingLeaseTestChainNode()
+	r := signingLeaseTestReconciler(t, chainNode)
+
+	cm, err := r.getSigningStateConfigMapSpec(chainNode, &appsv1.SigningState{Height: 1234, Round: 2, Step: 3})
+	require.NoError(t, err)
+	require.NoError(t, r.ensureConfigMap(ctx, cm))
+
+	current := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "validator-signing-state"}, current))
+	// The ConfigMap must use the same format as priv_validator_state.json
+	assert.JSONEq(t, `{"height":"1234","round":2,"step":3}`, current.Data[nodeutils.PrivValidatorStateFilename])
+}
+
+func TestSigningStateInitContainer(t *testing.T) {
+	r := signingLeaseTestReconciler(t)
+	container := r.buildCheckSigningStateInitContainer(nil)
+	assert.Equal(t, []string{"check-signing-state", "/signing-state/priv_validator_state.json"}, container.Args)
+
+	volume := getSigningStateVolume(signingLeaseTestChainNode())
+	require.NotNil(t, volume.ConfigMap)
+	assert.Equal(t, "validator-signing-state", volume.ConfigMap.Name)
+	assert.True(t, *volume.ConfigMap.Optional)
+}
@@ -191,7 +191,7 @@ func (r *Reconciler) ensureVolumeSnapshots(ctx context.Context, chainNode *appsv
 				if err = r.exportTarball(ctx, chainNode, &snapshot); err != nil {
 					return err
 				}
-				snapshot.ObjectMeta.Annotations[controllers.AnnotationExportingTarball] = strconv.FormatBool(true)
+				setTarballExportStarted(&snapshot)
 				if err = r.Update(ctx, &snapshot); err != nil {
 					return err
 				}
@@ -235,7 +235,7 @@ func (r *Reconciler) ensureVolumeSnapshots(ctx context.Context, chainNode *appsv
 					if err = r.exportTarball(ctx, chainNode, &snapshot); err != nil {
 						return err
 					}
-					snapshot.ObjectMeta.Annotations[controllers.AnnotationExportingTarball] = strconv.FormatBool(true)
+					setTarballExportStarted(&snapshot)
 					if err = r.Update(ctx, &snapshot); err != nil {
 						return err
 					}
@@ -291,7 +291,7 @@ func (r *Reconciler) ensureVolumeSnapshots(ctx context.Context, chainNode *appsv
 			if err = r.exportTarball(ctx, chainNode, &snapshot); err != nil {
 				return err
 			}
-			snapshot.ObjectMeta.Annotations[controllers.AnnotationExportingTarball] = strconv.FormatBool(true)
+			setTarballExportStarted(&snapshot)
 			if err = r.Update(ctx, &snapshot); err != nil {
 				return err
 			}
@@ -513,6 +513,21 @@ func (r *Reconciler) ensureVolumeSnapshots(ctx context.Context, chainNode *appsv
 		return nil
 	}
 
+	// Height schedules are evaluated from the height of the last snapshot. Without one, start from the
+	// current height so that heights already in the past do not trigger a snapshot. Scheduled heights the
+	// node went past without being halted, such as while syncing, are skipped the same way.
+	if schedule := chainNode.Spec.Persistence.Snapshots.HeightSchedule; schedule != nil {
+		checkpoint := getSnapshotHeightCheckpoint(chainNode)
+		next := schedule.NextHeight(checkpoint)
+		if checkpoint == 0 || (next > 0 && chainNode.Status.LatestHeight > next+1) {
+			if checkpoint > 0 {
+				logger.Info("skipping snapshot heights the node was not halted at", "from", next, "to", chainNode.Status.LatestHeight)
+			}
+			setSnapshotHeightCheckpoint(chainNode, chainNode.Status.LatestHeight)
+			return r.Update(ctx, chainNode)
+		}
+	}
+
 	// Create a snapshot if it's time for that
 	if shouldSnapshot(chainNode, nodePodReady) {
 		logger.Info("creating new pvc snapshot")
@@ -691,7 +706,12 @@ func (r *Reconciler) startNewSnapshot(ctx context.Context, chainNode *appsv1.Cha
 		"Started PVC snapshot %s", snapshot.GetName(),
 	)
 
+	dataHeight, err := strconv.ParseInt(snapshot.Annotations[controllers.AnnotationDataHeight], 10, 64)
+	if err != nil {
+		return err
+	}
 	setSnapshotInProgress(chainNode, true)
+	setSnapshotHeightCheckpoint(chainNode, dataHeight)
 	if err := r.Update(ctx, chainNode); err != nil {
 		return err
 	}
@@ -700,6 +720,7 @@ func (r *Reconciler) startNewSnapshot(ctx context.Context, chainNode *appsv1.Cha
 
 func (r *Reconciler) createSnapshot(ctx context.Context, chainNode *appsv1.ChainNode) (*snapshotv1.VolumeSnapshot, error) {
 	logger := log.FromContext(ctx)
+	haltHeight := getSnapshotHaltHeight(chainNode)
 
 	if chainNode.Spec.Persistence.Snapshots.ShouldStopNode() {
 		pod, err := r.getPodSpec(ctx, chainNode, "")
@@ -718,6 +739,14 @@ func (r *Reconciler) createSnapshot(ctx context.Context, chainNode *appsv1.Chain
 			}
 		}
 	}
+
+	// A node halted at a scheduled height holds the data at exactly that height
+	if haltHeight > 0 {
+		snapshot := getVolumeSnapshotSpec(chainNode)
+		snapshot.Annotations[controllers.AnnotationDataHeight] = strconv.FormatInt(haltHeight, 10)
+		return snapshot, r.Create(ctx, snapshot)
+	}
+
 	if err := r.updateLatestHeight(ctx, chainNode); err != nil {
 		// When this error happens, the most likely scenario is that pod is not running. So lets not throw the error and
 		// let the rest of the reconcile loop handle the missing pod.
@@ -729,6 +758,11 @@ func (r *Reconciler) createSnapshot(ctx context.Context, chainNode *appsv1.Chain
 }
 
 func shouldSnapshot(chainNode *appsv1.ChainNode, nodePodReady bool) bool {
+	// Height schedules only snapshot nodes halted at a scheduled height, which are not expected to be healthy
+	if chainNode.Spec.Persistence.Snapshots.HeightSchedule != nil {
+		return getSnapshotHaltHeight(chainNode) > 0
+	}
+
 	switch {
 	case chainNode.Spec.Persistence.Snapshots.ShouldDisableWhileSyncing() && chainNode.Status.Phase == appsv1.PhaseChainNodeSyncing:
 		return false
@@ -738,11 +772,24 @@ func shouldSnapshot(chainNode *appsv1.ChainNode, nodePodReady bool) bool {
 		return false
 	}
 
-	period, err := strfmt.ParseDuration(chainNode.Spec.Persistence.Snapshots.Frequency)
+	lastSnapshotTime := getLastSnapshotTime(chainNode)
+	snapshots := chainNode.Spec.Persistence.Snapshots
+
+	if snapshots.Schedule != nil {
+		schedule, err := snapshots.GetCronSchedule()
+		if err != nil {
+			return false
+		}
+		if lastSnapshotTime.IsZero() {
+			lastSnapshotTime = chainNode.CreationTimestamp.UTC()
+		}
+		return !schedule.Next(lastSnapshotTime).After(time.Now().UTC())
+	}
+
+	period, err := strfmt.ParseDuration(snapshots.Frequency)
 	if err != nil {
 		return false
 	}
-	lastSnapshotTime := getLastSnapshotTime(chainNode)
 	if lastSnapshotTime.IsZero() {
 		return chainNode.CreationTimestamp.UTC().Add(minimumTimeBeforeFirstSnapshot).Before(time.Now().UTC())
 	}
@@ -841,6 +888,59 @@ func setSnapshotTime(chainNode *appsv1.ChainNode, ts time.Time) {
 	chainNode.ObjectMeta.Annotations[controllers.AnnotationLastPvcSnapshot] = ts.UTC().Format(timeLayout)
 }
 
+func setSnapshotHeightCheckpoint(chainNode *appsv1.ChainNode, height int64) {
+	if chainNode.ObjectMeta.Annotations == nil {
+		chainNode.ObjectMeta.Annotations = make(map[string]string)
+	}
+	chainNode.ObjectMeta.Annotations[controllers.AnnotationSnapshotHeightCheckpoint] = strconv.FormatInt(height, 10)
+}
+
+// getScheduledSnapshotHeight returns the height at which node-utils must halt the node for the next snapshot of
+// a height schedule, or zero if there is none. Nodes are not halted while syncing when snapshots are disabled
+// while syncing.
+func getScheduledSnapshotHeight(chainNode *appsv1.ChainNode) int64 {
+	if !chainNode.SnapshotsEnabled() || chainNode.Spec.Persistence.Snapshots.HeightSchedule == nil {
+		return 0
+	}
+	snapshots := chainNode.Spec.Persistence.Snapshots
+	if snapshots.ShouldDisableWhileSyncing() && chainNode.Status.Phase == appsv1.PhaseChainNodeSyncing {
+		return 0
+	}
+	checkpoint := getSnapshotHeightCheckpoint(chainNode)
+	if checkpoint == 0 {
+		return 0
+	}
+	return snapshots.HeightSchedule.NextHeight(checkpoint)
+}
+
+// getSnapshotHaltHeight returns the scheduled height at which node-utils halted the node for a snapshot, or zero
+// if the node is not halted. node-utils stops the node on the first trace past the scheduled height and stops
+// tracking heights from then on, so a halted node reports the height right after the scheduled one.
+func getSnapshotHaltHeight(chainNode *appsv1.ChainNode) int64 {
+	if !chainNode.SnapshotsEnabled() || chainNode.Spec.Persistence.Snapshots.HeightSchedule == nil {
+		return 0
+	}
+	checkpoint := getSnapshotHeightCheckpoint(chainNode)
+	if checkpoint == 0 {
+		return 0
+	}
+	height := chainNode.Spec.Persistence.Snapshots.HeightSchedule.NextHeight(checkpoint)
+	if height > 0 && chainNode.Status.LatestHeight == height+1 {
+		return height
+	}
+	return 0
+}
+
+// getSnapshotHeightCheckpoint returns the height from which height schedules are evaluated, or 0 if not set.
+func getSnapshotHeightCheckpoint(chainNode *appsv1.ChainNode) int64 {
+	if s, ok := chainNode.ObjectMeta.Annotations[controllers.AnnotationSnapshotHeightCheckpoint]; ok {
+		if height, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return height
+		}
+	}
+	return 0
+}
+
 func getLastSnapshotTime(chainNode *appsv1.ChainNode) time.Time {
 	if s, ok := chainNode.ObjectMeta.Annotations[controllers.AnnotationLastPvcSnapshot]; ok {
 		if ts, err := time.Parse(timeLayout, s); err == nil {
@@ -988,7 +1088,7 @@ func (r *Reconciler) isTarballReady(ctx context.Context, chainNode *appsv1.Chain
 		if cleanupErr := r.cleanUpTarballExport(ctx, chainNode, snapshot); cleanupErr != nil {
 			return false, fmt.Errorf("clean up missing tarball export job: %w", cleanupErr)
 		}
-		retry, updateErr := r.recordTarballExportFailure(ctx, snapshot)
+		retry, updateErr := r.recordTarballExportFailure(ctx, chainNode, snapshot)
 		if updateErr != nil {
 			return false, updateErr
 		}
@@ -1003,7 +1103,7 @@ func (r *Reconciler) isTarballReady(ctx context.Context, chainNode *appsv1.Chain
 		if cleanupErr := r.cleanUpTarballExport(ctx, chainNode, snapshot); cleanupErr != nil {
 			return false, fmt.Errorf("clean up failed tarball export job: %w", cleanupErr)
 		}
-		retry, updateErr := r.recordTarballExportFailure(ctx, snapshot)
+		retry, updateErr := r.recordTarballExportFailure(ctx, chainNode, snapshot)
 		if updateErr != nil {
 			return false, updateErr
 		}
@@ -1047,7 +1147,7 @@ func (r *Reconciler) isTarballReadyLegacy(ctx context.Context, chainNode *appsv1
 		if cleanupErr := r.cleanUpTarballExport(ctx, chainNode, snapshot); cleanupErr != nil {
 			return false, cleanupErr
 		}
-		retry, updateErr := r.recordTarballExportFailure(ctx, snapshot)
+		retry, updateErr := r.recordTarballExportFailure(ctx, chainNode, snapshot)
 		if updateErr != nil {
 			return false, updateErr
 		}
@@ -1060,10 +1160,12 @@ func (r *Reconciler) isTarballReadyLegacy(ctx context.Context, chainNode *appsv1
 	}
 }
 
-func (r *Reconciler) recordTarballExportFailure(ctx context.Context, snapshot *snapshotv1.VolumeSnapshot) (bool, error) {
+func (r *Reconciler) recordTarballExportFailure(ctx context.Context, chainNode *appsv1.ChainNode, snapshot *snapshotv1.VolumeSnapshot) (bool, error) {
 	if snapshot.Annotations == nil {
 		snapshot.Annotations = make(map[string]string)
 	}
+	start := getTarballExportStartTime(snapshot)
+	delete(snapshot.Annotations, controllers.AnnotationTarballExportStartTime)
 	attempts, parseErr := strconv.Atoi(snapshot.Annotations[controllers.AnnotationTarballExportAttempts])
 	if parseErr != nil || attempts < 0 {
 		attempts = 0
@@ -1076,7 +1178,11 @@ func (r *Reconciler) recordTarballExportFailure(ctx context.Context, snapshot *s
 	} else {
 		snapshot.Annotations[controllers.AnnotationExportingTarball] = tarballFailed
 	}
-	return retry, r.Update(ctx, snapshot)
+	if err := r.Update(ctx, snapshot); err != nil {
+		return retry, err
+	}
+	observeTarballExport(chainNode, start, tarballExportFailed)
+	return retry, nil
 }
 
 // resetTarballExport clears the export markers so the next reconcile starts a fresh upload against the
@@ -1092,6 +1198,7 @@ func (r *Reconciler) resetTarballExport(ctx context.Context, snapshot *snapshotv
 	}
 	delete(snapshot.Annotations, controllers.AnnotationExportingTarball)
 	delete(snapshot.Annotations, controllers.AnnotationTarballExportAttempts)
+	delete(snapshot.Annotations, controllers.AnnotationTarballExportStartTime)
 	return r.Update(ctx, snapshot)
 }
 
@@ -1104,11 +1211,14 @@ func tarballFailureAction(retry bool) string {
 
 func (r *Reconciler) finishTarballExport(ctx context.Context, chainNode *appsv1.ChainNode, snapshot *snapshotv1.VolumeSnapshot) error {
 	if snapshot.Annotations[controllers.AnnotationExportingTarball] != tarballUploaded {
+		start := getTarballExportStartTime(snapshot)
 		snapshot.Annotations[controllers.AnnotationExportingTarball] = tarballUploaded
 		delete(snapshot.Annotations, controllers.AnnotationTarballExportAttempts)
+		delete(snapshot.Annotations, controllers.AnnotationTarballExportStartTime)
 		if err := r.Update(ctx, snapshot); err != nil {
 			return err
 		}
+		observeTarballExport(chainNode, start, tarballExportSucceeded)
 	}
 	if export := snapshotExportFor(chainNode, snapshot); export != nil && export.Phase != appsv1.SnapshotExportPhaseUploaded {
 		if _, err := r.setSnapshotExportPhase(ctx, chainNode, export.ID, appsv1.SnapshotExportPhaseUploaded, ""); err != nil {
@@ -1554,6 +1664,22 @@ func (r *Reconciler) cleanUpTarballDeletion(ctx context.Context, chainNode *apps
 	return r.removeSnapshotExport(ctx, chainNode, export.ID)
 }
 
+// setTarballExportStarted marks the tarball export of a snapshot as started, recording the start time of the
+// attempt so that its duration can be observed once it finishes.
+func setTarballExportStarted(snapshot *snapshotv1.VolumeSnapshot) {
+	snapshot.Annotations[controllers.AnnotationExportingTarball] = strconv.FormatBool(true)
+	snapshot.Annotations[controllers.AnnotationTarballExportStartTime] = time.Now().UTC().Format(timeLayout)
+}
+
+func getTarballExportStartTime(snapshot *snapshotv1.VolumeSnapshot) time.Time {
+	if s, ok := snapshot.Annotations[controllers.AnnotationTarballExportStartTime]; ok {
+		if ts, err := time.Parse(timeLayout, s); err == nil {
+			return ts.UTC()
+		}
+	}
+	return time.Time{}
+}
+
 func getTarballName(chainNode *appsv1.ChainNode, snapshot *snapshotv1.VolumeSnapshot) string {
 	name := fmt.Sprintf("%s-%s", chainNode.Status.ChainID, snapshot.CreationTimestamp.UTC().Format(timeLayout))
 	if chainNode.Spec.Persistence.Snapshots.ExportTarball.Suffix != nil {
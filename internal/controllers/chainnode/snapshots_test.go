@@ -273,9 +273,10 @@ func TestRecordTarballExportFailureStopsAtRetryLimit(t *testing.T) {
 	}
 	client := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(snapshot).Build()
 	reconciler := &Reconciler{Client: client}
+	chainNode := &appsv1.ChainNode{ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"}}
 
 	for attempt := 1; attempt <= tarballExportMaxAttempts; attempt++ {
-		retry, err := reconciler.recordTarballExportFailure(context.Background(), snapshot)
+		retry, err := reconciler.recordTarballExportFailure(context.Background(), chainNode, snapshot)
 		require.NoError(t, err)
 		assert.Equal(t, attempt < tarballExportMaxAttempts, retry)
 
@@ -3162,6 +3163,89 @@ func TestGetLastSnapshotTime(t *testing.T) {
 	}
 }
 
+func TestShouldSnapshotWithSchedules(t *testing.T) {
+	newChainNode := func(snapshots *appsv1.VolumeSnapshotsConfig, created time.Time) *appsv1.ChainNode {
+		return &appsv1.ChainNode{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Spec:       appsv1.ChainNodeSpec{Persistence: &appsv1.Persistence{Snapshots: snapshots}},
+			Status:     appsv1.ChainNodeStatus{Phase: appsv1.PhaseChainNodeRunning, LatestHeight: 1050},
+		}
+	}
+
+	t.Run("cron schedule", func(t *testing.T) {
+		snapshots := &appsv1.VolumeSnapshotsConfig{Schedule: ptr.To("0 * * * *")}
+
+		chainNode := newChainNode(snapshots, time.Now().Add(-2*time.Hour))
+		assert.True(t, shouldSnapshot(chainNode, true))
+
+		setSnapshotTime(chainNode, time.Now().Add(-2*time.Hour))
+		assert.True(t, shouldSnapshot(chainNode, true))
+
+		chainNode.Spec.Persistence.Snapshots.Schedule = ptr.To("0 0 1 1 *")
+		setSnapshotTime(chainNode, time.Now())
+		assert.False(t, shouldSnapshot(chainNode, true))
+	})
+
+	t.Run("height schedule", func(t *testing.T) {
+		snapshots := &appsv1.VolumeSnapshotsConfig{
+			HeightSchedule: &appsv1.SnapshotHeightSchedule{Interval: ptr.To[int64](100)},
+		}
+		chainNode := newChainNode(snapshots, time.Now())
+
+		// No checkpoint yet
+		assert.False(t, shouldSnapshot(chainNode, true))
+
+		// Next height is 1100, node is not halted yet
+		setSnapshotHeightCheckpoint(chainNode, 1010)
+		assert.False(t, shouldSnapshot(chainNode, true))
+
+		// Node went past 1000 without being halted there
+		setSnapshotHeightCheckpoint(chainNode, 990)
+		assert.False(t, shouldSnapshot(chainNode, true))
+
+		// Node halted right after committing 1000, even if not ready
+		chainNode.Status.LatestHeight = 1001
+		assert.True(t, shouldSnapshot(chainNode, false))
+	})
+}
+
+func TestSnapshotHaltHeight(t *testing.T) {
+	chainNode := &appsv1.ChainNode{
+		Spec: appsv1.ChainNodeSpec{Persistence: &appsv1.Persistence{
+			Snapshots: &appsv1.VolumeSnapshotsConfig{
+				HeightSchedule: &appsv1.SnapshotHeightSchedule{Interval: ptr.To[int64](100), Heights: []int64{1050}},
+			},
+		}},
+		Status: appsv1.ChainNodeStatus{Phase: appsv1.PhaseChainNodeRunning, LatestHeight: 1020},
+	}
+
+	// No checkpoint yet
+	assert.Zero(t, getScheduledSnapshotHeight(chainNode))
+	assert.Zero(t, getSnapshotHaltHeight(chainNode))
+
+	setSnapshotHeightCheckpoint(chainNode, 1010)
+	assert.Equal(t, int64(1050), getScheduledSnapshotHeight(chainNode))
+	assert.Zero(t, getSnapshotHaltHeight(chainNode))
+
+	chainNode.Status.LatestHeight = 1051
+	assert.Equal(t, int64(1050), getSnapshotHaltHeight(chainNode))
+
+	// Nodes are not halted while syncing
+	chainNode.Status.Phase = appsv1.PhaseChainNodeSyncing
+	assert.Zero(t, getScheduledSnapshotHeight(chainNode))
+
+	chainNode.Spec.Persistence.Snapshots.DisableWhileSyncing = ptr.To(false)
+	assert.Equal(t, int64(1050), getScheduledSnapshotHeight(chainNode))
+}
+
+func TestSnapshotHeightCheckpoint(t *testing.T) {
+	chainNode := &appsv1.ChainNode{}
+	assert.Zero(t, getSnapshotHeightCheckpoint(chainNode))
+
+	setSnapshotHeightCheckpoint(chainNode, 1234)
+	assert.Equal(t, int64(1234), getSnapshotHeightCheckpoint(chainNode))
+}
+
 func TestSetSnapshotTime(t *testing.T) {
 	now := time.Now().UTC()
 	chainNode := &appsv1.ChainNode{
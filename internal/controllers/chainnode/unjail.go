@@ -0,0 +1,186 @@
+package chainnode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+// autoUnjail submits an unjail transaction for a validator jailed for downtime once its jail period
+// is over and the node has been synced for the configured number of blocks. Tombstoned validators
+// are never unjailed.
+func (r *Reconciler) autoUnjail(ctx context.Context, app *chainutils.App, chainNode *appsv1.ChainNode) error {
+	logger := log.FromContext(ctx)
+	cfg := chainNode.Spec.Validator.AutoUnjail
+
+	if !chainNode.Status.Jailed || chainNode.Status.Phase != appsv1.PhaseChainNodeRunning {
+		return r.setAutoUnjailSyncedHeight(ctx, chainNode, 0)
+	}
+
+	info := chainNode.Status.SigningInfo
+	if info == nil {
+		logger.V(1).Info("waiting for signing info before unjailing validator")
+		return nil
+	}
+	if info.Tombstoned {
+		logger.V(1).Info("validator is tombstoned and will not be unjailed")
+		return nil
+	}
+
+	now := time.Now()
+	if info.JailedUntil != nil && now.Before(info.JailedUntil.Time) {
+		logger.V(1).Info("waiting for jail period to end", "jailedUntil", info.JailedUntil.Time)
+		return nil
+	}
+
+	syncedHeight := getAutoUnjailSyncedHeight(chainNode)
+	if syncedHeight == 0 {
+		return r.setAutoUnjailSyncedHeight(ctx, chainNode, chainNode.Status.LatestHeight)
+	}
+	if syncedBlocks := chainNode.Status.LatestHeight - syncedHeight; syncedBlocks < cfg.GetMinSyncedBlocks() {
+		logger.V(1).Info("waiting for node to be synced before unjailing validator",
+			"syncedBlocks", syncedBlocks,
+			"minSyncedBlocks", cfg.GetMinSyncedBlocks(),
+		)
+		return nil
+	}
+
+	history := recentAutoUnjails(getAutoUnjailHistory(chainNode), now.Add(-cfg.GetWindow()))
+	if len(history) >= cfg.GetMaxUnjails() {
+		logger.Info("auto-unjail limit reached",
+			"recentUnjails", len(history),
+			"maxUnjails", cfg.GetMaxUnjails(),
+			"window", cfg.GetWindow(),
+		)
+		r.recorder.Eventf(chainNode, corev1.EventTypeWarning, appsv1.ReasonAutoUnjailLimitReached,
+			"Auto-unjail limit reached (%d/%d in %s). Validator must be unjailed manually.",
+			len(history), cfg.GetMaxUnjails(), cfg.GetWindow())
+		return nil
+	}
+
+	if r.validatorAccountBusy(chainNode) {
+		logger.V(1).Info("waiting for previous validator tx before submitting unjail tx")
+		return nil
+	}
+
+	account, err := r.getValidatorAccount(ctx, chainNode)
+	if err != nil {
+		return err
+	}
+
+	// Record the attempt before submitting, so that failed attempts also count towards the limit.
+	if err := r.setAutoUnjailHistory(ctx, chainNode, append(history, now)); err != nil {
+		return err
+	}
+
+	logger.Info("submitting unjail tx")
+	hash, err := app.Unjail(ctx, account,
+		&chainutils.Params{
+			ChainID:   chainNode.Status.ChainID,
+			GasPrices: cfg.GasPrices,
+		},
+		fmt.Sprintf("tcp://%s:%d", chainNode.GetNodeFQDN(), chainutils.RpcPort),
+	)
+	if err != nil {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonUnjailFailure,
+			"failed to unjail validator: %s", err.Error())
+		return err
+	}
+	r.trackValidatorTx(chainNode, validatorTx{hash: hash, kind: validatorTxUnjail})
+
+	r.recorder.Eventf(chainNode,
+		corev1.EventTypeNormal,
+		appsv1.ReasonUnjailSuccess,
+		"successfully submitted unjail tx %s (%d/%d in %s)", hash, len(history)+1, cfg.GetMaxUnjails(), cfg.GetWindow())
+	return r.setAutoUnjailSyncedHeight(ctx, chainNode, 0)
+}
+
+// recentAutoUnjails returns the entries of history that happened after cutoff.
+func recentAutoUnjails(history []time.Time, cutoff time.Time) []time.Time {
+	var recent []time.Time
+	for _, ts := range history {
+		if ts.After(cutoff) {
+			recent = append(recent, ts)
+		}
+	}
+	return recent
+}
+
+// getAutoUnjailHistory retrieves the automatic unjail history from annotations
+func getAutoUnjailHistory(chainNode *appsv1.ChainNode) []time.Time {
+	data, ok := chainNode.Annotations[controllers.AnnotationAutoUnjailHistory]
+	if !ok || data == "" {
+		return nil
+	}
+
+	var timestamps []string
+	if err := json.Unmarshal([]byte(data), &timestamps); err != nil {
+		return nil
+	}
+
+	var history []time.Time
+	for _, ts := range timestamps {
+		if t, err := time.Parse(timeLayout, ts); err == nil {
+			history = append(history, t.UTC())
+		}
+	}
+	return history
+}
+
+// setAutoUnjailHistory stores the automatic unjail history in annotations
+func (r *Reconciler) setAutoUnjailHistory(ctx context.Context, chainNode *appsv1.ChainNode, history []time.Time) error {
+	timestamps := make([]string, len(history))
+	for i, ts := range history {
+		timestamps[i] = ts.UTC().Format(timeLayout)
+	}
+
+	data, err := json.Marshal(timestamps)
+	if err != nil {
+		return err
+	}
+
+	if chainNode.Annotations == nil {
+		chainNode.Annotations = map[string]string{}
+	}
+	chainNode.Annotations[controllers.AnnotationAutoUnjailHistory] = string(data)
+	return r.Update(ctx, chainNode)
+}
+
+// getAutoUnjailSyncedHeight returns the height at which the jailed node was first seen synced after
+// its jail period, or 0 if not recorded.
+func getAutoUnjailSyncedHeight(chainNode *appsv1.ChainNode) int64 {
+	height, err := strconv.ParseInt(chainNode.Annotations[controllers.AnnotationAutoUnjailSyncedHeight], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// setAutoUnjailSyncedHeight records the height at which the jailed node was first seen synced.
+// Setting it to 0 removes the annotation.
+func (r *Reconciler) setAutoUnjailSyncedHeight(ctx context.Context, chainNode *appsv1.ChainNode, height int64) error {
+	if getAutoUnjailSyncedHeight(chainNode) == height {
+		return nil
+	}
+
+	if height == 0 {
+		delete(chainNode.Annotations, controllers.AnnotationAutoUnjailSyncedHeight)
+	} else {
+		if chainNode.Annotations == nil {
+			chainNode.Annotations = map[string]string{}
+		}
+		chainNode.Annotations[controllers.AnnotationAutoUnjailSyncedHeight] = strconv.FormatInt(height, 10)
+	}
+	return r.Update(ctx, chainNode)
+}
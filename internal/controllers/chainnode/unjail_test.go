@@ -0,0 +1,115 @@
+package chainnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func autoUnjailTestReconciler(t *testing.T, chainNode *appsv1.ChainNode) (*Reconciler, *record.FakeRecorder) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	recorder := record.NewFakeRecorder(10)
+	return &Reconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(chainNode).Build(),
+		Scheme:   scheme,
+		recorder: recorder,
+	}, recorder
+}
+
+func jailedChainNode() *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "validator", Namespace: "default"},
+		Spec: appsv1.ChainNodeSpec{
+			Validator: &appsv1.ValidatorConfig{
+				AutoUnjail: &appsv1.AutoUnjailConfig{GasPrices: "0.025stake"},
+			},
+		},
+		Status: appsv1.ChainNodeStatus{
+			Phase:        appsv1.PhaseChainNodeRunning,
+			Jailed:       true,
+			LatestHeight: 1000,
+			SigningInfo: &appsv1.ValidatorSigningInfo{
+				JailedUntil: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			},
+		},
+	}
+}
+
+func TestAutoUnjailNeverTouchesTombstonedValidators(t *testing.T) {
+	chainNode := jailedChainNode()
+	chainNode.Status.SigningInfo.Tombstoned = true
+	r, recorder := autoUnjailTestReconciler(t, chainNode)
+
+	require.NoError(t, r.autoUnjail(context.Background(), nil, chainNode))
+	assert.NotContains(t, chainNode.Annotations, controllers.AnnotationAutoUnjailSyncedHeight)
+	assert.NotContains(t, chainNode.Annotations, controllers.AnnotationAutoUnjailHistory)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestAutoUnjailWaitsForJailPeriod(t *testing.T) {
+	chainNode := jailedChainNode()
+	chainNode.Status.SigningInfo.JailedUntil = &metav1.Time{Time: time.Now().Add(time.Hour)}
+	r, recorder := autoUnjailTestReconciler(t, chainNode)
+
+	require.NoError(t, r.autoUnjail(context.Background(), nil, chainNode))
+	assert.NotContains(t, chainNode.Annotations, controllers.AnnotationAutoUnjailSyncedHeight)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestAutoUnjailWaitsForSyncedBlocks(t *testing.T) {
+	chainNode := jailedChainNode()
+	r, recorder := autoUnjailTestReconciler(t, chainNode)
+
+	// First pass records the height at which the node was seen synced.
+	require.NoError(t, r.autoUnjail(context.Background(), nil, chainNode))
+	assert.Equal(t, int64(1000), getAutoUnjailSyncedHeight(chainNode))
+
+	// Not enough blocks yet.
+	chainNode.Status.LatestHeight = 1050
+	require.NoError(t, r.autoUnjail(context.Background(), nil, chainNode))
+	assert.Equal(t, int64(1000), getAutoUnjailSyncedHeight(chainNode))
+	assert.Empty(t, recorder.Events)
+
+	// Leaving the running phase resets the count.
+	chainNode.Status.Phase = appsv1.PhaseChainNodeSyncing
+	require.NoError(t, r.autoUnjail(context.Background(), nil, chainNode))
+	assert.Zero(t, getAutoUnjailSyncedHeight(chainNode))
+}
+
+func TestAutoUnjailLimitReached(t *testing.T) {
+	chainNode := jailedChainNode()
+	chainNode.Status.LatestHeight = 2000
+	chainNode.Annotations = map[string]string{controllers.AnnotationAutoUnjailSyncedHeight: "1000"}
+	r, recorder := autoUnjailTestReconciler(t, chainNode)
+
+	now := time.Now()
+	require.NoError(t, r.setAutoUnjailHistory(context.Background(), chainNode, []time.Time{
+		now.Add(-48 * time.Hour),
+		now.Add(-3 * time.Hour),
+		now.Add(-2 * time.Hour),
+		now.Add(-time.Hour),
+	}))
+
+	require.NoError(t, r.autoUnjail(context.Background(), nil, chainNode))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonAutoUnjailLimitReached)
+}
+
+func TestRecentAutoUnjails(t *testing.T) {
+	now := time.Now()
+	history := []time.Time{now.Add(-2 * time.Hour), now.Add(-30 * time.Minute), now}
+	assert.Equal(t, history[1:], recentAutoUnjails(history, now.Add(-time.Hour)))
+	assert.Empty(t, recentAutoUnjails(nil, now))
+}
@@ -0,0 +1,228 @@
+package chainnode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+var (
+	// upgradeInfoHTTPClient is used to download plan info and chain-registry files.
+	upgradeInfoHTTPClient = &http.Client{Timeout: upgradeInfoFetchTimeout}
+)
+
+// upgradeImageResolver resolves the container image of a governance upgrade plan. Resolvers return an empty
+// image and no error when they have no image for the plan, so that the next resolver can be tried.
+type upgradeImageResolver interface {
+	resolve(ctx context.Context, plan *upgradetypes.Plan) (string, error)
+}
+
+// getUpgradeImageResolvers returns the resolvers configured for the node, in the order they should be tried.
+func (r *Reconciler) getUpgradeImageResolvers(chainNode *appsv1.ChainNode) ([]upgradeImageResolver, error) {
+	cfg := chainNode.Spec.App.UpgradeImageResolver
+	resolvers := []upgradeImageResolver{
+		&planInfoResolver{fetch: cfg.ShouldFetchPlanInfo()},
+	}
+	if cfg == nil {
+		return resolvers, nil
+	}
+
+	if cfg.ChainRegistry != nil {
+		resolvers = append(resolvers, &chainRegistryResolver{
+			image:  chainNode.Spec.App.Image,
+			source: cfg.ChainRegistry,
+			client: r.Client,
+			ns:     chainNode.GetNamespace(),
+		})
+	}
+
+	tmpl, err := cfg.GetTemplate()
+	if err != nil {
+		return nil, fmt.Errorf("parsing upgrade image template: %w", err)
+	}
+	if tmpl != nil {
+		resolvers = append(resolvers, &templateResolver{image: chainNode.Spec.App.Image, tmpl: tmpl})
+	}
+	return resolvers, nil
+}
+
+// resolveUpgradeImage returns the image of the first resolver that finds one. Resolver errors are collected
+// and only returned when no resolver found an image.
+func resolveUpgradeImage(ctx context.Context, resolvers []upgradeImageResolver, plan *upgradetypes.Plan) (string, error) {
+	var errs []error
+	for _, resolver := range resolvers {
+		image, err := resolver.resolve(ctx, plan)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if image != "" {
+			return image, nil
+		}
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("resolving image for upgrade %q: %v", plan.Name, errs)
+	}
+	return "", nil
+}
+
+// planInfoResolver looks for the image in the `binaries.docker` key of the plan info. When fetch is enabled
+// and the plan info is a URL, the document it points to is used instead.
+type planInfoResolver struct {
+	fetch bool
+}
+
+func (p *planInfoResolver) resolve(ctx context.Context, plan *upgradetypes.Plan) (string, error) {
+	info := []byte(plan.Info)
+	if p.fetch && isURL(plan.Info) {
+		body, err := fetchURL(ctx, strings.TrimSpace(plan.Info))
+		if err != nil {
+			return "", fmt.Errorf("fetching plan info: %w", err)
+		}
+		info = body
+	}
+
+	out := struct {
+		Binaries struct {
+			Docker string `json:"docker"`
+		} `json:"binaries"`
+	}{}
+	if err := json.Unmarshal(info, &out); err != nil {
+		// Plan info is free text, so not being JSON is not an error
+		return "", nil
+	}
+	return out.Binaries.Docker, nil
+}
+
+// chainRegistryResolver looks up the upgrade name in chain-registry versions and uses the matching tag with
+// the app image.
+type chainRegistryResolver struct {
+	image  string
+	source *appsv1.ChainRegistryVersions
+	client client.Client
+	ns     string
+}
+
+// chainRegistryVersion is an entry of chain-registry `versions`.
+type chainRegistryVersion struct {
+	Name               string `json:"name"`
+	Tag                string `json:"tag"`
+	RecommendedVersion string `json:"recommended_version"`
+}
+
+func (c *chainRegistryResolver) resolve(ctx context.Context, plan *upgradetypes.Plan) (string, error) {
+	data, err := c.load(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading chain-registry versions: %w", err)
+	}
+
+	versions, err := parseChainRegistryVersions(data)
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range versions {
+		if v.Name != plan.Name {
+			continue
+		}
+		tag := v.Tag
+		if tag == "" {
+			tag = v.RecommendedVersion
+		}
+		if tag == "" {
+			return "", nil
+		}
+		return fmt.Sprintf("%s:%s", c.image, tag), nil
+	}
+	return "", nil
+}
+
+func (c *chainRegistryResolver) load(ctx context.Context) ([]byte, error) {
+	if c.source.Url != nil {
+		return fetchURL(ctx, *c.source.Url)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: c.ns, Name: c.source.ConfigMap.Name}, cm); err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data[c.source.ConfigMap.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in configmap %s", c.source.ConfigMap.Key, c.source.ConfigMap.Name)
+	}
+	return []byte(data), nil
+}
+
+// parseChainRegistryVersions parses versions from either a chain-registry `chain.json` or `versions.json`.
+func parseChainRegistryVersions(data []byte) ([]chainRegistryVersion, error) {
+	out := struct {
+		Versions []chainRegistryVersion `json:"versions"`
+		Codebase struct {
+			Versions []chainRegistryVersion `json:"versions"`
+		} `json:"codebase"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("parsing chain-registry versions: %w", err)
+	}
+	return append(out.Versions, out.Codebase.Versions...), nil
+}
+
+// templateResolver builds the image from a template.
+type templateResolver struct {
+	image string
+	tmpl  *template.Template
+}
+
+func (t *templateResolver) resolve(_ context.Context, plan *upgradetypes.Plan) (string, error) {
+	data := struct {
+		Name   string
+		Height int64
+		Image  string
+	}{
+		Name:   plan.Name,
+		Height: plan.Height,
+		Image:  t.image,
+	}
+
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("executing upgrade image template: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(strings.TrimSpace(s))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func fetchURL(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := upgradeInfoHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", res.StatusCode, rawURL)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, maxUpgradeInfoSize))
+}
@@ -0,0 +1,163 @@
+package chainnode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
+)
+
+const testChainRegistryVersions = `{
+  "chain_name": "test",
+  "versions": [
+    {"name": "v2", "tag": "v2.0.0", "height": 1000},
+    {"name": "v3", "recommended_version": "v3.1.0", "height": 2000}
+  ]
+}`
+
+func upgradeImageTestChainNode(resolver *appsv1.UpgradeImageResolver) *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"},
+		Spec: appsv1.ChainNodeSpec{
+			App: appsv1.AppSpec{Image: "ghcr.io/org/app", App: "appd", UpgradeImageResolver: resolver},
+		},
+	}
+}
+
+func resolveTestUpgradeImage(t *testing.T, r *Reconciler, chainNode *appsv1.ChainNode, plan *upgradetypes.Plan) (string, error) {
+	t.Helper()
+	resolvers, err := r.getUpgradeImageResolvers(chainNode)
+	require.NoError(t, err)
+	return resolveUpgradeImage(context.Background(), resolvers, plan)
+}
+
+func TestResolveUpgradeImageFromPlanInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"binaries":{"docker":"ghcr.io/org/app:v2.0.0-fetched"}}`))
+	}))
+	defer server.Close()
+
+	chainNode := upgradeImageTestChainNode(nil)
+	r, _ := upgradeRollbackTestReconciler(t, chainNode)
+
+	tests := []struct {
+		name     string
+		info     string
+		expected string
+	}{
+		{name: "inline", info: `{"binaries":{"docker":"ghcr.io/org/app:v2.0.0"}}`, expected: "ghcr.io/org/app:v2.0.0"},
+		{name: "url", info: server.URL + "/upgrade-info.json", expected: "ghcr.io/org/app:v2.0.0-fetched"},
+		{name: "free text", info: "upgrade to v2", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, err := resolveTestUpgradeImage(t, r, chainNode, &upgradetypes.Plan{Name: "v2", Height: 1000, Info: tt.info})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, image)
+		})
+	}
+}
+
+func TestResolveUpgradeImageFromChainRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"codebase":{"versions":[{"name":"v2","tag":"v2.0.0"}]}}`))
+	}))
+	defer server.Close()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+		Data:       map[string]string{"versions.json": testChainRegistryVersions},
+	}
+
+	fromConfigMap := upgradeImageTestChainNode(&appsv1.UpgradeImageResolver{
+		ChainRegistry: &appsv1.ChainRegistryVersions{ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "registry"}, Key: "versions.json",
+		}},
+	})
+	r, _ := upgradeRollbackTestReconciler(t, fromConfigMap, configMap)
+
+	image, err := resolveTestUpgradeImage(t, r, fromConfigMap, &upgradetypes.Plan{Name: "v2", Height: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/app:v2.0.0", image)
+
+	image, err = resolveTestUpgradeImage(t, r, fromConfigMap, &upgradetypes.Plan{Name: "v3", Height: 2000})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/app:v3.1.0", image)
+
+	image, err = resolveTestUpgradeImage(t, r, fromConfigMap, &upgradetypes.Plan{Name: "v4", Height: 3000})
+	require.NoError(t, err)
+	assert.Empty(t, image)
+
+	fromURL := upgradeImageTestChainNode(&appsv1.UpgradeImageResolver{
+		ChainRegistry: &appsv1.ChainRegistryVersions{Url: ptr.To(server.URL + "/chain.json")},
+	})
+	image, err = resolveTestUpgradeImage(t, r, fromURL, &upgradetypes.Plan{Name: "v2", Height: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/app:v2.0.0", image)
+}
+
+func TestResolveUpgradeImageFallsBackToTemplate(t *testing.T) {
+	chainNode := upgradeImageTestChainNode(&appsv1.UpgradeImageResolver{
+		ChainRegistry: &appsv1.ChainRegistryVersions{ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "versions.json",
+		}},
+		Template: ptr.To("{{.Image}}:{{.Name}}"),
+	})
+	r, _ := upgradeRollbackTestReconciler(t, chainNode)
+
+	// The missing configmap does not prevent the template from being used
+	image, err := resolveTestUpgradeImage(t, r, chainNode, &upgradetypes.Plan{Name: "v2", Height: 1000, Info: "upgrade to v2"})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/app:v2", image)
+
+	// Plan info takes precedence
+	image, err = resolveTestUpgradeImage(t, r, chainNode, &upgradetypes.Plan{Name: "v2", Height: 1000, Info: `{"binaries":{"docker":"other:v2"}}`})
+	require.NoError(t, err)
+	assert.Equal(t, "other:v2", image)
+}
+
+func TestResolveUpgradeImageReportsErrorsWhenNotFound(t *testing.T) {
+	chainNode := upgradeImageTestChainNode(&appsv1.UpgradeImageResolver{
+		ChainRegistry: &appsv1.ChainRegistryVersions{ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "versions.json",
+		}},
+	})
+	r, _ := upgradeRollbackTestReconciler(t, chainNode)
+
+	image, err := resolveTestUpgradeImage(t, r, chainNode, &upgradetypes.Plan{Name: "v2", Height: 1000})
+	assert.Error(t, err)
+	assert.Empty(t, image)
+}
+
+func TestAddRequiredUpgradeSchedulesUnknownUpgrade(t *testing.T) {
+	chainNode := upgradeImageTestChainNode(&appsv1.UpgradeImageResolver{Template: ptr.To("{{.Image}}:{{.Name}}")})
+	chainNode.Status.LatestHeight = 1000
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"}}
+	r, _ := upgradeRollbackTestReconciler(t, chainNode, configMap)
+
+	required := &nodeutils.RequiredUpgrade{MustUpgrade: true, Name: "v2", Height: 1000}
+	require.NoError(t, r.addRequiredUpgrade(context.Background(), chainNode, required))
+
+	require.Len(t, chainNode.Status.Upgrades, 1)
+	upgrade := chainNode.Status.Upgrades[0]
+	assert.Equal(t, "v2", upgrade.Name)
+	assert.Equal(t, "ghcr.io/org/app:v2", upgrade.Image)
+	assert.Equal(t, appsv1.UpgradeScheduled, upgrade.Status)
+	assert.Equal(t, appsv1.OnChainUpgrade, upgrade.Source)
+	assert.NotNil(t, r.getUpgrade(chainNode, 1000))
+
+	// Known upgrades are left untouched
+	chainNode.Spec.App.UpgradeImageResolver.Template = ptr.To("other:{{.Name}}")
+	require.NoError(t, r.addRequiredUpgrade(context.Background(), chainNode, required))
+	assert.Equal(t, "ghcr.io/org/app:v2", chainNode.Status.Upgrades[0].Image)
+}
@@ -0,0 +1,224 @@
+package chainnode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/k8s"
+)
+
+// ensureUpgradeImagePrePull pulls the image of the next scheduled upgrade onto the Kubernetes node running the
+// node pod, so that the image is already available when the node halts at the upgrade height. Images are pulled
+// one at a time using a short-lived pod pinned to that Kubernetes node. When pre-pulling is disabled, any pod left
+// from a previous pull is removed.
+func (r *Reconciler) ensureUpgradeImagePrePull(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	logger := log.FromContext(ctx)
+
+	nodePod, err := r.getChainNodePod(ctx, chainNode)
+	if err != nil {
+		return err
+	}
+	nodeName := ""
+	if nodePod != nil {
+		nodeName = nodePod.Spec.NodeName
+	}
+
+	prePullPod := &corev1.Pod{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: chainNode.GetNamespace(), Name: getPrePullPodName(chainNode)}, prePullPod)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	var upgrade *appsv1.Upgrade
+	if chainNode.Spec.App.ShouldPrePullUpgradeImages() {
+		upgrade = nextUpgradeToPrePull(chainNode, nodeName)
+	}
+	if upgrade == nil || nodeName == "" {
+		if apiMeta.RemoveStatusCondition(&chainNode.Status.Conditions, appsv1.ConditionUpgradeImagePullFailed) {
+			if err := r.Status().Update(ctx, chainNode); err != nil {
+				return err
+			}
+		}
+		if exists {
+			return r.deletePrePullPod(ctx, prePullPod)
+		}
+		return nil
+	}
+
+	if !exists {
+		spec, err := r.getPrePullPodSpec(chainNode, nodePod, upgrade.Image)
+		if err != nil {
+			return err
+		}
+		logger.Info("pre-pulling upgrade image", "image", upgrade.Image, "height", upgrade.Height, "node", nodeName)
+		return r.Create(ctx, spec)
+	}
+
+	// The node pod moved or the next upgrade changed, so this pull is no longer useful
+	if prePullPod.Spec.NodeName != nodeName || prePullPod.Spec.Containers[0].Image != upgrade.Image {
+		return r.deletePrePullPod(ctx, prePullPod)
+	}
+
+	pulled, pullErr := getPrePullStatus(prePullPod)
+	if pullErr != "" {
+		if r.updatePrePullFailedCondition(chainNode, upgrade, pullErr) {
+			if err := r.Status().Update(ctx, chainNode); err != nil {
+				return err
+			}
+		}
+		// Pods rejected by the kubelet never start, so they are recreated to retry the pull. Pods failing to pull
+		// the image are retried by the kubelet itself.
+		if prePullPod.Status.Phase == corev1.PodFailed {
+			return r.deletePrePullPod(ctx, prePullPod)
+		}
+		return nil
+	}
+	if !pulled {
+		return nil
+	}
+
+	logger.Info("pre-pulled upgrade image", "image", upgrade.Image, "height", upgrade.Height, "node", nodeName)
+	r.recorder.Eventf(chainNode,
+		corev1.EventTypeNormal,
+		appsv1.ReasonUpgradeImagePulled,
+		"Pre-pulled image %s for upgrade at height %d on node %s", upgrade.Image, upgrade.Height, nodeName,
+	)
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Height == upgrade.Height {
+			chainNode.Status.Upgrades[i].PrePulledOn = nodeName
+		}
+	}
+	apiMeta.RemoveStatusCondition(&chainNode.Status.Conditions, appsv1.ConditionUpgradeImagePullFailed)
+	if err := r.Status().Update(ctx, chainNode); err != nil {
+		return err
+	}
+	return r.deletePrePullPod(ctx, prePullPod)
+}
+
+// updatePrePullFailedCondition sets the UpgradeImagePullFailed condition for the image of the given upgrade and
+// emits a warning event the first time the image fails to be pulled. It returns true if the condition changed.
+func (r *Reconciler) updatePrePullFailedCondition(chainNode *appsv1.ChainNode, upgrade *appsv1.Upgrade, reason string) bool {
+	prefix := fmt.Sprintf("image %s ", upgrade.Image)
+	current := apiMeta.FindStatusCondition(chainNode.Status.Conditions, appsv1.ConditionUpgradeImagePullFailed)
+	if current == nil || current.Status != metav1.ConditionTrue || !strings.HasPrefix(current.Message, prefix) {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonUpgradeImagePullFailed,
+			"Failed to pre-pull image %s for upgrade at height %d: %s", upgrade.Image, upgrade.Height, reason,
+		)
+	}
+
+	return apiMeta.SetStatusCondition(&chainNode.Status.Conditions, metav1.Condition{
+		Type:               appsv1.ConditionUpgradeImagePullFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             appsv1.ReasonUpgradeImagePullFailed,
+		Message:            fmt.Sprintf("%sfor upgrade at height %d could not be pulled: %s", prefix, upgrade.Height, reason),
+		ObservedGeneration: chainNode.Generation,
+	})
+}
+
+func (r *Reconciler) deletePrePullPod(ctx context.Context, pod *corev1.Pod) error {
+	if !pod.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	uid := pod.GetUID()
+	if err := r.Delete(ctx, pod, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *Reconciler) getPrePullPodSpec(chainNode *appsv1.ChainNode, nodePod *corev1.Pod, image string) (*corev1.Pod, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPrePullPodName(chainNode),
+			Namespace: chainNode.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			NodeName:      nodePod.Spec.NodeName,
+			Tolerations:   nodePod.Spec.Tolerations,
+			Containers: []corev1.Container{
+				{
+					Name:            imagePrePullContainerName,
+					Image:           image,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					// The container only needs to start for the image to be pulled, so any quick command will do.
+					Command:         []string{chainNode.Spec.App.App, "version"},
+					SecurityContext: k8s.RestrictedSecurityContext(),
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    lightContainerCpuResources,
+							corev1.ResourceMemory: lightContainerMemoryResources,
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    lightContainerCpuResources,
+							corev1.ResourceMemory: lightContainerMemoryResources,
+						},
+					},
+				},
+			},
+		},
+	}
+	if chainNode.Spec.Config != nil {
+		pod.Spec.ImagePullSecrets = chainNode.Spec.Config.ImagePullSecrets
+	}
+	if err := controllerutil.SetControllerReference(chainNode, pod, r.Scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference: %w", err)
+	}
+	return pod, nil
+}
+
+func getPrePullPodName(chainNode *appsv1.ChainNode) string {
+	return fmt.Sprintf("%s-image-prepull", chainNode.GetName())
+}
+
+// nextUpgradeToPrePull returns the lowest scheduled upgrade whose image was not yet pulled onto the given node.
+func nextUpgradeToPrePull(chainNode *appsv1.ChainNode, nodeName string) *appsv1.Upgrade {
+	var next *appsv1.Upgrade
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Status != appsv1.UpgradeScheduled || u.Image == "" || u.PrePulledOn == nodeName {
+			continue
+		}
+		if next == nil || u.Height < next.Height {
+			next = &chainNode.Status.Upgrades[i]
+		}
+	}
+	return next
+}
+
+// getPrePullStatus returns whether the image of the pre-pull pod was pulled, or the reason it could not be pulled.
+// The image is pulled once the container started, regardless of its exit code. A pod that failed before its
+// container was created, such as one rejected by the kubelet, failed to pull the image.
+func getPrePullStatus(pod *corev1.Pod) (bool, string) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != imagePrePullContainerName {
+			continue
+		}
+		switch {
+		case status.State.Running != nil || status.State.Terminated != nil:
+			return true, ""
+		case isImagePullFailure(status.State.Waiting):
+			return false, status.State.Waiting.Message
+		}
+	}
+	if pod.Status.Phase == corev1.PodFailed {
+		if pod.Status.Message != "" {
+			return false, pod.Status.Message
+		}
+		return false, fmt.Sprintf("pod failed with reason %q", pod.Status.Reason)
+	}
+	return false, ""
+}
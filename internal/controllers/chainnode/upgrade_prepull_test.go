@@ -0,0 +1,181 @@
+package chainnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+func prePullTestChainNode() *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default", UID: "node-uid"},
+		Spec: appsv1.ChainNodeSpec{
+			App: appsv1.AppSpec{Image: "app", Version: ptr.To("v1"), App: "appd"},
+		},
+		Status: appsv1.ChainNodeStatus{
+			LatestHeight: 900,
+			Upgrades: []appsv1.Upgrade{
+				{Height: 2000, Image: "app:v3", Status: appsv1.UpgradeScheduled},
+				{Height: 1000, Image: "app:v2", Status: appsv1.UpgradeScheduled},
+			},
+		},
+	}
+}
+
+func prePullTestNodePod(nodeName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func getTestPrePullPod(t *testing.T, r *Reconciler) (*corev1.Pod, error) {
+	t.Helper()
+	pod := &corev1.Pod{}
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "node-image-prepull"}, pod)
+	return pod, err
+}
+
+func TestEnsureUpgradeImagePrePullCreatesPodForNextUpgrade(t *testing.T) {
+	chainNode := prePullTestChainNode()
+	r, _ := upgradeRollbackTestReconciler(t, chainNode, prePullTestNodePod("worker-1"))
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(context.Background(), chainNode))
+
+	pod, err := getTestPrePullPod(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-1", pod.Spec.NodeName)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, "app:v2", pod.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"appd", "version"}, pod.Spec.Containers[0].Command)
+	assert.NotNil(t, pod.Spec.Containers[0].SecurityContext)
+}
+
+func TestEnsureUpgradeImagePrePullDisabled(t *testing.T) {
+	chainNode := prePullTestChainNode()
+	r, _ := upgradeRollbackTestReconciler(t, chainNode, prePullTestNodePod("worker-1"))
+	ctx := context.Background()
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	_, err := getTestPrePullPod(t, r)
+	require.NoError(t, err)
+
+	// Pods of previous pulls are removed once pre-pulling is disabled
+	chainNode.Spec.App.PrePullUpgradeImages = ptr.To(false)
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	_, err = getTestPrePullPod(t, r)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestEnsureUpgradeImagePrePullRecordsPulledImage(t *testing.T) {
+	chainNode := prePullTestChainNode()
+	r, recorder := upgradeRollbackTestReconciler(t, chainNode, prePullTestNodePod("worker-1"))
+	ctx := context.Background()
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	pod, err := getTestPrePullPod(t, r)
+	require.NoError(t, err)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  imagePrePullContainerName,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+	}}
+	require.NoError(t, r.Status().Update(ctx, pod))
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	assert.Equal(t, "worker-1", chainNode.Status.Upgrades[1].PrePulledOn)
+	assert.Empty(t, chainNode.Status.Upgrades[0].PrePulledOn)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeImagePulled)
+	_, err = getTestPrePullPod(t, r)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// The next upgrade is pulled afterwards
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	pod, err = getTestPrePullPod(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, "app:v3", pod.Spec.Containers[0].Image)
+}
+
+func TestEnsureUpgradeImagePrePullReportsPullFailure(t *testing.T) {
+	chainNode := prePullTestChainNode()
+	r, recorder := upgradeRollbackTestReconciler(t, chainNode, prePullTestNodePod("worker-1"))
+	ctx := context.Background()
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	pod, err := getTestPrePullPod(t, r)
+	require.NoError(t, err)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: imagePrePullContainerName,
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason: ReasonImagePullBackOff, Message: "manifest unknown",
+		}},
+	}}
+	require.NoError(t, r.Status().Update(ctx, pod))
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	assert.Empty(t, chainNode.Status.Upgrades[1].PrePulledOn)
+	event := <-recorder.Events
+	assert.Contains(t, event, appsv1.ReasonUpgradeImagePullFailed)
+	assert.Contains(t, event, "manifest unknown")
+	assert.True(t, apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionUpgradeImagePullFailed))
+
+	// The failure is only reported once per image
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestEnsureUpgradeImagePrePullRecreatesRejectedPod(t *testing.T) {
+	chainNode := prePullTestChainNode()
+	r, recorder := upgradeRollbackTestReconciler(t, chainNode, prePullTestNodePod("worker-1"))
+	ctx := context.Background()
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	pod, err := getTestPrePullPod(t, r)
+	require.NoError(t, err)
+	pod.Status.Phase = corev1.PodFailed
+	pod.Status.Reason = "OutOfcpu"
+	pod.Status.Message = "Pod was rejected: Node didn't have enough resource: cpu"
+	require.NoError(t, r.Status().Update(ctx, pod))
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	assert.Contains(t, <-recorder.Events, "Pod was rejected")
+	_, err = getTestPrePullPod(t, r)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// The pull is retried with a new pod, and the image is recorded once pulled
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	pod, err = getTestPrePullPod(t, r)
+	require.NoError(t, err)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  imagePrePullContainerName,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}}
+	require.NoError(t, r.Status().Update(ctx, pod))
+	require.NoError(t, r.ensureUpgradeImagePrePull(ctx, chainNode))
+	assert.Equal(t, "worker-1", chainNode.Status.Upgrades[1].PrePulledOn)
+	assert.Nil(t, apiMeta.FindStatusCondition(chainNode.Status.Conditions, appsv1.ConditionUpgradeImagePullFailed))
+}
+
+func TestEnsureUpgradeImagePrePullRepullsWhenNodeChanges(t *testing.T) {
+	chainNode := prePullTestChainNode()
+	chainNode.Status.Upgrades[1].PrePulledOn = "worker-1"
+	chainNode.Status.Upgrades[0].PrePulledOn = "worker-1"
+	r, _ := upgradeRollbackTestReconciler(t, chainNode, prePullTestNodePod("worker-2"))
+
+	require.NoError(t, r.ensureUpgradeImagePrePull(context.Background(), chainNode))
+
+	pod, err := getTestPrePullPod(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, "worker-2", pod.Spec.NodeName)
+	assert.Equal(t, "app:v2", pod.Spec.Containers[0].Image)
+}
@@ -0,0 +1,350 @@
+package chainnode
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+// ensureUpgradeRehearsal rehearses the next scheduled upgrade on a temporary clone of the node. The clone is a
+// ChainNode restored from the latest ready VolumeSnapshot of the node, that runs the current image until the
+// upgrade height, is then upgraded like any other node and halts right after the upgrade height. The outcome is
+// recorded on the upgrade in `.status.upgrades` and the clone is deleted once the rehearsal finishes.
+func (r *Reconciler) ensureUpgradeRehearsal(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	clone := &appsv1.ChainNode{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: chainNode.GetNamespace(), Name: getRehearsalNodeName(chainNode)}, clone)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	var upgrade *appsv1.Upgrade
+	if chainNode.Spec.App.ShouldRehearseUpgrades() && chainNode.SnapshotsEnabled() {
+		upgrade = nextUpgradeToRehearse(chainNode)
+	}
+
+	if interruptUpgradeRehearsals(chainNode, upgrade) {
+		if err := r.Status().Update(ctx, chainNode); err != nil {
+			return err
+		}
+	}
+
+	if upgrade == nil {
+		if exists {
+			return r.deleteRehearsalNode(ctx, clone)
+		}
+		return nil
+	}
+
+	// Wait for the clone of a previous rehearsal to be gone
+	if exists && !clone.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	if upgrade.Rehearsal == nil || upgrade.Rehearsal.Image != upgrade.Image {
+		if exists {
+			return r.deleteRehearsalNode(ctx, clone)
+		}
+		return r.startUpgradeRehearsal(ctx, chainNode, upgrade)
+	}
+
+	if !exists {
+		return r.finishUpgradeRehearsal(ctx, chainNode, nil, upgrade.Height, appsv1.UpgradeRehearsalFailed,
+			"Rehearsal node was deleted before the rehearsal finished")
+	}
+
+	pod, err := r.getChainNodePod(ctx, clone)
+	if err != nil {
+		return err
+	}
+	timeout := chainNode.Spec.App.UpgradeRehearsal.GetTimeout()
+	phase, message := getRehearsalOutcome(clone, upgrade.Height, timeout, isAppContainerRunning(pod, clone.Spec.App.App))
+	if phase == appsv1.UpgradeRehearsalRunning {
+		return nil
+	}
+	return r.finishUpgradeRehearsal(ctx, chainNode, clone, upgrade.Height, phase, message)
+}
+
+func (r *Reconciler) startUpgradeRehearsal(ctx context.Context, chainNode *appsv1.ChainNode, upgrade *appsv1.Upgrade) error {
+	logger := log.FromContext(ctx)
+
+	snapshot, err := r.getRehearsalSnapshot(ctx, chainNode, upgrade.Height)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		logger.V(1).Info("no snapshot available to rehearse upgrade", "height", upgrade.Height)
+		return nil
+	}
+
+	clone, err := r.getRehearsalNodeSpec(chainNode, upgrade, snapshot.GetName())
+	if err != nil {
+		return err
+	}
+	logger.Info("starting upgrade rehearsal", "node", clone.GetName(), "image", upgrade.Image, "height", upgrade.Height, "snapshot", snapshot.GetName())
+	if err := r.Create(ctx, clone); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(chainNode,
+		corev1.EventTypeNormal,
+		appsv1.ReasonUpgradeRehearsalStarted,
+		"Rehearsing upgrade to %s at height %d on %s, restored from snapshot %s", upgrade.Image, upgrade.Height, clone.GetName(), snapshot.GetName(),
+	)
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Height == upgrade.Height {
+			chainNode.Status.Upgrades[i].Rehearsal = &appsv1.UpgradeRehearsalStatus{
+				Phase:     appsv1.UpgradeRehearsalRunning,
+				Image:     upgrade.Image,
+				Snapshot:  snapshot.GetName(),
+				StartedAt: ptr.To(metav1.Now()),
+			}
+		}
+	}
+	return r.Status().Update(ctx, chainNode)
+}
+
+func (r *Reconciler) finishUpgradeRehearsal(ctx context.Context, chainNode, clone *appsv1.ChainNode, height int64, phase appsv1.UpgradeRehearsalPhase, message string) error {
+	log.FromContext(ctx).Info("upgrade rehearsal finished", "height", height, "phase", phase, "message", message)
+
+	if phase == appsv1.UpgradeRehearsalPassed {
+		r.recorder.Event(chainNode, corev1.EventTypeNormal, appsv1.ReasonUpgradeRehearsalPassed, message)
+	} else {
+		r.recorder.Event(chainNode, corev1.EventTypeWarning, appsv1.ReasonUpgradeRehearsalFailed, message)
+	}
+
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Height == height && u.Rehearsal != nil {
+			chainNode.Status.Upgrades[i].Rehearsal.Phase = phase
+			chainNode.Status.Upgrades[i].Rehearsal.CompletedAt = ptr.To(metav1.Now())
+			chainNode.Status.Upgrades[i].Rehearsal.Message = message
+		}
+	}
+	if err := r.Status().Update(ctx, chainNode); err != nil {
+		return err
+	}
+
+	if clone != nil {
+		return r.deleteRehearsalNode(ctx, clone)
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteRehearsalNode(ctx context.Context, clone *appsv1.ChainNode) error {
+	if !clone.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	log.FromContext(ctx).Info("deleting upgrade rehearsal node", "node", clone.GetName())
+	uid := clone.GetUID()
+	if err := r.Delete(ctx, clone, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// getRehearsalSnapshot returns the most recent ready snapshot of the node taken before the given height, or nil
+// if there is none.
+func (r *Reconciler) getRehearsalSnapshot(ctx context.Context, chainNode *appsv1.ChainNode, height int64) (*snapshotv1.VolumeSnapshot, error) {
+	snapshots, err := r.listNodeSnapshots(ctx, chainNode)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *snapshotv1.VolumeSnapshot
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		if !snapshot.GetDeletionTimestamp().IsZero() || !isSnapshotReady(snapshot) {
+			continue
+		}
+		dataHeight, err := strconv.ParseInt(snapshot.Annotations[controllers.AnnotationDataHeight], 10, 64)
+		if err != nil || dataHeight >= height {
+			continue
+		}
+		if latest == nil || snapshot.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = snapshot
+		}
+	}
+	return latest, nil
+}
+
+// getRehearsalNodeSpec returns the clone used to rehearse the upgrade. The clone never signs, is not exposed,
+// is not discovered as a peer by other nodes and has its data volume deleted along with it.
+func (r *Reconciler) getRehearsalNodeSpec(chainNode *appsv1.ChainNode, upgrade *appsv1.Upgrade, snapshot string) (*appsv1.ChainNode, error) {
+	genesis := chainNode.Spec.Genesis.DeepCopy()
+	if !genesis.ShouldDownloadUsingContainer() && !genesis.HasConfigMapSource() {
+		genesis = &appsv1.GenesisConfig{
+			ConfigMap: ptr.To(chainNode.Spec.Genesis.GetConfigMapName(chainNode.Status.ChainID)),
+		}
+	}
+
+	app := chainNode.Spec.App.DeepCopy()
+	// Completed upgrades are not carried over to the clone, so pin the version currently run by the node.
+	app.Version = ptr.To(chainNode.GetAppVersion())
+	app.Upgrades = []appsv1.UpgradeSpec{{
+		Height:       upgrade.Height,
+		Image:        upgrade.Image,
+		ForceOnChain: ptr.To(upgrade.Source == appsv1.OnChainUpgrade),
+	}}
+	app.CheckGovUpgrades = ptr.To(false)
+	app.PrePullUpgradeImages = ptr.To(false)
+	app.UpgradeFailurePolicy = nil
+	app.UpgradeRehearsal = nil
+
+	config := chainNode.Spec.Config.DeepCopy()
+	if config == nil {
+		config = &appsv1.Config{}
+	}
+	config.HaltHeight = ptr.To(upgrade.Height + 1)
+	config.StateSync = nil
+	config.CosmoGuard = nil
+
+	persistence := chainNode.Spec.Persistence.DeepCopy()
+	persistence.Snapshots = nil
+	persistence.SnapshotBeforeUpgrade = nil
+	persistence.RestoreFromTarball = nil
+	persistence.RestoreFromSnapshot = &appsv1.PvcSnapshot{Name: snapshot}
+
+	labels := map[string]string{
+		controllers.LabelUpgradeRehearsal: chainNode.GetName(),
+		controllers.LabelUpgradeHeight:    strconv.FormatInt(upgrade.Height, 10),
+	}
+	if worker, ok := chainNode.Labels[controllers.LabelWorkerName]; ok {
+		labels[controllers.LabelWorkerName] = worker
+	}
+
+	clone := &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getRehearsalNodeName(chainNode),
+			Namespace: chainNode.GetNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.ChainNodeSpec{
+			Genesis:     genesis,
+			App:         *app,
+			Config:      config,
+			Persistence: persistence,
+			DeletionPolicy: &appsv1.DeletionPolicy{
+				DataVolumes:   ptr.To(appsv1.DeletionPolicyDelete),
+				GeneratedKeys: ptr.To(appsv1.DeletionPolicyDelete),
+			},
+			AutoDiscoverPeers: chainNode.Spec.AutoDiscoverPeers,
+			StateSyncRestore:  ptr.To(false),
+			Peers:             chainNode.Spec.Peers,
+			Resources:         chainNode.Spec.Resources,
+			NodeSelector:      chainNode.Spec.NodeSelector,
+			Affinity:          chainNode.Spec.Affinity,
+		},
+	}
+	if err := controllerutil.SetControllerReference(chainNode, clone, r.Scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference: %w", err)
+	}
+	return clone, nil
+}
+
+func getRehearsalNodeName(chainNode *appsv1.ChainNode) string {
+	return fmt.Sprintf("%s-rehearsal", chainNode.GetName())
+}
+
+func isUpgradeRehearsalNode(chainNode *appsv1.ChainNode) bool {
+	_, ok := chainNode.Labels[controllers.LabelUpgradeRehearsal]
+	return ok
+}
+
+// nextUpgradeToRehearse returns the upgrade whose rehearsal is running, or otherwise the lowest scheduled upgrade
+// whose image was not rehearsed yet.
+func nextUpgradeToRehearse(chainNode *appsv1.ChainNode) *appsv1.Upgrade {
+	var next *appsv1.Upgrade
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Status != appsv1.UpgradeScheduled || u.Image == "" {
+			continue
+		}
+		if u.Rehearsal != nil && u.Rehearsal.Image == u.Image {
+			if u.Rehearsal.Phase == appsv1.UpgradeRehearsalRunning {
+				return &chainNode.Status.Upgrades[i]
+			}
+			continue
+		}
+		if next == nil || u.Height < next.Height {
+			next = &chainNode.Status.Upgrades[i]
+		}
+	}
+	return next
+}
+
+// interruptUpgradeRehearsals marks running rehearsals of upgrades other than the given one as failed, since they
+// can no longer finish. Returns true if any rehearsal was interrupted.
+func interruptUpgradeRehearsals(chainNode *appsv1.ChainNode, current *appsv1.Upgrade) bool {
+	interrupted := false
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Rehearsal == nil || u.Rehearsal.Phase != appsv1.UpgradeRehearsalRunning {
+			continue
+		}
+		if current != nil && current.Height == u.Height && current.Image == u.Rehearsal.Image {
+			continue
+		}
+		chainNode.Status.Upgrades[i].Rehearsal.Phase = appsv1.UpgradeRehearsalFailed
+		chainNode.Status.Upgrades[i].Rehearsal.CompletedAt = ptr.To(metav1.Now())
+		chainNode.Status.Upgrades[i].Rehearsal.Message = "Rehearsal was interrupted before it finished"
+		interrupted = true
+	}
+	return interrupted
+}
+
+// getRehearsalOutcome returns the phase of the rehearsal running on the clone, along with a message describing
+// the outcome once it finished. The rehearsal passes once the clone processes a block past the upgrade height,
+// or when the new image is still running after the timeout, which means the migration started.
+func getRehearsalOutcome(clone *appsv1.ChainNode, height int64, timeout time.Duration, appRunning bool) (appsv1.UpgradeRehearsalPhase, string) {
+	var upgrade *appsv1.Upgrade
+	for i := range clone.Status.Upgrades {
+		if clone.Status.Upgrades[i].Height == height {
+			upgrade = &clone.Status.Upgrades[i]
+		}
+	}
+
+	switch {
+	// Clone is still running the current image
+	case upgrade == nil || upgrade.Status == appsv1.UpgradeScheduled || upgrade.Status == appsv1.UpgradeOnGoing:
+		return appsv1.UpgradeRehearsalRunning, ""
+
+	case upgrade.Status != appsv1.UpgradeCompleted:
+		return appsv1.UpgradeRehearsalFailed, fmt.Sprintf("Rehearsal node did not apply upgrade at height %d: upgrade is %s", height, upgrade.Status)
+
+	case clone.Status.LatestHeight > height:
+		return appsv1.UpgradeRehearsalPassed, fmt.Sprintf("Image %s produced blocks past upgrade height %d", upgrade.Image, height)
+
+	case upgrade.AppliedAt == nil || time.Since(upgrade.AppliedAt.Time) < timeout:
+		return appsv1.UpgradeRehearsalRunning, ""
+
+	case appRunning:
+		return appsv1.UpgradeRehearsalPassed, fmt.Sprintf("Image %s started the migration at upgrade height %d and was still running after %s", upgrade.Image, height, timeout)
+	}
+
+	return appsv1.UpgradeRehearsalFailed, fmt.Sprintf("Image %s did not get past upgrade height %d within %s", upgrade.Image, height, timeout)
+}
+
+// isAppContainerRunning returns true if the app container of the pod is running and never restarted.
+func isAppContainerRunning(pod *corev1.Pod, app string) bool {
+	if pod == nil {
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == app {
+			return status.State.Running != nil && status.RestartCount == 0
+		}
+	}
+	return false
+}
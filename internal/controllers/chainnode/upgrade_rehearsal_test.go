@@ -0,0 +1,257 @@
+package chainnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func rehearsalTestChainNode() *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "node",
+			Namespace: "default",
+			UID:       "node-uid",
+			Labels:    map[string]string{controllers.LabelWorkerName: "worker", "team": "infra"},
+		},
+		Spec: appsv1.ChainNodeSpec{
+			Genesis: &appsv1.GenesisConfig{Url: ptr.To("https://example.com/genesis.json")},
+			App: appsv1.AppSpec{
+				Image:            "app",
+				Version:          ptr.To("v1"),
+				App:              "appd",
+				UpgradeRehearsal: &appsv1.UpgradeRehearsal{Timeout: ptr.To("10m")},
+			},
+			Validator: &appsv1.ValidatorConfig{},
+			Persistence: &appsv1.Persistence{
+				Snapshots:             &appsv1.VolumeSnapshotsConfig{Frequency: "24h"},
+				SnapshotBeforeUpgrade: ptr.To(true),
+			},
+		},
+		Status: appsv1.ChainNodeStatus{
+			ChainID:      "test-1",
+			LatestHeight: 900,
+			Upgrades: []appsv1.Upgrade{
+				{Height: 2000, Image: "app:v3", Status: appsv1.UpgradeScheduled, Source: appsv1.ManualUpgrade},
+				{Height: 1000, Image: "app:v2", Status: appsv1.UpgradeScheduled, Source: appsv1.OnChainUpgrade},
+			},
+		},
+	}
+}
+
+func rehearsalTestSnapshot(name string, height string, createdAt time.Time) *snapshotv1.VolumeSnapshot {
+	return &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(createdAt),
+			Labels:            map[string]string{controllers.LabelChainNode: "node"},
+			Annotations:       map[string]string{controllers.AnnotationDataHeight: height},
+		},
+		Status: &snapshotv1.VolumeSnapshotStatus{ReadyToUse: ptr.To(true)},
+	}
+}
+
+func rehearsalTestReconciler(t *testing.T, objects ...client.Object) (*Reconciler, *record.FakeRecorder) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, snapshotv1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(&appsv1.ChainNode{}).
+			Build(),
+		Scheme:   scheme,
+		recorder: recorder,
+	}, recorder
+}
+
+func getTestRehearsalNode(r *Reconciler) (*appsv1.ChainNode, error) {
+	clone := &appsv1.ChainNode{}
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "node-rehearsal"}, clone)
+	return clone, err
+}
+
+func TestGetRehearsalNodeSpec(t *testing.T) {
+	chainNode := rehearsalTestChainNode()
+	r, _ := rehearsalTestReconciler(t)
+
+	clone, err := r.getRehearsalNodeSpec(chainNode, &chainNode.Status.Upgrades[1], "node-snapshot")
+	require.NoError(t, err)
+
+	assert.Equal(t, "node-rehearsal", clone.GetName())
+	assert.Equal(t, map[string]string{
+		controllers.LabelWorkerName:       "worker",
+		controllers.LabelUpgradeRehearsal: "node",
+		controllers.LabelUpgradeHeight:    "1000",
+	}, clone.Labels)
+	require.Len(t, clone.OwnerReferences, 1)
+	assert.Equal(t, "node", clone.OwnerReferences[0].Name)
+
+	// Runs the current version until the upgrade height, and halts right after it
+	assert.Equal(t, ptr.To("v1"), clone.Spec.App.Version)
+	assert.Equal(t, []appsv1.UpgradeSpec{{Height: 1000, Image: "app:v2", ForceOnChain: ptr.To(true)}}, clone.Spec.App.Upgrades)
+	assert.Equal(t, ptr.To(int64(1001)), clone.Spec.Config.HaltHeight)
+	assert.False(t, clone.Spec.App.ShouldQueryGovUpgrades())
+	assert.Nil(t, clone.Spec.App.UpgradeRehearsal)
+
+	assert.Equal(t, &appsv1.PvcSnapshot{Name: "node-snapshot"}, clone.Spec.Persistence.RestoreFromSnapshot)
+	assert.False(t, clone.SnapshotsEnabled())
+	assert.False(t, clone.ShouldSnapshotBeforeUpgrade())
+	assert.Equal(t, ptr.To(appsv1.DeletionPolicyDelete), clone.Spec.DeletionPolicy.DataVolumes)
+	assert.Nil(t, clone.Spec.Validator)
+	assert.True(t, isUpgradeRehearsalNode(clone))
+	assert.False(t, isUpgradeRehearsalNode(chainNode))
+}
+
+func TestGetRehearsalNodeSpecUsesGenesisConfigMap(t *testing.T) {
+	chainNode := rehearsalTestChainNode()
+	chainNode.Spec.Genesis = nil
+	r, _ := rehearsalTestReconciler(t)
+
+	clone, err := r.getRehearsalNodeSpec(chainNode, &chainNode.Status.Upgrades[1], "node-snapshot")
+	require.NoError(t, err)
+	assert.Equal(t, &appsv1.GenesisConfig{ConfigMap: ptr.To("test-1-genesis")}, clone.Spec.Genesis)
+}
+
+func TestNextUpgradeToRehearse(t *testing.T) {
+	chainNode := rehearsalTestChainNode()
+	assert.Equal(t, int64(1000), nextUpgradeToRehearse(chainNode).Height)
+
+	chainNode.Status.Upgrades[1].Rehearsal = &appsv1.UpgradeRehearsalStatus{Phase: appsv1.UpgradeRehearsalPassed, Image: "app:v2"}
+	assert.Equal(t, int64(2000), nextUpgradeToRehearse(chainNode).Height)
+
+	// A new image must be rehearsed again
+	chainNode.Status.Upgrades[1].Image = "app:v2.0.1"
+	assert.Equal(t, int64(1000), nextUpgradeToRehearse(chainNode).Height)
+
+	// A running rehearsal is picked before lower upgrades
+	chainNode.Status.Upgrades[0].Rehearsal = &appsv1.UpgradeRehearsalStatus{Phase: appsv1.UpgradeRehearsalRunning, Image: "app:v3"}
+	assert.Equal(t, int64(2000), nextUpgradeToRehearse(chainNode).Height)
+}
+
+func TestGetRehearsalOutcome(t *testing.T) {
+	clone := func(status appsv1.UpgradePhase, latestHeight int64, appliedAt time.Time) *appsv1.ChainNode {
+		return &appsv1.ChainNode{Status: appsv1.ChainNodeStatus{
+			LatestHeight: latestHeight,
+			Upgrades: []appsv1.Upgrade{{
+				Height: 1000, Image: "app:v2", Status: status, AppliedAt: ptr.To(metav1.NewTime(appliedAt)),
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		clone      *appsv1.ChainNode
+		appRunning bool
+		expected   appsv1.UpgradeRehearsalPhase
+	}{
+		{"syncing", &appsv1.ChainNode{}, true, appsv1.UpgradeRehearsalRunning},
+		{"before upgrade height", clone(appsv1.UpgradeScheduled, 990, time.Time{}), true, appsv1.UpgradeRehearsalRunning},
+		{"produced blocks", clone(appsv1.UpgradeCompleted, 1001, time.Now()), false, appsv1.UpgradeRehearsalPassed},
+		{"migrating", clone(appsv1.UpgradeCompleted, 1000, time.Now()), false, appsv1.UpgradeRehearsalRunning},
+		{"still running after timeout", clone(appsv1.UpgradeCompleted, 1000, time.Now().Add(-time.Hour)), true, appsv1.UpgradeRehearsalPassed},
+		{"crashed", clone(appsv1.UpgradeCompleted, 1000, time.Now().Add(-time.Hour)), false, appsv1.UpgradeRehearsalFailed},
+		{"skipped", clone(appsv1.UpgradeSkipped, 1200, time.Now()), true, appsv1.UpgradeRehearsalFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, _ := getRehearsalOutcome(tt.clone, 1000, 30*time.Minute, tt.appRunning)
+			assert.Equal(t, tt.expected, phase)
+		})
+	}
+}
+
+func TestEnsureUpgradeRehearsalStartsFromLatestSnapshot(t *testing.T) {
+	chainNode := rehearsalTestChainNode()
+	now := time.Now()
+	r, recorder := rehearsalTestReconciler(t, chainNode,
+		rehearsalTestSnapshot("node-old", "500", now.Add(-2*time.Hour)),
+		rehearsalTestSnapshot("node-latest", "800", now.Add(-time.Hour)),
+		rehearsalTestSnapshot("node-past-upgrade", "1000", now),
+	)
+
+	require.NoError(t, r.ensureUpgradeRehearsal(context.Background(), chainNode))
+
+	clone, err := getTestRehearsalNode(r)
+	require.NoError(t, err)
+	assert.Equal(t, "node-latest", clone.Spec.Persistence.RestoreFromSnapshot.Name)
+	rehearsal := chainNode.Status.Upgrades[1].Rehearsal
+	require.NotNil(t, rehearsal)
+	assert.Equal(t, appsv1.UpgradeRehearsalRunning, rehearsal.Phase)
+	assert.Equal(t, "app:v2", rehearsal.Image)
+	assert.Equal(t, "node-latest", rehearsal.Snapshot)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeRehearsalStarted)
+}
+
+func TestEnsureUpgradeRehearsalWaitsForSnapshot(t *testing.T) {
+	chainNode := rehearsalTestChainNode()
+	r, _ := rehearsalTestReconciler(t, chainNode)
+
+	require.NoError(t, r.ensureUpgradeRehearsal(context.Background(), chainNode))
+	_, err := getTestRehearsalNode(r)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Nil(t, chainNode.Status.Upgrades[1].Rehearsal)
+}
+
+func TestEnsureUpgradeRehearsalRecordsOutcome(t *testing.T) {
+	chainNode := rehearsalTestChainNode()
+	r, recorder := rehearsalTestReconciler(t, chainNode, rehearsalTestSnapshot("node-latest", "800", time.Now()))
+	ctx := context.Background()
+
+	require.NoError(t, r.ensureUpgradeRehearsal(ctx, chainNode))
+	<-recorder.Events
+
+	clone, err := getTestRehearsalNode(r)
+	require.NoError(t, err)
+	clone.Status.LatestHeight = 1001
+	clone.Status.Upgrades = []appsv1.Upgrade{{
+		Height: 1000, Image: "app:v2", Status: appsv1.UpgradeCompleted, AppliedAt: ptr.To(metav1.Now()),
+	}}
+	require.NoError(t, r.Status().Update(ctx, clone))
+
+	require.NoError(t, r.ensureUpgradeRehearsal(ctx, chainNode))
+	rehearsal := chainNode.Status.Upgrades[1].Rehearsal
+	require.NotNil(t, rehearsal)
+	assert.Equal(t, appsv1.UpgradeRehearsalPassed, rehearsal.Phase)
+	assert.NotNil(t, rehearsal.CompletedAt)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeRehearsalPassed)
+
+	_, err = getTestRehearsalNode(r)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestEnsureUpgradeRehearsalInterruptsAppliedUpgrades(t *testing.T) {
+	chainNode := rehearsalTestChainNode()
+	chainNode.Status.Upgrades[1].Status = appsv1.UpgradeOnGoing
+	chainNode.Status.Upgrades[1].Rehearsal = &appsv1.UpgradeRehearsalStatus{Phase: appsv1.UpgradeRehearsalRunning, Image: "app:v2"}
+	chainNode.Status.Upgrades[0].Rehearsal = &appsv1.UpgradeRehearsalStatus{Phase: appsv1.UpgradeRehearsalPassed, Image: "app:v3"}
+	specReconciler, _ := rehearsalTestReconciler(t)
+	clone, err := specReconciler.getRehearsalNodeSpec(chainNode, &chainNode.Status.Upgrades[1], "node-latest")
+	require.NoError(t, err)
+	r, _ := rehearsalTestReconciler(t, chainNode, clone)
+
+	require.NoError(t, r.ensureUpgradeRehearsal(context.Background(), chainNode))
+	assert.Equal(t, appsv1.UpgradeRehearsalFailed, chainNode.Status.Upgrades[1].Rehearsal.Phase)
+	_, err = getTestRehearsalNode(r)
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,180 @@
+package chainnode
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+	"github.com/voluzi/cosmopilot/v3/internal/k8s"
+)
+
+// checkUpgradeHealth verifies that a node upgraded by cosmopilot gets past the upgrade height within the
+// deadline of the upgrade failure policy, and rolls the upgrade back otherwise. Returns true when a rollback
+// was performed.
+func (r *Reconciler) checkUpgradeHealth(ctx context.Context, chainNode *appsv1.ChainNode) (bool, error) {
+	height := getUpgradePendingVerification(chainNode)
+	if height == 0 {
+		return false, nil
+	}
+
+	var upgrade *appsv1.Upgrade
+	for i := range chainNode.Status.Upgrades {
+		if chainNode.Status.Upgrades[i].Height == height {
+			upgrade = &chainNode.Status.Upgrades[i]
+			break
+		}
+	}
+
+	switch {
+	case !chainNode.Spec.App.ShouldRollbackFailedUpgrades() || upgrade == nil:
+		return false, r.clearUpgradePendingVerification(ctx, chainNode)
+
+	// Upgrade has not been applied yet
+	case upgrade.Status == appsv1.UpgradeOnGoing || upgrade.Status == appsv1.UpgradeScheduled:
+		return false, nil
+
+	case upgrade.Status != appsv1.UpgradeCompleted || upgrade.AppliedAt == nil:
+		return false, r.clearUpgradePendingVerification(ctx, chainNode)
+
+	// The new image is processing blocks
+	case chainNode.Status.LatestHeight > height:
+		log.FromContext(ctx).Info("upgraded node got past upgrade height", "height", height)
+		return false, r.clearUpgradePendingVerification(ctx, chainNode)
+
+	case time.Since(upgrade.AppliedAt.Time) < chainNode.Spec.App.UpgradeFailurePolicy.GetDeadline():
+		return false, nil
+	}
+
+	return true, r.rollbackUpgrade(ctx, chainNode, upgrade)
+}
+
+// rollbackUpgrade stops the node, restores the data from the pre-upgrade snapshot when available and marks
+// the upgrade as failed, so that the node is started again with the previous image. If the upgrade has a
+// fallback image that was not tried yet, the upgrade is scheduled again with it instead.
+func (r *Reconciler) rollbackUpgrade(ctx context.Context, chainNode *appsv1.ChainNode, upgrade *appsv1.Upgrade) error {
+	logger := log.FromContext(ctx)
+
+	logger.Info("rolling back upgrade", "height", upgrade.Height, "image", upgrade.Image)
+	failedImage := upgrade.Image
+
+	pod, err := r.getPodSpec(ctx, chainNode, "")
+	if err != nil {
+		return err
+	}
+	ph := k8s.NewPodHelper(r.ClientSet, r.RestConfig, pod)
+	if err := ph.Delete(ctx); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else if err := ph.WaitForPodDeleted(ctx, timeoutPodDeleted); err != nil {
+		return err
+	}
+
+	// The PVC is recreated from the snapshot by ensureDataVolume on the next reconcile.
+	restoreSnapshot := ""
+	if chainNode.Spec.App.UpgradeFailurePolicy.ShouldRestoreData() && upgrade.Snapshot != "" {
+		restoreSnapshot = upgrade.Snapshot
+		chainNode.ObjectMeta.Annotations[controllers.AnnotationUpgradeRollbackSnapshot] = restoreSnapshot
+	}
+	delete(chainNode.ObjectMeta.Annotations, controllers.AnnotationUpgradePendingVerification)
+
+	// Update may refresh status, so keep a copy of the upgrade being rolled back.
+	height := upgrade.Height
+	if err := r.Update(ctx, chainNode); err != nil {
+		return err
+	}
+
+	if restoreSnapshot != "" {
+		pvc, err := r.getPVC(ctx, chainNode)
+		if err != nil {
+			return err
+		}
+		if pvc != nil {
+			logger.Info("deleting pvc to restore pre-upgrade snapshot", "pvc", pvc.GetName(), "snapshot", restoreSnapshot)
+			uid := pvc.GetUID()
+			if err := r.Delete(ctx, pvc, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	var message string
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Height != height {
+			continue
+		}
+		chainNode.Status.Upgrades[i].FailedImages = append(chainNode.Status.Upgrades[i].FailedImages, failedImage)
+		fallback := chainNode.Spec.App.GetUpgradeFallbackImage(height)
+		if fallback != "" && !slices.Contains(chainNode.Status.Upgrades[i].FailedImages, fallback) {
+			chainNode.Status.Upgrades[i].Image = fallback
+			chainNode.Status.Upgrades[i].Status = appsv1.UpgradeScheduled
+			message = fmt.Sprintf("Node did not get past upgrade height %d with image %s. Retrying with fallback image %s", height, failedImage, fallback)
+		} else {
+			chainNode.Status.Upgrades[i].Status = appsv1.UpgradeFailed
+			message = fmt.Sprintf("Node did not get past upgrade height %d with image %s. Upgrade marked as failed", height, failedImage)
+		}
+	}
+	if restoreSnapshot != "" {
+		message = fmt.Sprintf("%s. Restoring data from snapshot %s", message, restoreSnapshot)
+	}
+
+	chainNode.Status.AppVersion = chainNode.GetAppVersion()
+	apiMeta.SetStatusCondition(&chainNode.Status.Conditions, metav1.Condition{
+		Type:    appsv1.ConditionUpgrade,
+		Status:  metav1.ConditionFalse,
+		Reason:  appsv1.ReasonUpgradeRolledBack,
+		Message: message,
+	})
+	r.recorder.Event(chainNode, corev1.EventTypeWarning, appsv1.ReasonUpgradeRolledBack, message)
+
+	if err := r.Status().Update(ctx, chainNode); err != nil {
+		return err
+	}
+	return r.ensureUpgradesConfig(ctx, chainNode)
+}
+
+func (r *Reconciler) setUpgradePendingVerification(ctx context.Context, chainNode *appsv1.ChainNode, height int64) error {
+	if chainNode.ObjectMeta.Annotations == nil {
+		chainNode.ObjectMeta.Annotations = make(map[string]string)
+	}
+	chainNode.ObjectMeta.Annotations[controllers.AnnotationUpgradePendingVerification] = strconv.FormatInt(height, 10)
+	return r.Update(ctx, chainNode)
+}
+
+func (r *Reconciler) clearUpgradePendingVerification(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	delete(chainNode.ObjectMeta.Annotations, controllers.AnnotationUpgradePendingVerification)
+	return r.Update(ctx, chainNode)
+}
+
+// getUpgradePendingVerification returns the height of the upgrade waiting to be verified, or 0 if there is none.
+func getUpgradePendingVerification(chainNode *appsv1.ChainNode) int64 {
+	if s, ok := chainNode.ObjectMeta.Annotations[controllers.AnnotationUpgradePendingVerification]; ok {
+		if height, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return height
+		}
+	}
+	return 0
+}
+
+// getRestoreSnapshotName returns the name of the VolumeSnapshot the data volume should be created from, if any.
+// A pending upgrade rollback takes precedence over `.spec.persistence.restoreFromSnapshot`.
+func getRestoreSnapshotName(chainNode *appsv1.ChainNode) string {
+	if name := chainNode.ObjectMeta.Annotations[controllers.AnnotationUpgradeRollbackSnapshot]; name != "" {
+		return name
+	}
+	if chainNode.ShouldRestoreFromSnapshot() {
+		return chainNode.Spec.Persistence.RestoreFromSnapshot.Name
+	}
+	return ""
+}
@@ -0,0 +1,169 @@
+package chainnode
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func upgradeRollbackTestChainNode(appliedAt time.Time) *appsv1.ChainNode {
+	genesisConfigMap := "genesis"
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "node",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controllers.AnnotationUpgradePendingVerification: "1000",
+			},
+		},
+		Spec: appsv1.ChainNodeSpec{
+			Genesis: &appsv1.GenesisConfig{ConfigMap: &genesisConfigMap},
+			App: appsv1.AppSpec{
+				Image:                "app",
+				Version:              ptr.To("v1"),
+				App:                  "appd",
+				UpgradeFailurePolicy: &appsv1.UpgradeFailurePolicy{Deadline: ptr.To("30m")},
+				Upgrades: []appsv1.UpgradeSpec{
+					{Height: 1000, Image: "app:v2", FallbackImage: ptr.To("app:v2.0.1")},
+				},
+			},
+		},
+		Status: appsv1.ChainNodeStatus{
+			ChainID:      "test-1",
+			NodeID:       "node-id",
+			LatestHeight: 1000,
+			AppVersion:   "v2",
+			Upgrades: []appsv1.Upgrade{{
+				Height:    1000,
+				Image:     "app:v2",
+				Status:    appsv1.UpgradeCompleted,
+				Source:    appsv1.ManualUpgrade,
+				Snapshot:  "node-upgrade-1000",
+				AppliedAt: ptr.To(metav1.NewTime(appliedAt)),
+			}},
+		},
+	}
+}
+
+func upgradeRollbackTestReconciler(t *testing.T, objects ...client.Object) (*Reconciler, *record.FakeRecorder) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	// The node pod is already gone
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)),
+			Request:    req,
+		}, nil
+	})
+	clientSet, err := kubernetes.NewForConfig(&rest.Config{
+		Host: "https://kubernetes.invalid", ContentConfig: rest.ContentConfig{ContentType: "application/json"}, Transport: transport,
+	})
+	require.NoError(t, err)
+
+	recorder := record.NewFakeRecorder(10)
+	return &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(&appsv1.ChainNode{}).
+			Build(),
+		ClientSet: clientSet,
+		Scheme:    scheme,
+		opts:      &controllers.ControllerRunOptions{NodeUtilsImage: "node-utils"},
+		recorder:  recorder,
+	}, recorder
+}
+
+func TestCheckUpgradeHealthWaitsForDeadline(t *testing.T) {
+	chainNode := upgradeRollbackTestChainNode(time.Now().Add(-10 * time.Minute))
+	r, recorder := upgradeRollbackTestReconciler(t, chainNode)
+
+	rolledBack, err := r.checkUpgradeHealth(context.Background(), chainNode)
+	require.NoError(t, err)
+	assert.False(t, rolledBack)
+	assert.Equal(t, int64(1000), getUpgradePendingVerification(chainNode))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestCheckUpgradeHealthClearsVerificationPastUpgradeHeight(t *testing.T) {
+	chainNode := upgradeRollbackTestChainNode(time.Now().Add(-time.Hour))
+	chainNode.Status.LatestHeight = 1001
+	r, _ := upgradeRollbackTestReconciler(t, chainNode)
+
+	rolledBack, err := r.checkUpgradeHealth(context.Background(), chainNode)
+	require.NoError(t, err)
+	assert.False(t, rolledBack)
+	assert.Zero(t, getUpgradePendingVerification(chainNode))
+	assert.Equal(t, appsv1.UpgradeCompleted, chainNode.Status.Upgrades[0].Status)
+}
+
+func TestCheckUpgradeHealthRollsBackWithFallbackImage(t *testing.T) {
+	chainNode := upgradeRollbackTestChainNode(time.Now().Add(-time.Hour))
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"}}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"}}
+	r, recorder := upgradeRollbackTestReconciler(t, chainNode, pvc, configMap)
+
+	rolledBack, err := r.checkUpgradeHealth(context.Background(), chainNode)
+	require.NoError(t, err)
+	assert.True(t, rolledBack)
+
+	upgrade := chainNode.Status.Upgrades[0]
+	assert.Equal(t, appsv1.UpgradeScheduled, upgrade.Status)
+	assert.Equal(t, "app:v2.0.1", upgrade.Image)
+	assert.Equal(t, []string{"app:v2"}, upgrade.FailedImages)
+	assert.Equal(t, "v1", chainNode.Status.AppVersion)
+	assert.Zero(t, getUpgradePendingVerification(chainNode))
+	assert.Equal(t, "node-upgrade-1000", getRestoreSnapshotName(chainNode))
+	assert.True(t, apiMeta.IsStatusConditionFalse(chainNode.Status.Conditions, appsv1.ConditionUpgrade))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeRolledBack)
+
+	err = r.Get(context.Background(), client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestRollbackUpgradeMarksFailedWhenFallbackAlsoFailed(t *testing.T) {
+	chainNode := upgradeRollbackTestChainNode(time.Now().Add(-time.Hour))
+	chainNode.Spec.App.UpgradeFailurePolicy.RestoreData = ptr.To(false)
+	chainNode.Status.Upgrades[0].Image = "app:v2.0.1"
+	chainNode.Status.Upgrades[0].FailedImages = []string{"app:v2"}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"}}
+	r, _ := upgradeRollbackTestReconciler(t, chainNode, configMap)
+
+	require.NoError(t, r.rollbackUpgrade(context.Background(), chainNode, &chainNode.Status.Upgrades[0]))
+
+	upgrade := chainNode.Status.Upgrades[0]
+	assert.Equal(t, appsv1.UpgradeFailed, upgrade.Status)
+	assert.Equal(t, []string{"app:v2", "app:v2.0.1"}, upgrade.FailedImages)
+	assert.Empty(t, getRestoreSnapshotName(chainNode))
+
+	// A new image for the failed upgrade schedules it again, but previously failed images don't.
+	upgrades := AddOrUpdateUpgrade(chainNode.Status.Upgrades, appsv1.Upgrade{Height: 1000, Image: "app:v2"}, 1000)
+	assert.Equal(t, appsv1.UpgradeFailed, upgrades[0].Status)
+	upgrades = AddOrUpdateUpgrade(upgrades, appsv1.Upgrade{Height: 1000, Image: "app:v2.0.2"}, 1000)
+	assert.Equal(t, appsv1.UpgradeScheduled, upgrades[0].Status)
+	assert.Equal(t, "app:v2.0.2", upgrades[0].Image)
+}
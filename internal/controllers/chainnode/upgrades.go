@@ -5,16 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
 	"github.com/voluzi/cosmopilot/v3/pkg/nodeutils"
 )
 
@@ -59,6 +67,12 @@ func (r *Reconciler) ensureUpgrades(ctx context.Context, chainNode *appsv1.Chain
 		chainNode.Status.Upgrades = AddOrUpdateUpgrade(chainNode.Status.Upgrades, u, chainNode.Status.LatestHeight)
 	}
 
+	// Manual upgrades removed from spec before being applied are no longer scheduled. This is also the case for
+	// nodes of a ChainNodeSet on which a staged upgrade rollout is being held.
+	chainNode.Status.Upgrades = slices.DeleteFunc(chainNode.Status.Upgrades, func(u appsv1.Upgrade) bool {
+		return u.Source == appsv1.ManualUpgrade && u.Status == appsv1.UpgradeScheduled && !hasUpgradeSpec(chainNode, u.Height)
+	})
+
 	// Sort upgrades by height
 	sort.Slice(chainNode.Status.Upgrades, func(i, j int) bool {
 		return chainNode.Status.Upgrades[i].Height < chainNode.Status.Upgrades[j].Height
@@ -79,9 +93,11 @@ func (r *Reconciler) ensureUpgradesConfig(ctx context.Context, chainNode *appsv1
 	logger := log.FromContext(ctx)
 
 	upgrades := struct {
-		Upgrades []appsv1.Upgrade `json:"upgrades"`
+		Upgrades       []appsv1.Upgrade `json:"upgrades"`
+		SnapshotHeight int64            `json:"snapshotHeight,omitempty"`
 	}{
-		Upgrades: chainNode.Status.Upgrades,
+		Upgrades:       chainNode.Status.Upgrades,
+		SnapshotHeight: getScheduledSnapshotHeight(chainNode),
 	}
 	b, err := json.Marshal(upgrades)
 	if err != nil {
@@ -118,8 +134,49 @@ func (r *Reconciler) ensureUpgradesConfig(ctx context.Context, chainNode *appsv1
 	return nil
 }
 
-func (r *Reconciler) requiresUpgrade(ctx context.Context, chainNode *appsv1.ChainNode) (bool, error) {
-	return nodeutils.NewClient(chainNode.GetNodeFQDN()).RequiresUpgrade(ctx)
+func (r *Reconciler) getRequiredUpgrade(ctx context.Context, chainNode *appsv1.ChainNode) (*nodeutils.RequiredUpgrade, error) {
+	return nodeutils.NewClient(chainNode.GetNodeFQDN()).GetRequiredUpgrade(ctx)
+}
+
+// addRequiredUpgrade adds an on-chain upgrade reported by node-utils to `.status.upgrades` when it is unknown or
+// still missing an image. This covers upgrades whose plan was never retrieved from the chain.
+func (r *Reconciler) addRequiredUpgrade(ctx context.Context, chainNode *appsv1.ChainNode, required *nodeutils.RequiredUpgrade) error {
+	for _, u := range chainNode.Status.Upgrades {
+		if u.Height == required.Height && u.Status != appsv1.UpgradeImageMissing {
+			return nil
+		}
+	}
+
+	upgrade := appsv1.Upgrade{
+		Height: required.Height,
+		Name:   required.Name,
+		Status: appsv1.UpgradeScheduled,
+		Source: appsv1.OnChainUpgrade,
+	}
+	resolvers, err := r.getUpgradeImageResolvers(chainNode)
+	if err != nil {
+		return err
+	}
+	plan := &upgradetypes.Plan{Name: required.Name, Height: required.Height, Info: required.Info}
+	image, err := resolveUpgradeImage(ctx, resolvers, plan)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "could not resolve upgrade image", "upgrade", required.Name, "height", required.Height)
+	}
+	if image != "" {
+		upgrade.Image = image
+	} else {
+		upgrade.Status = appsv1.UpgradeImageMissing
+	}
+
+	log.FromContext(ctx).Info("adding upgrade reported by node", "upgrade", required.Name, "height", required.Height, "image", image)
+	chainNode.Status.Upgrades = AddOrUpdateUpgrade(chainNode.Status.Upgrades, upgrade, chainNode.Status.LatestHeight)
+	sort.Slice(chainNode.Status.Upgrades, func(i, j int) bool {
+		return chainNode.Status.Upgrades[i].Height < chainNode.Status.Upgrades[j].Height
+	})
+	if err := r.Status().Update(ctx, chainNode); err != nil {
+		return err
+	}
+	return r.ensureUpgradesConfig(ctx, chainNode)
 }
 
 func (r *Reconciler) getUpgrade(chainNode *appsv1.ChainNode, height int64) *appsv1.Upgrade {
@@ -131,6 +188,15 @@ func (r *Reconciler) getUpgrade(chainNode *appsv1.ChainNode, height int64) *apps
 	return nil
 }
 
+func hasUpgradeSpec(chainNode *appsv1.ChainNode, height int64) bool {
+	for _, upgrade := range chainNode.Spec.App.Upgrades {
+		if upgrade.Height == height {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Reconciler) setUpgradeStatus(ctx context.Context, chainNode *appsv1.ChainNode, upgrade *appsv1.Upgrade, status appsv1.UpgradePhase) error {
 	logger := log.FromContext(ctx)
 
@@ -138,6 +204,7 @@ func (r *Reconciler) setUpgradeStatus(ctx context.Context, chainNode *appsv1.Cha
 		if u.Height == upgrade.Height {
 			chainNode.Status.Upgrades[i].Status = status
 			if status == appsv1.UpgradeCompleted {
+				chainNode.Status.Upgrades[i].AppliedAt = ptr.To(metav1.Now())
 				addUpgradeStatusCondition(chainNode, upgrade)
 			}
 			logger.Info("setting upgrade status", "height", upgrade.Height, "status", status)
@@ -151,6 +218,91 @@ func (r *Reconciler) setUpgradeStatus(ctx context.Context, chainNode *appsv1.Cha
 	return fmt.Errorf("cant update upgrade phase: upgrade not found")
 }
 
+// ensureUpgradeSnapshot takes a VolumeSnapshot of the node data before the upgrade image starts, and waits
+// for the snapshot to be cut. The snapshot is named after the upgrade height, so a retried upgrade reuses the
+// snapshot from the previous attempt. It does not carry the chain-node label, which keeps it out of retention,
+// integrity checks and tarball exports.
+func (r *Reconciler) ensureUpgradeSnapshot(ctx context.Context, chainNode *appsv1.ChainNode, upgrade *appsv1.Upgrade) error {
+	logger := log.FromContext(ctx)
+
+	snapshot := getUpgradeSnapshotSpec(chainNode, upgrade.Height)
+	key := client.ObjectKeyFromObject(snapshot)
+	if err := r.Get(ctx, key, &snapshotv1.VolumeSnapshot{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("creating pre-upgrade pvc snapshot", "snapshot", snapshot.GetName(), "height", upgrade.Height)
+		if err := r.Create(ctx, snapshot); err != nil {
+			return err
+		}
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, snapshotCheckPeriod, timeoutUpgradeSnapshot, true, func(ctx context.Context) (bool, error) {
+		if err := r.Get(ctx, key, snapshot); err != nil {
+			return false, err
+		}
+		return isSnapshotCut(snapshot), nil
+	})
+	if err != nil {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonUpgradeSnapshotFailed,
+			"Failed to snapshot data before upgrade at height %d: %v", upgrade.Height, err,
+		)
+		return fmt.Errorf("waiting for pre-upgrade snapshot %s: %w", snapshot.GetName(), err)
+	}
+
+	r.recorder.Eventf(chainNode,
+		corev1.EventTypeNormal,
+		appsv1.ReasonUpgradeSnapshotCreated,
+		"Created PVC snapshot %s before upgrade at height %d", snapshot.GetName(), upgrade.Height,
+	)
+
+	for i, u := range chainNode.Status.Upgrades {
+		if u.Height == upgrade.Height && u.Snapshot != snapshot.GetName() {
+			chainNode.Status.Upgrades[i].Snapshot = snapshot.GetName()
+			return r.Status().Update(ctx, chainNode)
+		}
+	}
+	return nil
+}
+
+func getUpgradeSnapshotSpec(chainNode *appsv1.ChainNode, height int64) *snapshotv1.VolumeSnapshot {
+	var snapshotClass *string
+	if chainNode.SnapshotsEnabled() {
+		snapshotClass = chainNode.Spec.Persistence.Snapshots.SnapshotClassName
+	}
+
+	return &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getUpgradeSnapshotName(chainNode, height),
+			Namespace: chainNode.GetNamespace(),
+			Annotations: map[string]string{
+				controllers.AnnotationDataHeight: strconv.FormatInt(chainNode.Status.LatestHeight, 10),
+			},
+			Labels: WithChainNodeLabels(chainNode, map[string]string{
+				controllers.LabelUpgradeHeight: strconv.FormatInt(height, 10),
+			}),
+		},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source: snapshotv1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: ptr.To(chainNode.GetName()),
+			},
+			VolumeSnapshotClassName: snapshotClass,
+		},
+	}
+}
+
+func getUpgradeSnapshotName(chainNode *appsv1.ChainNode, height int64) string {
+	return fmt.Sprintf("%s-upgrade-%d", chainNode.GetName(), height)
+}
+
+// isSnapshotCut returns true once the point-in-time copy of the volume was taken, which is when the
+// volume can safely be written again. The snapshot may still be uploading at this point.
+func isSnapshotCut(snapshot *snapshotv1.VolumeSnapshot) bool {
+	return isSnapshotReady(snapshot) || (snapshot.Status != nil && snapshot.Status.CreationTime != nil)
+}
+
 func (r *Reconciler) getGovUpgrades(ctx context.Context, chainNode *appsv1.ChainNode) ([]appsv1.Upgrade, error) {
 	c, err := r.getChainNodeClient(chainNode)
 	if err != nil {
@@ -166,17 +318,29 @@ func (r *Reconciler) getGovUpgrades(ctx context.Context, chainNode *appsv1.Chain
 	if plannedUpgrade != nil {
 		upgrade := appsv1.Upgrade{
 			Height: plannedUpgrade.Height,
+			Name:   plannedUpgrade.Name,
 			Status: appsv1.UpgradeScheduled,
 			Source: appsv1.OnChainUpgrade,
 		}
 
-		info := struct {
-			Binaries struct {
-				Docker string `json:"docker"`
-			} `json:"binaries"`
-		}{}
-		if err := json.Unmarshal([]byte(plannedUpgrade.Info), &info); err == nil && info.Binaries.Docker != "" {
-			upgrade.Image = info.Binaries.Docker
+		// Avoid resolving the image again once it is known
+		for _, existing := range chainNode.Status.Upgrades {
+			if existing.Height == plannedUpgrade.Height && existing.Status != appsv1.UpgradeImageMissing && existing.Image != "" {
+				upgrade.Image = existing.Image
+				return append(upgrades, upgrade), nil
+			}
+		}
+
+		resolvers, err := r.getUpgradeImageResolvers(chainNode)
+		if err != nil {
+			return nil, err
+		}
+		image, err := resolveUpgradeImage(ctx, resolvers, plannedUpgrade)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "could not resolve upgrade image", "upgrade", plannedUpgrade.Name, "height", plannedUpgrade.Height)
+		}
+		if image != "" {
+			upgrade.Image = image
 		} else {
 			upgrade.Status = appsv1.UpgradeImageMissing
 		}
@@ -195,12 +359,21 @@ func AddOrUpdateUpgrade(upgrades []appsv1.Upgrade, upgrade appsv1.Upgrade, curre
 				upgrades[i].Status = appsv1.UpgradeScheduled
 			}
 
+			// Reschedule a failed upgrade when a new image is provided for it
+			if u.Status == appsv1.UpgradeFailed && upgrade.Image != "" && upgrade.Image != u.Image && !slices.Contains(u.FailedImages, upgrade.Image) {
+				upgrades[i].Image = upgrade.Image
+				upgrades[i].Status = appsv1.UpgradeScheduled
+			}
+
 			// If we are updating an upgrade with a past height, and it was not completed, lets set it
 			// as skipped
 			if u.Status != appsv1.UpgradeCompleted && u.Height < currentHeight {
 				upgrades[i].Status = appsv1.UpgradeSkipped
 			}
 
+			if upgrade.Name != "" {
+				upgrades[i].Name = upgrade.Name
+			}
 			upgrades[i].Source = upgrade.Source
 			return upgrades
 		}
@@ -210,14 +383,10 @@ func AddOrUpdateUpgrade(upgrades []appsv1.Upgrade, upgrade appsv1.Upgrade, curre
 }
 
 func addUpgradeStatusCondition(chainNode *appsv1.ChainNode, upgrade *appsv1.Upgrade) {
-	if chainNode.Status.Conditions == nil {
-		chainNode.Status.Conditions = make([]metav1.Condition, 0)
-	}
-	chainNode.Status.Conditions = append(chainNode.Status.Conditions, metav1.Condition{
-		Type:               appsv1.ConditionUpgrade,
-		Status:             metav1.ConditionTrue,
-		LastTransitionTime: metav1.Now(),
-		Reason:             appsv1.ReasonUpgradeSuccess,
-		Message:            fmt.Sprintf("Successfully upgraded node to image %s", upgrade.Image),
+	apiMeta.SetStatusCondition(&chainNode.Status.Conditions, metav1.Condition{
+		Type:    appsv1.ConditionUpgrade,
+		Status:  metav1.ConditionTrue,
+		Reason:  appsv1.ReasonUpgradeSuccess,
+		Message: fmt.Sprintf("Successfully upgraded node to image %s", upgrade.Image),
 	})
 }
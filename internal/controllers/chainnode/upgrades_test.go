@@ -1,9 +1,21 @@
 package chainnode
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
 )
 
 func TestGetUpgrade(t *testing.T) {
@@ -144,3 +156,76 @@ func TestAddOrUpdateUpgrade(t *testing.T) {
 		})
 	}
 }
+
+func upgradeSnapshotTestChainNode() *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node", Namespace: "default"},
+		Spec: appsv1.ChainNodeSpec{
+			Persistence: &appsv1.Persistence{
+				SnapshotBeforeUpgrade: ptr.To(true),
+				Snapshots: &appsv1.VolumeSnapshotsConfig{
+					Frequency:         "24h",
+					Retention:         ptr.To("72h"),
+					SnapshotClassName: ptr.To("csi-snapclass"),
+				},
+			},
+		},
+		Status: appsv1.ChainNodeStatus{
+			LatestHeight: 999,
+			Upgrades:     []appsv1.Upgrade{{Height: 1000, Image: "app:v2", Status: appsv1.UpgradeOnGoing}},
+		},
+	}
+}
+
+func TestGetUpgradeSnapshotSpec(t *testing.T) {
+	snapshot := getUpgradeSnapshotSpec(upgradeSnapshotTestChainNode(), 1000)
+
+	assert.Equal(t, "node-upgrade-1000", snapshot.GetName())
+	assert.Equal(t, "1000", snapshot.Labels[controllers.LabelUpgradeHeight])
+	assert.Equal(t, "999", snapshot.Annotations[controllers.AnnotationDataHeight])
+	assert.Equal(t, ptr.To("csi-snapclass"), snapshot.Spec.VolumeSnapshotClassName)
+	assert.Equal(t, ptr.To("node"), snapshot.Spec.Source.PersistentVolumeClaimName)
+
+	// Must stay out of regular snapshot processing and retention
+	assert.NotContains(t, snapshot.Labels, controllers.LabelChainNode)
+	assert.NotContains(t, snapshot.Annotations, controllers.AnnotationSnapshotRetention)
+	assert.NotContains(t, snapshot.Annotations, controllers.AnnotationPvcSnapshotReady)
+}
+
+func TestEnsureUpgradeSnapshotReusesExistingSnapshot(t *testing.T) {
+	chainNode := upgradeSnapshotTestChainNode()
+	existing := getUpgradeSnapshotSpec(chainNode, 1000)
+	existing.Status = &snapshotv1.VolumeSnapshotStatus{CreationTime: ptr.To(metav1.Now())}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, snapshotv1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(chainNode, existing).
+			WithStatusSubresource(&appsv1.ChainNode{}).
+			Build(),
+		Scheme:   scheme,
+		recorder: recorder,
+	}
+
+	require.NoError(t, r.ensureUpgradeSnapshot(context.Background(), chainNode, &chainNode.Status.Upgrades[0]))
+	assert.Equal(t, "node-upgrade-1000", chainNode.Status.Upgrades[0].Snapshot)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeSnapshotCreated)
+}
+
+func TestEnsureUpgradesDropsRemovedManualUpgrades(t *testing.T) {
+	chainNode := upgradeRollbackTestChainNode(time.Now())
+	chainNode.Spec.App.Upgrades = []appsv1.UpgradeSpec{{Height: 1000, Image: "app:v2"}}
+	chainNode.Status.Upgrades = append(chainNode.Status.Upgrades,
+		appsv1.Upgrade{Height: 2000, Image: "app:v3", Status: appsv1.UpgradeScheduled, Source: appsv1.ManualUpgrade},
+		appsv1.Upgrade{Height: 3000, Image: "app:v4", Status: appsv1.UpgradeScheduled, Source: appsv1.OnChainUpgrade},
+	)
+	r, _ := upgradeRollbackTestReconciler(t, chainNode)
+
+	require.NoError(t, r.ensureUpgrades(context.Background(), chainNode, false))
+	require.Len(t, chainNode.Status.Upgrades, 2)
+	assert.Equal(t, int64(1000), chainNode.Status.Upgrades[0].Height)
+	assert.Equal(t, int64(3000), chainNode.Status.Upgrades[1].Height)
+}
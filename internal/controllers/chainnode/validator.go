@@ -5,8 +5,13 @@ import (
 	"context"
 	"fmt"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -29,20 +34,7 @@ func (r *Reconciler) createValidator(ctx context.Context, app *chainutils.App, c
 		GasPrices:               chainNode.Spec.Validator.CreateValidator.GasPrices,
 	}
 
-	accountSecret := &corev1.Secret{}
-	if err := r.Get(ctx, types.NamespacedName{
-		Namespace: chainNode.GetNamespace(),
-		Name:      chainNode.Spec.Validator.GetAccountSecretName(chainNode),
-	}, accountSecret); err != nil {
-		return err
-	}
-
-	account, err := chainutils.AccountFromMnemonic(
-		string(accountSecret.Data[MnemonicKey]),
-		chainNode.Spec.Validator.GetAccountPrefix(),
-		chainNode.Spec.Validator.GetValPrefix(),
-		chainNode.Spec.Validator.GetAccountHDPath(),
-	)
+	account, err := r.getValidatorAccount(ctx, chainNode)
 	if err != nil {
 		return err
 	}
@@ -56,13 +48,14 @@ func (r *Reconciler) createValidator(ctx context.Context, app *chainutils.App, c
 		nodeInfo.Identity = chainNode.Spec.Validator.Info.Identity
 	}
 
-	if err := app.CreateValidator(ctx,
+	hash, err := app.CreateValidator(ctx,
 		chainNode.Status.PubKey,
 		account,
 		nodeInfo,
 		params,
 		fmt.Sprintf("tcp://%s:%d", chainNode.GetNodeFQDN(), chainutils.RpcPort),
-	); err != nil {
+	)
+	if err != nil {
 		r.recorder.Eventf(chainNode,
 			corev1.EventTypeWarning,
 			appsv1.ReasonCreateValidatorFailure,
@@ -73,10 +66,28 @@ func (r *Reconciler) createValidator(ctx context.Context, app *chainutils.App, c
 	r.recorder.Eventf(chainNode,
 		corev1.EventTypeNormal,
 		appsv1.ReasonCreateValidatorSuccess,
-		"successfully submited create-validator tx")
+		"successfully submited create-validator tx %s", hash)
 	return nil
 }
 
+// getValidatorAccount loads the validator account from the mnemonic secret created in ensureAccount.
+func (r *Reconciler) getValidatorAccount(ctx context.Context, chainNode *appsv1.ChainNode) (*chainutils.Account, error) {
+	accountSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Namespace: chainNode.GetNamespace(),
+		Name:      chainNode.Spec.Validator.GetAccountSecretName(chainNode),
+	}, accountSecret); err != nil {
+		return nil, err
+	}
+
+	return chainutils.AccountFromMnemonic(
+		string(accountSecret.Data[MnemonicKey]),
+		chainNode.Spec.Validator.GetAccountPrefix(),
+		chainNode.Spec.Validator.GetValPrefix(),
+		chainNode.Spec.Validator.GetAccountHDPath(),
+	)
+}
+
 func (r *Reconciler) updateValidatorStatus(ctx context.Context, chainNode *appsv1.ChainNode) error {
 	logger := log.FromContext(ctx)
 
@@ -140,7 +151,11 @@ func (r *Reconciler) updateValidatorStatus(ctx context.Context, chainNode *appsv
 
 	validatorStatus := getValidatorStatus(validator.Status)
 
-	if !chainNode.Status.Validator ||
+	signingInfoChanged := r.updateSigningInfo(ctx, client, chainNode, pk.Address())
+	missedBlocksChanged := r.updateMissedBlocksCondition(chainNode)
+
+	if signingInfoChanged || missedBlocksChanged ||
+		!chainNode.Status.Validator ||
 		chainNode.Status.ValidatorAddress == "" ||
 		chainNode.Status.ValidatorStatus != validatorStatus ||
 		chainNode.Status.AccountAddress != accountAddr ||
@@ -176,6 +191,100 @@ func (r *Reconciler) updateValidatorStatus(ctx context.Context, chainNode *appsv
 	return nil
 }
 
+// updateSigningInfo queries the slashing signing info of the validator and records it in status.
+// It returns true if status was changed. Query failures are only logged, as signing info does not
+// exist for validators that were never bonded.
+func (r *Reconciler) updateSigningInfo(ctx context.Context, client *chainutils.Client, chainNode *appsv1.ChainNode, consAddr []byte) bool {
+	logger := log.FromContext(ctx)
+
+	consAddress, err := sdk.Bech32ifyAddressBytes(chainNode.Spec.Validator.GetConsPrefix(), consAddr)
+	if err != nil {
+		logger.Error(err, "could not encode consensus address")
+		return false
+	}
+
+	info, err := client.QuerySigningInfo(ctx, consAddress)
+	if err != nil {
+		logger.V(1).Info("could not get signing info", "error", err)
+		return false
+	}
+
+	params, err := client.QuerySlashingParams(ctx)
+	if err != nil {
+		logger.V(1).Info("could not get slashing params", "error", err)
+		return false
+	}
+
+	signingInfo := getSigningInfo(info, params.SignedBlocksWindow)
+	if apiequality.Semantic.DeepEqual(chainNode.Status.SigningInfo, signingInfo) {
+		return false
+	}
+
+	if signingInfo.Tombstoned && (chainNode.Status.SigningInfo == nil || !chainNode.Status.SigningInfo.Tombstoned) {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonValidatorTombstoned,
+			"Validator is tombstoned",
+		)
+	}
+
+	chainNode.Status.SigningInfo = signingInfo
+	return true
+}
+
+// updateMissedBlocksCondition sets the missed blocks condition according to the configured threshold,
+// emitting an event whenever the threshold is crossed. It returns true if conditions were changed.
+func (r *Reconciler) updateMissedBlocksCondition(chainNode *appsv1.ChainNode) bool {
+	cfg := chainNode.Spec.Validator.MissedBlocks
+	if cfg == nil || chainNode.Status.SigningInfo == nil {
+		return apiMeta.RemoveStatusCondition(&chainNode.Status.Conditions, appsv1.ConditionValidatorMissedBlocks)
+	}
+
+	missed := chainNode.Status.SigningInfo.MissedBlocksCounter
+	wasAbove := apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionValidatorMissedBlocks)
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionValidatorMissedBlocks,
+		Status:             metav1.ConditionFalse,
+		Reason:             appsv1.ReasonMissedBlocksBelowThreshold,
+		Message:            fmt.Sprintf("validator missed %d blocks (threshold: %d)", missed, cfg.Threshold),
+		ObservedGeneration: chainNode.Generation,
+	}
+
+	if missed >= cfg.Threshold {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = appsv1.ReasonMissedBlocksAboveThreshold
+		if !wasAbove {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonValidatorMissingBlocks,
+				"Validator missed %d blocks in the current signing window (threshold: %d)", missed, cfg.Threshold,
+			)
+		}
+	} else if wasAbove {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeNormal,
+			appsv1.ReasonValidatorSigningRecovered,
+			"Validator missed blocks are back below threshold (%d/%d)", missed, cfg.Threshold,
+		)
+	}
+
+	return apiMeta.SetStatusCondition(&chainNode.Status.Conditions, condition)
+}
+
+func getSigningInfo(info *slashingtypes.ValidatorSigningInfo, signedBlocksWindow int64) *appsv1.ValidatorSigningInfo {
+	signingInfo := &appsv1.ValidatorSigningInfo{
+		MissedBlocksCounter: info.MissedBlocksCounter,
+		IndexOffset:         info.IndexOffset,
+		SignedBlocksWindow:  signedBlocksWindow,
+		Tombstoned:          info.Tombstoned,
+	}
+	if info.JailedUntil.Unix() > 0 {
+		signingInfo.JailedUntil = &metav1.Time{Time: info.JailedUntil}
+	}
+	return signingInfo
+}
+
 func getValidatorStatus(status stakingTypes.BondStatus) appsv1.ValidatorStatus {
 	switch status {
 	case stakingTypes.Bonded:
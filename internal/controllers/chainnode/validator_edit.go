@@ -0,0 +1,206 @@
+package chainnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	corev1 "k8s.io/api/core/v1"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+)
+
+const (
+	// commissionChangePeriod is the minimum time between commission rate changes enforced by x/staking.
+	commissionChangePeriod = 24 * time.Hour
+
+	// validatorEditCooldown is the minimum time between edit-validator transactions, giving the
+	// previous one time to be included in a block before drift is evaluated again.
+	validatorEditCooldown = 5 * time.Minute
+)
+
+// reconcileValidatorInfo submits an edit-validator transaction when the description or commission
+// rate of the on-chain validator drifted from the ones in spec.
+func (r *Reconciler) reconcileValidatorInfo(ctx context.Context, app *chainutils.App, chainNode *appsv1.ChainNode) error {
+	logger := log.FromContext(ctx)
+
+	if last := chainNode.Status.LastValidatorEdit; last != nil && time.Since(last.Time.Time) < validatorEditCooldown {
+		return nil
+	}
+
+	client, err := r.getChainNodeClient(chainNode)
+	if err != nil {
+		return err
+	}
+
+	validator, err := client.QueryValidator(ctx, chainNode.Status.ValidatorAddress)
+	if err != nil {
+		return err
+	}
+
+	edit, err := getValidatorEdit(chainNode, validator, time.Now())
+	if err != nil {
+		logger.Error(err, "could not reconcile validator info")
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonEditValidatorFailure,
+			"failed to edit-validator: %s", err.Error())
+		return nil
+	}
+	if r.updateCommissionCondition(chainNode, validator) {
+		if err := r.Status().Update(ctx, chainNode); err != nil {
+			return err
+		}
+	}
+	if edit == nil {
+		return nil
+	}
+	if r.validatorAccountBusy(chainNode) {
+		logger.V(1).Info("waiting for previous validator tx before submitting edit-validator tx")
+		return nil
+	}
+
+	account, err := r.getValidatorAccount(ctx, chainNode)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("submitting edit-validator tx")
+	hash, err := app.EditValidator(ctx, account, edit,
+		&chainutils.Params{
+			ChainID:   chainNode.Status.ChainID,
+			GasPrices: chainNode.Spec.Validator.CreateValidator.GasPrices,
+		},
+		fmt.Sprintf("tcp://%s:%d", chainNode.GetNodeFQDN(), chainutils.RpcPort),
+	)
+	if err != nil {
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonEditValidatorFailure,
+			"failed to edit-validator: %s", err.Error())
+		if r.setValidatorEditCondition(chainNode, err) {
+			if updateErr := r.Status().Update(ctx, chainNode); updateErr != nil {
+				return errors.Join(err, updateErr)
+			}
+		}
+		return err
+	}
+	r.trackValidatorTx(chainNode, validatorTx{hash: hash, kind: validatorTxEdit})
+
+	r.recorder.Eventf(chainNode,
+		corev1.EventTypeNormal,
+		appsv1.ReasonEditValidatorSuccess,
+		"successfully submitted edit-validator tx %s", hash)
+
+	chainNode.Status.LastValidatorEdit = &appsv1.ValidatorEditStatus{
+		Moniker:        edit.Moniker,
+		Details:        edit.Details,
+		Website:        edit.Website,
+		Identity:       edit.Identity,
+		CommissionRate: edit.CommissionRate,
+		Time:           metav1.Now(),
+	}
+	return r.Status().Update(ctx, chainNode)
+}
+
+// updateCommissionCondition sets the CommissionAboveMaxRate condition, emitting an event when the commission
+// rate in spec goes above the max rate of the validator. Returns true if the condition changed.
+func (r *Reconciler) updateCommissionCondition(chainNode *appsv1.ChainNode, validator *stakingTypes.Validator) bool {
+	wasAbove := apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionCommissionAboveMaxRate)
+	maxRate := validator.Commission.CommissionRates.MaxRate
+
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionCommissionAboveMaxRate,
+		Status:             metav1.ConditionFalse,
+		Reason:             appsv1.ReasonRateWithinMaxRate,
+		Message:            fmt.Sprintf("commission rate is within max rate %s", maxRate),
+		ObservedGeneration: chainNode.Generation,
+	}
+
+	if commissionAboveMaxRate(chainNode, validator) {
+		rate := chainNode.Spec.Validator.GetCommissionRate()
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = appsv1.ReasonRateAboveMaxRate
+		condition.Message = fmt.Sprintf("commission rate %s exceeds max rate %s, only the description is reconciled", rate, maxRate)
+		if !wasAbove {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonEditValidatorFailure,
+				"Commission rate %s exceeds max rate %s of the validator and will not be applied", rate, maxRate,
+			)
+		}
+	}
+
+	return apiMeta.SetStatusCondition(&chainNode.Status.Conditions, condition)
+}
+
+// commissionAboveMaxRate returns true if the commission rate in spec is above the max rate of the validator.
+func commissionAboveMaxRate(chainNode *appsv1.ChainNode, validator *stakingTypes.Validator) bool {
+	desiredRate, err := sdk.NewDecFromStr(chainNode.Spec.Validator.GetCommissionRate())
+	return err == nil && desiredRate.GT(validator.Commission.CommissionRates.MaxRate)
+}
+
+// getValidatorEdit compares the on-chain validator with the spec and returns the fields that must
+// be changed, or nil if there is nothing to change. Description fields not set in spec are ignored.
+// Commission rate changes are limited by the max change rate of the validator and are only applied
+// once every 24h, so reaching the desired rate may take several edits. A commission rate above the
+// max rate of the validator can never be applied, so it is left out of the edit.
+func getValidatorEdit(chainNode *appsv1.ChainNode, validator *stakingTypes.Validator, now time.Time) (*chainutils.ValidatorEdit, error) {
+	edit := &chainutils.ValidatorEdit{}
+	changed := false
+
+	diff := func(desired *string, current string) *string {
+		if desired == nil || *desired == current {
+			return nil
+		}
+		changed = true
+		return desired
+	}
+
+	moniker := chainNode.GetMoniker()
+	edit.Moniker = diff(&moniker, validator.Description.Moniker)
+	if info := chainNode.Spec.Validator.Info; info != nil {
+		edit.Details = diff(info.Details, validator.Description.Details)
+		edit.Website = diff(info.Website, validator.Description.Website)
+		edit.Identity = diff(info.Identity, validator.Description.Identity)
+	}
+
+	desiredRate, err := sdk.NewDecFromStr(chainNode.Spec.Validator.GetCommissionRate())
+	if err != nil {
+		return nil, fmt.Errorf("parsing commission rate: %w", err)
+	}
+
+	rates := validator.Commission.CommissionRates
+	switch {
+	case desiredRate.Equal(rates.Rate):
+	case desiredRate.GT(rates.MaxRate):
+		// Reported through the CommissionAboveMaxRate condition
+	case now.Sub(validator.Commission.UpdateTime) < commissionChangePeriod:
+		// x/staking rejects a second commission change within 24h. The description can still be
+		// updated, and the commission rate is reconciled once the period is over.
+	default:
+		nextRate := desiredRate
+		if desiredRate.Sub(rates.Rate).Abs().GT(rates.MaxChangeRate) {
+			if desiredRate.GT(rates.Rate) {
+				nextRate = rates.Rate.Add(rates.MaxChangeRate)
+			} else {
+				nextRate = rates.Rate.Sub(rates.MaxChangeRate)
+			}
+		}
+		rate := nextRate.String()
+		edit.CommissionRate = &rate
+		changed = true
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return edit, nil
+}
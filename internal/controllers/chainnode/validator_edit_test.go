@@ -0,0 +1,134 @@
+package chainnode
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+func TestGetValidatorEdit(t *testing.T) {
+	now := time.Now()
+
+	newChainNode := func(info *appsv1.ValidatorInfo, rate string) *appsv1.ChainNode {
+		return &appsv1.ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "validator"},
+			Spec: appsv1.ChainNodeSpec{
+				Validator: &appsv1.ValidatorConfig{
+					Info:            info,
+					CreateValidator: &appsv1.CreateValidatorConfig{CommissionRate: ptr.To(rate)},
+				},
+			},
+		}
+	}
+	newValidator := func(rate string, updated time.Time) *stakingTypes.Validator {
+		return &stakingTypes.Validator{
+			Description: stakingTypes.Description{Moniker: "validator", Website: "https://example.com"},
+			Commission: stakingTypes.Commission{
+				CommissionRates: stakingTypes.CommissionRates{
+					Rate:          sdk.MustNewDecFromStr(rate),
+					MaxRate:       sdk.MustNewDecFromStr("0.2"),
+					MaxChangeRate: sdk.MustNewDecFromStr("0.01"),
+				},
+				UpdateTime: updated,
+			},
+		}
+	}
+
+	t.Run("no drift", func(t *testing.T) {
+		edit, err := getValidatorEdit(newChainNode(nil, "0.1"), newValidator("0.1", now), now)
+		require.NoError(t, err)
+		assert.Nil(t, edit)
+	})
+
+	t.Run("description drift", func(t *testing.T) {
+		chainNode := newChainNode(&appsv1.ValidatorInfo{
+			Moniker: ptr.To("new-moniker"),
+			Website: ptr.To("https://example.com"),
+			Details: ptr.To("new details"),
+		}, "0.1")
+		edit, err := getValidatorEdit(chainNode, newValidator("0.1", now), now)
+		require.NoError(t, err)
+		require.NotNil(t, edit)
+		assert.Equal(t, ptr.To("new-moniker"), edit.Moniker)
+		assert.Equal(t, ptr.To("new details"), edit.Details)
+		assert.Nil(t, edit.Website)
+		assert.Nil(t, edit.Identity)
+		assert.Nil(t, edit.CommissionRate)
+	})
+
+	t.Run("commission is limited by max change rate", func(t *testing.T) {
+		edit, err := getValidatorEdit(newChainNode(nil, "0.15"), newValidator("0.1", now.Add(-25*time.Hour)), now)
+		require.NoError(t, err)
+		require.NotNil(t, edit)
+		assert.Equal(t, sdk.MustNewDecFromStr("0.11").String(), *edit.CommissionRate)
+
+		edit, err = getValidatorEdit(newChainNode(nil, "0.05"), newValidator("0.1", now.Add(-25*time.Hour)), now)
+		require.NoError(t, err)
+		assert.Equal(t, sdk.MustNewDecFromStr("0.09").String(), *edit.CommissionRate)
+
+		edit, err = getValidatorEdit(newChainNode(nil, "0.105"), newValidator("0.1", now.Add(-25*time.Hour)), now)
+		require.NoError(t, err)
+		assert.Equal(t, sdk.MustNewDecFromStr("0.105").String(), *edit.CommissionRate)
+	})
+
+	t.Run("commission is not changed within 24h", func(t *testing.T) {
+		edit, err := getValidatorEdit(newChainNode(nil, "0.11"), newValidator("0.1", now.Add(-time.Hour)), now)
+		require.NoError(t, err)
+		assert.Nil(t, edit)
+	})
+
+	t.Run("commission above max rate", func(t *testing.T) {
+		edit, err := getValidatorEdit(newChainNode(nil, "0.3"), newValidator("0.1", now.Add(-25*time.Hour)), now)
+		require.NoError(t, err)
+		assert.Nil(t, edit)
+
+		// Description is still reconciled
+		chainNode := newChainNode(&appsv1.ValidatorInfo{Details: ptr.To("new details")}, "0.3")
+		edit, err = getValidatorEdit(chainNode, newValidator("0.1", now.Add(-25*time.Hour)), now)
+		require.NoError(t, err)
+		require.NotNil(t, edit)
+		assert.Equal(t, ptr.To("new details"), edit.Details)
+		assert.Nil(t, edit.CommissionRate)
+	})
+}
+
+func TestUpdateCommissionCondition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+	chainNode := &appsv1.ChainNode{
+		Spec: appsv1.ChainNodeSpec{
+			Validator: &appsv1.ValidatorConfig{
+				CreateValidator: &appsv1.CreateValidatorConfig{CommissionRate: ptr.To("0.1")},
+			},
+		},
+	}
+	validator := &stakingTypes.Validator{
+		Commission: stakingTypes.Commission{
+			CommissionRates: stakingTypes.CommissionRates{MaxRate: sdk.MustNewDecFromStr("0.2")},
+		},
+	}
+
+	// Rate within max rate: condition is set to false without events.
+	assert.True(t, r.updateCommissionCondition(chainNode, validator))
+	assert.True(t, apiMeta.IsStatusConditionFalse(chainNode.Status.Conditions, appsv1.ConditionCommissionAboveMaxRate))
+	assert.Empty(t, recorder.Events)
+
+	// Going above max rate emits a warning once.
+	chainNode.Spec.Validator.CreateValidator.CommissionRate = ptr.To("0.3")
+	assert.True(t, r.updateCommissionCondition(chainNode, validator))
+	assert.True(t, apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionCommissionAboveMaxRate))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonEditValidatorFailure)
+
+	assert.False(t, r.updateCommissionCondition(chainNode, validator))
+	assert.Empty(t, recorder.Events)
+}
@@ -2,9 +2,14 @@ package chainnode
 
 import (
 	"testing"
+	"time"
 
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/assert"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
 	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
 )
@@ -49,3 +54,63 @@ func TestGetValidatorStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSigningInfo(t *testing.T) {
+	info := &slashingtypes.ValidatorSigningInfo{
+		IndexOffset:         120,
+		MissedBlocksCounter: 7,
+		JailedUntil:         time.Unix(0, 0).UTC(),
+	}
+	got := getSigningInfo(info, 10000)
+	assert.Equal(t, &appsv1.ValidatorSigningInfo{
+		MissedBlocksCounter: 7,
+		IndexOffset:         120,
+		SignedBlocksWindow:  10000,
+	}, got)
+
+	jailedUntil := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	info.JailedUntil = jailedUntil
+	info.Tombstoned = true
+	got = getSigningInfo(info, 10000)
+	assert.True(t, got.Tombstoned)
+	assert.Equal(t, &metav1.Time{Time: jailedUntil}, got.JailedUntil)
+}
+
+func TestUpdateMissedBlocksCondition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+	chainNode := &appsv1.ChainNode{
+		Spec: appsv1.ChainNodeSpec{
+			Validator: &appsv1.ValidatorConfig{MissedBlocks: &appsv1.MissedBlocksConfig{Threshold: 10}},
+		},
+		Status: appsv1.ChainNodeStatus{
+			SigningInfo: &appsv1.ValidatorSigningInfo{MissedBlocksCounter: 2},
+		},
+	}
+
+	// Below threshold: condition is set to false without events.
+	assert.True(t, r.updateMissedBlocksCondition(chainNode))
+	assert.True(t, apiMeta.IsStatusConditionFalse(chainNode.Status.Conditions, appsv1.ConditionValidatorMissedBlocks))
+	assert.Empty(t, recorder.Events)
+
+	// Crossing the threshold emits a warning once.
+	chainNode.Status.SigningInfo.MissedBlocksCounter = 10
+	assert.True(t, r.updateMissedBlocksCondition(chainNode))
+	assert.True(t, apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionValidatorMissedBlocks))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonValidatorMissingBlocks)
+
+	chainNode.Status.SigningInfo.MissedBlocksCounter = 12
+	r.updateMissedBlocksCondition(chainNode)
+	assert.Empty(t, recorder.Events)
+
+	// Recovering emits a normal event.
+	chainNode.Status.SigningInfo.MissedBlocksCounter = 3
+	assert.True(t, r.updateMissedBlocksCondition(chainNode))
+	assert.True(t, apiMeta.IsStatusConditionFalse(chainNode.Status.Conditions, appsv1.ConditionValidatorMissedBlocks))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonValidatorSigningRecovered)
+
+	// Removing the config removes the condition.
+	chainNode.Spec.Validator.MissedBlocks = nil
+	assert.True(t, r.updateMissedBlocksCondition(chainNode))
+	assert.Nil(t, apiMeta.FindStatusCondition(chainNode.Status.Conditions, appsv1.ConditionValidatorMissedBlocks))
+}
@@ -0,0 +1,161 @@
+package chainnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+)
+
+const (
+	// validatorTxTimeout is the time a transaction sent from the validator account has to be included in a
+	// block before the account is used again anyway, e.g. when it was dropped from the mempool or the tx
+	// indexer of the node is disabled.
+	validatorTxTimeout = 2 * time.Minute
+
+	// validatorTxCheckPeriod is the time after which a transaction sent from the validator account is
+	// checked again.
+	validatorTxCheckPeriod = 10 * time.Second
+)
+
+// validatorTxKind identifies what a transaction sent from the validator account does.
+type validatorTxKind string
+
+const (
+	validatorTxEdit   validatorTxKind = "edit-validator"
+	validatorTxUnjail validatorTxKind = "unjail"
+	validatorTxVote   validatorTxKind = "vote"
+)
+
+// validatorTx is a transaction sent from the validator account that was not seen in a block yet.
+type validatorTx struct {
+	hash string
+	kind validatorTxKind
+
+	// proposalID is the proposal voted on by validatorTxVote transactions.
+	proposalID uint64
+}
+
+func validatorTxKey(chainNode *appsv1.ChainNode) string {
+	return client.ObjectKeyFromObject(chainNode).String()
+}
+
+// trackValidatorTx records a transaction sent from the validator account, so that no other transaction is
+// sent from it until this one is included in a block.
+func (r *Reconciler) trackValidatorTx(chainNode *appsv1.ChainNode, tx validatorTx) {
+	r.validatorTxs.Set(validatorTxKey(chainNode), tx, validatorTxTimeout)
+}
+
+// hasPendingValidatorTx returns true if a transaction sent from the validator account was not seen in a
+// block yet.
+func (r *Reconciler) hasPendingValidatorTx(chainNode *appsv1.ChainNode) bool {
+	return r.validatorTxs.Has(validatorTxKey(chainNode))
+}
+
+// validatorAccountBusy returns true while a transaction sent from the validator account might not be
+// included in a block yet. Transactions are signed with the account sequence of the last block, so sending
+// another one before that would be rejected with a sequence mismatch. Auto-restake runs keep the account
+// busy until they finish, as their pods may broadcast at any time.
+func (r *Reconciler) validatorAccountBusy(chainNode *appsv1.ChainNode) bool {
+	return chainNode.Status.RestakeInProgress != nil || r.hasPendingValidatorTx(chainNode)
+}
+
+// checkValidatorTx checks whether the last transaction sent from the validator account was included in a
+// block, and reports its result once it was.
+func (r *Reconciler) checkValidatorTx(ctx context.Context, chainNode *appsv1.ChainNode) error {
+	item := r.validatorTxs.Get(validatorTxKey(chainNode))
+	if item == nil {
+		return nil
+	}
+	tx := item.Value()
+
+	nodeClient, err := r.getChainNodeClient(chainNode)
+	if err != nil {
+		return err
+	}
+
+	included, err := nodeClient.TxIncluded(ctx, tx.hash)
+	switch {
+	case errors.Is(err, chainutils.ErrTxIndexDisabled):
+		// The transaction cannot be looked up, so the account is released once validatorTxTimeout expires.
+		return nil
+	case errors.Is(err, chainutils.ErrTxFailed):
+		// Keep err to report it below
+	case err != nil:
+		return err
+	case !included:
+		return nil
+	}
+
+	r.validatorTxs.Delete(validatorTxKey(chainNode))
+	log.FromContext(ctx).V(1).Info("validator tx included in a block", "kind", tx.kind, "hash", tx.hash)
+	if r.reportValidatorTx(chainNode, tx, err) {
+		return r.Status().Update(ctx, chainNode)
+	}
+	return nil
+}
+
+// reportValidatorTx emits an event when a transaction sent from the validator account failed on execution,
+// and updates the status of the operation it belongs to. Returns true if status changed.
+func (r *Reconciler) reportValidatorTx(chainNode *appsv1.ChainNode, tx validatorTx, txErr error) bool {
+	switch tx.kind {
+	case validatorTxEdit:
+		if txErr != nil {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonEditValidatorFailure,
+				"failed to edit-validator: %s", txErr.Error())
+		}
+		return r.setValidatorEditCondition(chainNode, txErr)
+
+	case validatorTxUnjail:
+		if txErr != nil {
+			r.recorder.Eventf(chainNode,
+				corev1.EventTypeWarning,
+				appsv1.ReasonUnjailFailure,
+				"failed to unjail validator: %s", txErr.Error())
+		}
+
+	case validatorTxVote:
+		if txErr == nil {
+			return false
+		}
+		r.recorder.Eventf(chainNode,
+			corev1.EventTypeWarning,
+			appsv1.ReasonProposalVoteFailure,
+			"failed to vote on proposal %d: %s", tx.proposalID, txErr.Error())
+		// The vote is submitted again once proposalVoteCooldown expires
+		if proposal := getTrackedProposal(chainNode, tx.proposalID); proposal != nil && proposal.VoteError != txErr.Error() {
+			proposal.VoteError = txErr.Error()
+			return true
+		}
+	}
+	return false
+}
+
+// setValidatorEditCondition sets the ValidatorEditFailed condition from the result of the last edit-validator
+// transaction. Returns true if the condition changed.
+func (r *Reconciler) setValidatorEditCondition(chainNode *appsv1.ChainNode, txErr error) bool {
+	condition := metav1.Condition{
+		Type:               appsv1.ConditionValidatorEditFailed,
+		Status:             metav1.ConditionFalse,
+		Reason:             appsv1.ReasonEditValidatorSuccess,
+		Message:            "last edit-validator tx was included in a block",
+		ObservedGeneration: chainNode.Generation,
+	}
+	if txErr != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = appsv1.ReasonEditValidatorFailure
+		condition.Message = fmt.Sprintf("last edit-validator tx failed: %v", txErr)
+	}
+	return apiMeta.SetStatusCondition(&chainNode.Status.Conditions, condition)
+}
@@ -0,0 +1,59 @@
+package chainnode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/stretchr/testify/assert"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+func TestValidatorAccountBusy(t *testing.T) {
+	r := &Reconciler{validatorTxs: ttlcache.New(ttlcache.WithDisableTouchOnHit[string, validatorTx]())}
+	chainNode := &appsv1.ChainNode{ObjectMeta: metav1.ObjectMeta{Name: "validator", Namespace: "default"}}
+	other := &appsv1.ChainNode{ObjectMeta: metav1.ObjectMeta{Name: "validator", Namespace: "other"}}
+
+	assert.False(t, r.validatorAccountBusy(chainNode))
+
+	r.trackValidatorTx(chainNode, validatorTx{hash: "ABCDEF", kind: validatorTxEdit})
+	assert.True(t, r.validatorAccountBusy(chainNode))
+	assert.False(t, r.validatorAccountBusy(other))
+
+	// Auto-restake runs keep the account busy while they are in progress
+	other.Status.RestakeInProgress = &appsv1.RestakeRun{Time: metav1.Now()}
+	assert.True(t, r.validatorAccountBusy(other))
+	assert.False(t, r.hasPendingValidatorTx(other))
+}
+
+func TestReportValidatorTx(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+	chainNode := &appsv1.ChainNode{}
+
+	assert.True(t, r.reportValidatorTx(chainNode, validatorTx{hash: "ABCDEF", kind: validatorTxEdit}, errors.New("transaction failed")))
+	assert.True(t, apiMeta.IsStatusConditionTrue(chainNode.Status.Conditions, appsv1.ConditionValidatorEditFailed))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonEditValidatorFailure)
+
+	assert.True(t, r.reportValidatorTx(chainNode, validatorTx{hash: "ABCDEF", kind: validatorTxEdit}, nil))
+	assert.True(t, apiMeta.IsStatusConditionFalse(chainNode.Status.Conditions, appsv1.ConditionValidatorEditFailed))
+	assert.Empty(t, recorder.Events)
+
+	assert.False(t, r.reportValidatorTx(chainNode, validatorTx{hash: "ABCDEF", kind: validatorTxUnjail}, errors.New("transaction failed")))
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUnjailFailure)
+
+	// Failed votes are reported on the proposal they were submitted for
+	chainNode.Status.Proposals = []appsv1.GovernanceProposal{{ID: 1}, {ID: 2}}
+	vote := validatorTx{hash: "ABCDEF", kind: validatorTxVote, proposalID: 2}
+	assert.True(t, r.reportValidatorTx(chainNode, vote, errors.New("transaction failed")))
+	assert.Empty(t, chainNode.Status.Proposals[0].VoteError)
+	assert.Equal(t, "transaction failed", chainNode.Status.Proposals[1].VoteError)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonProposalVoteFailure)
+
+	assert.False(t, r.reportValidatorTx(chainNode, vote, nil))
+	assert.Empty(t, recorder.Events)
+}
@@ -45,6 +45,7 @@ func (r *Reconciler) maybeGetVpaResources(ctx context.Context, chainNode *appsv1
 				} else if emergencyScaled {
 					updated.Requests[corev1.ResourceMemory] = newMemRequest
 					memScaleTs = time.Now()
+					observeVpaDecision(chainNode, corev1.ResourceMemory, appsv1.ScaleUp)
 
 					// Calculate new limit based on strategy
 					newMemLimit := calculateLimitFromRequest(chainNode, newMemRequest, chainNode.Spec.VPA.Memory, corev1.ResourceMemory)
@@ -108,6 +109,7 @@ func (r *Reconciler) maybeGetVpaResources(ctx context.Context, chainNode *appsv1
 					"old-limit", oldCpuLimit,
 					"new-limit", newCpuLimit,
 				)
+				observeVpaDecision(chainNode, corev1.ResourceCPU, rule.Direction)
 				break
 			}
 		}
@@ -155,6 +157,7 @@ func (r *Reconciler) maybeGetVpaResources(ctx context.Context, chainNode *appsv1
 					"old-limit", oldMemLimit,
 					"new-limit", newMemLimit,
 				)
+				observeVpaDecision(chainNode, corev1.ResourceMemory, rule.Direction)
 				break
 			}
 		}
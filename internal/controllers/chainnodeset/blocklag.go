@@ -0,0 +1,106 @@
+package chainnodeset
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+// withInSyncLabels sets the in-sync label on the desired ChainNodes of a regular group when any group of the set
+// has a maximum block lag. A node is in sync when the latest height on its status is at most maxBlockLag blocks
+// behind the highest node of the set. Nodes of groups without a maximum block lag are always in sync, so that
+// global Services spanning several groups keep selecting them. When no node of the group is in sync, all of them
+// are kept in the Services, as serving from lagging nodes is better than not serving at all.
+func withInSyncLabels(nodeSet *appsv1.ChainNodeSet, group appsv1.NodeGroupSpec, desired []*appsv1.ChainNode, current []appsv1.ChainNode) {
+	if !nodeSet.HasMaxBlockLag() {
+		return
+	}
+
+	heights := map[string]int64{}
+	highest := nodeSet.Status.LatestHeight
+	for _, node := range current {
+		heights[node.GetName()] = node.Status.LatestHeight
+		highest = max(highest, node.Status.LatestHeight)
+	}
+
+	inSync := make([]bool, len(desired))
+	anyInSync := false
+	for i, node := range desired {
+		inSync[i] = !group.HasMaxBlockLag() || highest-heights[node.GetName()] <= *group.MaxBlockLag
+		anyInSync = anyInSync || inSync[i]
+	}
+
+	for i, node := range desired {
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[controllers.LabelInSync] = strconv.FormatBool(inSync[i] || !anyInSync)
+	}
+}
+
+// withInSyncLabelFrom sets the in-sync label of node to the one of src, removing it when src does not have it.
+// The label only changes which Services select the node, so it is never held back by rolling updates.
+func withInSyncLabelFrom(node, src *appsv1.ChainNode) {
+	value, ok := src.Labels[controllers.LabelInSync]
+	if !ok {
+		delete(node.Labels, controllers.LabelInSync)
+		return
+	}
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	node.Labels[controllers.LabelInSync] = value
+}
+
+// getInSyncGroups returns the groups whose Services can require the in-sync label: every ready pod of the group
+// already carries it. This keeps Services from losing endpoints while the label is being propagated to pods.
+func (r *Reconciler) getInSyncGroups(ctx context.Context, nodeSet *appsv1.ChainNodeSet) (map[string]bool, error) {
+	groups := map[string]bool{}
+	if !nodeSet.HasMaxBlockLag() {
+		return groups, nil
+	}
+
+	for _, group := range nodeSet.Spec.Nodes {
+		if group.Validator == nil {
+			groups[group.Name] = true
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(nodeSet.GetNamespace()),
+		client.MatchingLabels{controllers.LabelChainNodeSet: nodeSet.GetName()},
+	); err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		group, ok := pod.Labels[controllers.LabelChainNodeSetGroup]
+		if !ok || !isPodReady(&pod) {
+			continue
+		}
+		if _, labelled := pod.Labels[controllers.LabelInSync]; !labelled {
+			groups[group] = false
+		}
+	}
+	return groups, nil
+}
+
+// allInSync returns true if the Services of all the given groups can require the in-sync label.
+func allInSync(inSync map[string]bool, groups []string) bool {
+	for _, group := range groups {
+		if !inSync[group] {
+			return false
+		}
+	}
+	return len(groups) > 0
+}
+
+// withInSyncSelector restricts a Service targeting node pods to the ones in sync.
+func withInSyncSelector(svc *corev1.Service) {
+	svc.Spec.Selector[controllers.LabelInSync] = controllers.StringValueTrue
+}
@@ -0,0 +1,164 @@
+package chainnodeset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func blockLagNodeSet() *appsv1.ChainNodeSet {
+	return &appsv1.ChainNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "chain", Namespace: "default", UID: types.UID("nodeset-uid")},
+		Spec: appsv1.ChainNodeSetSpec{
+			Nodes: []appsv1.NodeGroupSpec{
+				{Name: "fullnode", Instances: ptr.To(3), MaxBlockLag: ptr.To(int64(50))},
+				{Name: "archive", Instances: ptr.To(1)},
+			},
+			Ingresses: []appsv1.GlobalIngressConfig{{
+				Name:   "rpc",
+				Groups: []string{"fullnode", "archive"},
+				Host:   "nodes.example.com",
+			}},
+		},
+		Status: appsv1.ChainNodeSetStatus{LatestHeight: 1000},
+	}
+}
+
+func blockLagNodes(group string, heights ...int64) ([]*appsv1.ChainNode, []appsv1.ChainNode) {
+	desired := make([]*appsv1.ChainNode, len(heights))
+	current := make([]appsv1.ChainNode, len(heights))
+	for i, height := range heights {
+		name := fmt.Sprintf("chain-%s-%d", group, i)
+		desired[i] = &appsv1.ChainNode{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		current[i] = appsv1.ChainNode{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     appsv1.ChainNodeStatus{LatestHeight: height},
+		}
+	}
+	return desired, current
+}
+
+func inSyncLabels(nodes []*appsv1.ChainNode) []string {
+	labels := make([]string, len(nodes))
+	for i, node := range nodes {
+		labels[i] = node.Labels[controllers.LabelInSync]
+	}
+	return labels
+}
+
+func TestWithInSyncLabels(t *testing.T) {
+	t.Run("lagging nodes are out of sync", func(t *testing.T) {
+		nodeSet := blockLagNodeSet()
+		desired, current := blockLagNodes("fullnode", 1000, 950, 900)
+		withInSyncLabels(nodeSet, nodeSet.Spec.Nodes[0], desired, current)
+		assert.Equal(t, []string{"true", "true", "false"}, inSyncLabels(desired))
+	})
+
+	t.Run("new nodes are out of sync", func(t *testing.T) {
+		nodeSet := blockLagNodeSet()
+		desired, current := blockLagNodes("fullnode", 1000, 1000, 1000)
+		withInSyncLabels(nodeSet, nodeSet.Spec.Nodes[0], desired, current[:2])
+		assert.Equal(t, []string{"true", "true", "false"}, inSyncLabels(desired))
+	})
+
+	t.Run("whole group lagging is kept in sync", func(t *testing.T) {
+		nodeSet := blockLagNodeSet()
+		nodeSet.Status.LatestHeight = 5000
+		desired, current := blockLagNodes("fullnode", 1000, 950, 900)
+		withInSyncLabels(nodeSet, nodeSet.Spec.Nodes[0], desired, current)
+		assert.Equal(t, []string{"true", "true", "true"}, inSyncLabels(desired))
+	})
+
+	t.Run("groups without max block lag are always in sync", func(t *testing.T) {
+		nodeSet := blockLagNodeSet()
+		desired, current := blockLagNodes("archive", 10)
+		withInSyncLabels(nodeSet, nodeSet.Spec.Nodes[1], desired, current)
+		assert.Equal(t, []string{"true"}, inSyncLabels(desired))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		nodeSet := blockLagNodeSet()
+		nodeSet.Spec.Nodes[0].MaxBlockLag = nil
+		desired, current := blockLagNodes("fullnode", 1000, 10)
+		withInSyncLabels(nodeSet, nodeSet.Spec.Nodes[0], desired, current)
+		for _, node := range desired {
+			assert.NotContains(t, node.Labels, controllers.LabelInSync)
+		}
+	})
+}
+
+func TestInSyncLabelIsNotHeldByRollingUpdates(t *testing.T) {
+	current := &appsv1.ChainNode{ObjectMeta: metav1.ObjectMeta{
+		Name:   "chain-fullnode-0",
+		Labels: map[string]string{controllers.LabelInSync: controllers.StringValueTrue},
+	}}
+	desired := current.DeepCopy()
+	desired.Labels[controllers.LabelInSync] = controllers.StringValueFalse
+
+	assert.False(t, nodeSpecChanged(current, desired), "in-sync label changes must not count as updates")
+	held := getHeldNodeSpec(current, desired)
+	assert.Equal(t, controllers.StringValueFalse, held.Labels[controllers.LabelInSync])
+}
+
+func blockLagPod(name, group string, labelled bool) *corev1.Pod {
+	labels := map[string]string{
+		controllers.LabelChainNodeSet:      "chain",
+		controllers.LabelChainNodeSetGroup: group,
+	}
+	if labelled {
+		labels[controllers.LabelInSync] = controllers.StringValueTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+		}},
+	}
+}
+
+func TestEnsureServicesInSyncSelector(t *testing.T) {
+	ctx := context.Background()
+
+	getSelector := func(t *testing.T, r *Reconciler, name string) map[string]string {
+		svc := &corev1.Service{}
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, svc))
+		return svc.Spec.Selector
+	}
+
+	t.Run("pods not labelled yet", func(t *testing.T) {
+		nodeSet := blockLagNodeSet()
+		r := newValidatorTestReconciler(t, nodeSet,
+			blockLagPod("chain-fullnode-0", "fullnode", true),
+			blockLagPod("chain-fullnode-1", "fullnode", false),
+			blockLagPod("chain-archive-0", "archive", true),
+		)
+		require.NoError(t, r.ensureServices(ctx, nodeSet, cosmoGuardReconcile{}, nil))
+
+		assert.NotContains(t, getSelector(t, r, "chain-fullnode"), controllers.LabelInSync)
+		assert.NotContains(t, getSelector(t, r, "chain-global-rpc"), controllers.LabelInSync)
+	})
+
+	t.Run("all pods labelled", func(t *testing.T) {
+		nodeSet := blockLagNodeSet()
+		r := newValidatorTestReconciler(t, nodeSet,
+			blockLagPod("chain-fullnode-0", "fullnode", true),
+			blockLagPod("chain-fullnode-1", "fullnode", true),
+			blockLagPod("chain-archive-0", "archive", true),
+		)
+		require.NoError(t, r.ensureServices(ctx, nodeSet, cosmoGuardReconcile{}, nil))
+
+		assert.Equal(t, controllers.StringValueTrue, getSelector(t, r, "chain-fullnode")[controllers.LabelInSync])
+		assert.Equal(t, controllers.StringValueTrue, getSelector(t, r, "chain-global-rpc")[controllers.LabelInSync])
+		assert.NotContains(t, getSelector(t, r, "chain-fullnode-internal"), controllers.LabelInSync)
+	})
+}
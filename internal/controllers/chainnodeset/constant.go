@@ -14,6 +14,9 @@ const (
 	scopeGroup      = "group"
 	scopeCosmoGuard = "cosmoguard"
 
+	// scopeHeightProxy labels the height routing proxies of global ingresses.
+	scopeHeightProxy = "height-proxy"
+
 	// cosmoGuardRouteLabelPrefix namespaces the per-route labels stamped on CosmoGuard pods so a
 	// global ingress/gateway Service can select the guard pods of the groups it targets without
 	// colliding with the bare route labels carried by node pods (which back the direct/bypass
@@ -94,11 +94,13 @@ func (r *Reconciler) requeueWaiting(ctx context.Context, nodeSet *appsv1.ChainNo
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -361,6 +363,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return r.requeueWaiting(ctx, nodeSet)
 	}
 
+	// Progress staged upgrade rollouts before children are updated, so that only nodes the upgrade was
+	// released to get it in their spec.
+	if err := r.ensureUpgradeRollouts(ctx, nodeSet); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Once a genesis is available (chainID known), reconcile validators that consume an external
 	// genesis or that initialized it on an earlier pass. This also runs validator cleanup, so it must
 	// execute even when no validator is currently desired (e.g. the last validator was removed).
@@ -396,7 +404,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
-	if err := r.ensureServices(ctx, nodeSet, guards); err != nil {
+	heightProxies, err := r.ensureHeightProxies(ctx, nodeSet)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ensureServices(ctx, nodeSet, guards, heightProxies); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -404,6 +417,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	if err := r.ensureGuardMonitors(ctx, nodeSet, guards.expected); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Reconcile gateway routes BEFORE legacy ingresses so we know whether the
 	// replacement routes were actually applied. If Gateway API CRDs are missing,
 	// ensureIngresses must preserve any Ingress whose name is now covered by a
@@ -795,6 +812,7 @@ func (r *Reconciler) setupWithManager(mgr ctrl.Manager) error {
 		For(&appsv1.ChainNodeSet{}).
 		Owns(&appsv1.ChainNode{}).
 		Owns(&k8sappsv1.StatefulSet{}).
+		Owns(&k8sappsv1.Deployment{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
 		WithEventFilter(GenerationChangedPredicate{}).
@@ -242,6 +242,11 @@ func (r *Reconciler) ensureCosmoGuards(ctx context.Context, nodeSet *appsv1.Chai
 	expectedRoutes := map[string]bool{}
 	routesPending := false
 
+	inSync, err := r.getInSyncGroups(ctx, nodeSet)
+	if err != nil {
+		return cosmoGuardReconcile{}, err
+	}
+
 	for _, group := range nodeSet.Spec.Nodes {
 		cfg := group.GetServiceConfig()
 		if !cfg.CosmoGuardEnabled() {
@@ -269,6 +274,9 @@ func (r *Reconciler) ensureCosmoGuards(ctx context.Context, nodeSet *appsv1.Chai
 		if err != nil {
 			return cosmoGuardReconcile{}, err
 		}
+		if inSync[group.Name] {
+			withInSyncSelector(upstream)
+		}
 		if err := cosmoguard.ApplyOwned(ctx, r.Client, r.Scheme, nodeSet, upstream); err != nil {
 			return cosmoGuardReconcile{}, fmt.Errorf("failed to apply cosmoguard upstream service for group %s: %w", group.Name, err)
 		}
@@ -1674,16 +1674,7 @@ func (r *Reconciler) cosmosignerMigrationTargetWait(ctx context.Context, nodeSet
 }
 
 func cosmosignerMigrationTargetPodReady(pod *corev1.Pod) bool {
-	if pod.Status.Phase != corev1.PodRunning {
-		return false
-	}
-	for i := range pod.Status.Conditions {
-		condition := &pod.Status.Conditions[i]
-		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
-			return true
-		}
-	}
-	return false
+	return isPodReady(pod)
 }
 
 // initCosmosignerLocks persists a status entry and the raft-membership/PVC-template locks for every
@@ -0,0 +1,157 @@
+package chainnodeset
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+	"github.com/voluzi/cosmopilot/v3/internal/k8s"
+)
+
+// ensureHeightProxies deploys the height routing proxy of each global ingress that enables it, and removes the
+// proxies of ingresses that no longer do. It returns, for each ingress, whether its proxy has available replicas,
+// so that the global Service is only pointed at the proxy once it can serve traffic.
+func (r *Reconciler) ensureHeightProxies(ctx context.Context, nodeSet *appsv1.ChainNodeSet) (map[string]bool, error) {
+	ready := map[string]bool{}
+	expected := map[string]bool{}
+
+	for _, ingress := range nodeSet.Spec.Ingresses {
+		if ingress.HeightRouting == nil {
+			continue
+		}
+		deployment, err := r.getHeightProxyDeploymentSpec(nodeSet, ingress)
+		if err != nil {
+			return nil, err
+		}
+		expected[deployment.GetName()] = true
+		if err = r.ensureDeployment(ctx, deployment); err != nil {
+			return nil, err
+		}
+		ready[ingress.Name] = deployment.Status.AvailableReplicas > 0
+	}
+
+	deployments := &k8sappsv1.DeploymentList{}
+	if err := r.List(ctx, deployments,
+		client.InNamespace(nodeSet.GetNamespace()),
+		client.MatchingLabels{
+			controllers.LabelChainNodeSet: nodeSet.GetName(),
+			controllers.LabelScope:        scopeHeightProxy,
+		},
+	); err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		if expected[deployment.GetName()] || !metav1.IsControlledBy(&deployment, nodeSet) {
+			continue
+		}
+		log.FromContext(ctx).Info("deleting height proxy", "deployment", deployment.GetName())
+		if err := r.Delete(ctx, &deployment); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+	}
+
+	return ready, nil
+}
+
+func (r *Reconciler) getHeightProxyDeploymentSpec(nodeSet *appsv1.ChainNodeSet, ingress appsv1.GlobalIngressConfig) (*k8sappsv1.Deployment, error) {
+	cfg := ingress.HeightRouting
+	labels := heightProxySelector(nodeSet, ingress)
+
+	var recent []string
+	for _, group := range ingress.GetRecentGroups() {
+		recent = append(recent, heightProxyUpstream(nodeSet, group))
+	}
+
+	deployment := &k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingress.GetHeightProxyName(nodeSet),
+			Namespace: nodeSet.GetNamespace(),
+			Labels:    WithChainNodeSetLabels(nodeSet, labels),
+		},
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas: ptr.To(cfg.GetReplicas()),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: WithChainNodeSetLabels(nodeSet, labels)},
+				Spec: corev1.PodSpec{
+					SecurityContext: k8s.RestrictedPodSecurityContext(),
+					Containers: []corev1.Container{
+						{
+							Name:                     "height-proxy",
+							Image:                    r.opts.NodeUtilsImage,
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							Args:                     []string{"height-proxy"},
+							SecurityContext:          k8s.RestrictedSecurityContext(),
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							Env: []corev1.EnvVar{
+								{Name: "RECENT_HOSTS", Value: strings.Join(recent, ",")},
+								{Name: "ARCHIVE_HOSTS", Value: heightProxyUpstream(nodeSet, cfg.ArchiveGroup)},
+								{Name: "RECENT_BLOCKS", Value: strconv.FormatInt(cfg.GetRecentBlocks(), 10)},
+								{Name: "HTTP_PORTS", Value: fmt.Sprintf("%d,%d,%d,%d",
+									chainutils.RpcPort, chainutils.LcdPort, controllers.EvmRpcPort, controllers.EvmRpcWsPort)},
+								{Name: "GRPC_PORTS", Value: strconv.Itoa(chainutils.GrpcPort)},
+								{Name: "STATUS_PORT", Value: strconv.Itoa(chainutils.RpcPort)},
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: chainutils.RpcPortName, ContainerPort: chainutils.RpcPort, Protocol: corev1.ProtocolTCP},
+								{Name: chainutils.LcdPortName, ContainerPort: chainutils.LcdPort, Protocol: corev1.ProtocolTCP},
+								{Name: chainutils.GrpcPortName, ContainerPort: chainutils.GrpcPort, Protocol: corev1.ProtocolTCP},
+								{Name: controllers.EvmRpcPortName, ContainerPort: controllers.EvmRpcPort, Protocol: corev1.ProtocolTCP},
+								{Name: controllers.EvmRpcWsPortName, ContainerPort: controllers.EvmRpcWsPort, Protocol: corev1.ProtocolTCP},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(chainutils.RpcPort)},
+								},
+								PeriodSeconds: 5,
+							},
+							Resources: cfg.Resources,
+						},
+					},
+				},
+			},
+		},
+	}
+	return deployment, controllerutil.SetControllerReference(nodeSet, deployment, r.Scheme)
+}
+
+// withHeightProxy points a global ingress Service at the height routing proxy, which listens on the same ports as
+// the nodes.
+func withHeightProxy(svc *corev1.Service, nodeSet *appsv1.ChainNodeSet, ingress appsv1.GlobalIngressConfig) {
+	svc.Spec.Selector = heightProxySelector(nodeSet, ingress)
+	for i := range svc.Spec.Ports {
+		svc.Spec.Ports[i].TargetPort = intstr.FromInt32(svc.Spec.Ports[i].Port)
+	}
+}
+
+// heightProxySelector returns the labels of the height routing proxy pods of an ingress.
+func heightProxySelector(nodeSet *appsv1.ChainNodeSet, ingress appsv1.GlobalIngressConfig) map[string]string {
+	return map[string]string{
+		controllers.LabelChainNodeSet:  nodeSet.GetName(),
+		controllers.LabelGlobalIngress: ingress.Name,
+		controllers.LabelScope:         scopeHeightProxy,
+	}
+}
+
+// heightProxyUpstream returns the host of the Service of a group, which the proxy forwards requests to. The group
+// Service only targets ready nodes, and goes through CosmoGuard when the group enables it.
+func heightProxyUpstream(nodeSet *appsv1.ChainNodeSet, groupName string) string {
+	name := fmt.Sprintf("%s-%s", nodeSet.GetName(), groupName)
+	if group := findNodeGroup(nodeSet, groupName); group != nil {
+		name = group.GetServiceName(nodeSet)
+	}
+	return fmt.Sprintf("%s.%s.svc.cluster.local", name, nodeSet.GetNamespace())
+}
@@ -0,0 +1,116 @@
+package chainnodeset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func heightRoutingNodeSet() *appsv1.ChainNodeSet {
+	return &appsv1.ChainNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "chain", Namespace: "default", UID: types.UID("nodeset-uid")},
+		Spec: appsv1.ChainNodeSetSpec{
+			Nodes: []appsv1.NodeGroupSpec{
+				{Name: "pruned", Instances: ptr.To(2)},
+				{Name: "archive", Instances: ptr.To(1)},
+			},
+			Ingresses: []appsv1.GlobalIngressConfig{{
+				Name:   "rpc",
+				Groups: []string{"pruned", "archive"},
+				Host:   "nodes.example.com",
+				HeightRouting: &appsv1.HeightRoutingConfig{
+					ArchiveGroup: "archive",
+					RecentBlocks: ptr.To(int64(1000)),
+				},
+			}},
+		},
+	}
+}
+
+func envValue(container corev1.Container, name string) string {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestGetHeightProxyDeploymentSpec(t *testing.T) {
+	nodeSet := heightRoutingNodeSet()
+	r := newValidatorTestReconciler(t, nodeSet)
+	r.opts = &controllers.ControllerRunOptions{NodeUtilsImage: "node-utils:latest"}
+
+	deployment, err := r.getHeightProxyDeploymentSpec(nodeSet, nodeSet.Spec.Ingresses[0])
+	require.NoError(t, err)
+	assert.Equal(t, "chain-global-rpc-height", deployment.GetName())
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.True(t, metav1.IsControlledBy(deployment, nodeSet))
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "node-utils:latest", container.Image)
+	assert.Equal(t, []string{"height-proxy"}, container.Args)
+	// The archive group only receives old heights, even though it is one of the ingress groups
+	assert.Equal(t, "chain-pruned.default.svc.cluster.local", envValue(container, "RECENT_HOSTS"))
+	assert.Equal(t, "chain-archive.default.svc.cluster.local", envValue(container, "ARCHIVE_HOSTS"))
+	assert.Equal(t, "1000", envValue(container, "RECENT_BLOCKS"))
+}
+
+func TestEnsureHeightProxies(t *testing.T) {
+	ctx := context.Background()
+	nodeSet := heightRoutingNodeSet()
+	r := newValidatorTestReconciler(t, nodeSet)
+
+	ready, err := r.ensureHeightProxies(ctx, nodeSet)
+	require.NoError(t, err)
+	assert.False(t, ready["rpc"], "proxy without available replicas must not receive traffic")
+
+	key := types.NamespacedName{Namespace: "default", Name: "chain-global-rpc-height"}
+	deployment := &k8sappsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+
+	deployment.Status.AvailableReplicas = 1
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	ready, err = r.ensureHeightProxies(ctx, nodeSet)
+	require.NoError(t, err)
+	assert.True(t, ready["rpc"])
+
+	// Disabling height routing removes the proxy
+	nodeSet.Spec.Ingresses[0].HeightRouting = nil
+	ready, err = r.ensureHeightProxies(ctx, nodeSet)
+	require.NoError(t, err)
+	assert.Empty(t, ready)
+	assert.True(t, apierrors.IsNotFound(r.Get(ctx, key, &k8sappsv1.Deployment{})))
+}
+
+func TestGlobalServiceSelectsHeightProxy(t *testing.T) {
+	nodeSet := heightRoutingNodeSet()
+	r := newValidatorTestReconciler(t, nodeSet)
+	ingress := nodeSet.Spec.Ingresses[0]
+
+	svc, err := r.getGlobalServiceSpec(nodeSet, ingress, true)
+	require.NoError(t, err)
+	withHeightProxy(svc, nodeSet, ingress)
+
+	assert.Equal(t, map[string]string{
+		controllers.LabelChainNodeSet:  "chain",
+		controllers.LabelGlobalIngress: "rpc",
+		controllers.LabelScope:         scopeHeightProxy,
+	}, svc.Spec.Selector)
+	for _, port := range svc.Spec.Ports {
+		assert.Equal(t, port.Port, port.TargetPort.IntVal, "port %s", port.Name)
+	}
+	assert.Equal(t, int32(chainutils.GrpcPort), svc.Spec.Ports[2].TargetPort.IntVal)
+}
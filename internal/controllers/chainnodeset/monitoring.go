@@ -0,0 +1,90 @@
+package chainnodeset
+
+import (
+	"context"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+	"github.com/voluzi/cosmopilot/v3/internal/cosmoguard"
+)
+
+// ensureGuardMonitors creates a monitor for the metrics of each group CosmoGuard when monitoring is enabled, and
+// removes the monitors of guards that no longer exist or of the kind no longer in use. Node metrics and alerts are
+// handled by the ChainNode controller, as the monitoring config is propagated to every node of the set.
+func (r *Reconciler) ensureGuardMonitors(ctx context.Context, nodeSet *appsv1.ChainNodeSet, guards map[string]bool) error {
+	cfg := nodeSet.Spec.Monitoring
+	usePodMonitor := cfg.UsePodMonitor()
+
+	if cfg != nil {
+		for name := range guards {
+			params := controllers.MonitorParams{
+				Name:      name,
+				Namespace: nodeSet.GetNamespace(),
+				Labels:    WithChainNodeSetLabels(nodeSet, cfg.GetLabels()),
+				Selector:  cosmoguard.InstanceLabels(name),
+				Ports:     []string{controllers.CosmoGuardMetricsPortName},
+				Interval:  cfg.GetInterval(),
+			}
+
+			var obj client.Object = params.ServiceMonitor()
+			if usePodMonitor {
+				obj = params.PodMonitor()
+			}
+			withCosmoGuardScope(obj)
+			if err := controllerutil.SetControllerReference(nodeSet, obj, r.Scheme); err != nil {
+				return err
+			}
+
+			var err error
+			if usePodMonitor {
+				_, err = controllers.EnsurePodMonitor(ctx, r.Client, obj.(*monitoringv1.PodMonitor))
+			} else {
+				_, err = controllers.EnsureServiceMonitor(ctx, r.Client, obj.(*monitoringv1.ServiceMonitor))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	sel := client.MatchingLabels{controllers.LabelScope: scopeCosmoGuard}
+	ns := client.InNamespace(nodeSet.GetNamespace())
+
+	var stale []client.Object
+	serviceMonitors := &monitoringv1.ServiceMonitorList{}
+	if err := r.List(ctx, serviceMonitors, ns, sel); err != nil {
+		if controllers.IsCRDNotInstalled(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range serviceMonitors.Items {
+		if cfg == nil || usePodMonitor || !guards[serviceMonitors.Items[i].GetName()] {
+			stale = append(stale, &serviceMonitors.Items[i])
+		}
+	}
+
+	podMonitors := &monitoringv1.PodMonitorList{}
+	if err := r.List(ctx, podMonitors, ns, sel); err != nil {
+		if controllers.IsCRDNotInstalled(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range podMonitors.Items {
+		if cfg == nil || !usePodMonitor || !guards[podMonitors.Items[i].GetName()] {
+			stale = append(stale, &podMonitors.Items[i])
+		}
+	}
+
+	for _, obj := range stale {
+		if err := controllers.DeleteOwnedMonitoringObject(ctx, r.Client, nodeSet, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
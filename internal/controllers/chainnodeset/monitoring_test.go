@@ -0,0 +1,59 @@
+package chainnodeset
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+	"github.com/voluzi/cosmopilot/v3/internal/cosmoguard"
+)
+
+func TestEnsureGuardMonitors(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "default", Name: "chain-fullnode-cg"}
+
+	nodeSet := &appsv1.ChainNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "chain", Namespace: "default", UID: types.UID("nodeset-uid")},
+		Spec:       appsv1.ChainNodeSetSpec{Monitoring: &appsv1.MonitoringConfig{}},
+	}
+	r := newValidatorTestReconciler(t, nodeSet)
+	guards := map[string]bool{"chain-fullnode-cg": true}
+
+	require.NoError(t, r.ensureGuardMonitors(ctx, nodeSet, guards))
+	serviceMonitor := &monitoringv1.ServiceMonitor{}
+	require.NoError(t, r.Get(ctx, key, serviceMonitor))
+	assert.True(t, metav1.IsControlledBy(serviceMonitor, nodeSet))
+	assert.Equal(t, scopeCosmoGuard, serviceMonitor.Labels[controllers.LabelScope])
+	assert.Equal(t, cosmoguard.InstanceLabels("chain-fullnode-cg"), serviceMonitor.Spec.Selector.MatchLabels)
+	require.Len(t, serviceMonitor.Spec.Endpoints, 1)
+	assert.Equal(t, controllers.CosmoGuardMetricsPortName, serviceMonitor.Spec.Endpoints[0].Port)
+
+	nodeSet.Spec.Monitoring.PodMonitor = ptr.To(true)
+	require.NoError(t, r.ensureGuardMonitors(ctx, nodeSet, guards))
+	assert.True(t, errors.IsNotFound(r.Get(ctx, key, &monitoringv1.ServiceMonitor{})))
+	require.NoError(t, r.Get(ctx, key, &monitoringv1.PodMonitor{}))
+
+	// Monitors of guards that no longer exist are removed
+	require.NoError(t, r.ensureGuardMonitors(ctx, nodeSet, map[string]bool{}))
+	assert.True(t, errors.IsNotFound(r.Get(ctx, key, &monitoringv1.PodMonitor{})))
+}
+
+func TestMonitoringIsPropagatedToNodes(t *testing.T) {
+	nodeSet := blockLagNodeSet()
+	nodeSet.Spec.Monitoring = &appsv1.MonitoringConfig{Interval: ptr.To("1m")}
+
+	r := newValidatorTestReconciler(t, nodeSet)
+	node, err := r.getNodeSpec(nodeSet, nodeSet.Spec.Nodes[0], 0)
+	require.NoError(t, err)
+	require.NotNil(t, node.Spec.Monitoring)
+	assert.Equal(t, "1m", *node.Spec.Monitoring.Interval)
+}
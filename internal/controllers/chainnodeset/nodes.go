@@ -228,11 +228,23 @@ func (r *Reconciler) ensureNodeGroupWithBlockedSignerTargets(ctx context.Context
 		}
 	}
 
-	for i := 0; i < desiredSize; i++ {
-		node, err := r.getNodeSpecWithBlockedSignerTargets(nodeSet, group, i, blocked)
-		if err != nil {
+	desired := make([]*appsv1.ChainNode, desiredSize)
+	for i := range desired {
+		if desired[i], err = r.getNodeSpecWithBlockedSignerTargets(nodeSet, group, i, blocked); err != nil {
 			return err
 		}
+	}
+	withInSyncLabels(nodeSet, group, desired, chainNodeList.Items)
+
+	held, err := r.getHeldNodeUpdates(ctx, nodeSet, group, chainNodeList.Items, desired)
+	if err != nil {
+		return err
+	}
+
+	for i, node := range desired {
+		if heldNode, ok := held[i]; ok {
+			node = heldNode
+		}
 		if err := r.ensureNode(ctx, nodeSet, node, waitNone); err != nil {
 			return err
 		}
@@ -386,9 +398,10 @@ func (r *Reconciler) getNodeSpecWithBlockedSignerTargets(nodeSet *appsv1.ChainNo
 		}
 	}
 
+	name := fmt.Sprintf("%s-%s-%d", nodeSet.GetName(), group.Name, index)
 	node := &appsv1.ChainNode{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-%s-%d", nodeSet.GetName(), group.Name, index),
+			Name:      name,
 			Namespace: nodeSet.GetNamespace(),
 			// Stamp the internal validator label false explicitly. WithChainNodeSetLabels copies the
 			// ChainNodeSet's user labels onto this ChainNode; if the parent carries a user label
@@ -403,7 +416,7 @@ func (r *Reconciler) getNodeSpecWithBlockedSignerTargets(nodeSet *appsv1.ChainNo
 		},
 		Spec: appsv1.ChainNodeSpec{
 			Genesis:                       genesisConfig,
-			App:                           nodeSet.GetAppSpecWithUpgrades(),
+			App:                           getNodeAppSpec(nodeSet, name),
 			DeletionPolicy:                nodeSet.Spec.DeletionPolicy.DeepCopy(),
 			Config:                        configForChild(group.Config),
 			Persistence:                   group.Persistence.DeepCopy(),
@@ -417,6 +430,7 @@ func (r *Reconciler) getNodeSpecWithBlockedSignerTargets(nodeSet *appsv1.ChainNo
 			IgnoreGroupOnDisruptionChecks: group.IgnoreGroupOnDisruptionChecks,
 			VPA:                           group.VPA,
 			OverrideVersion:               group.OverrideVersion,
+			Monitoring:                    nodeSet.Spec.Monitoring.DeepCopy(),
 		},
 	}
 
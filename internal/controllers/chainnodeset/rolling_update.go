@@ -0,0 +1,126 @@
+package chainnodeset
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+// getHeldNodeUpdates applies the group update strategy to the desired ChainNodes of a group. It returns, by
+// index, the spec to apply instead of the desired one for ChainNodes whose update must wait. Existing
+// ChainNodes are updated from the highest index to the lowest, and only while no more than maxUnavailable
+// ChainNodes of the group are unavailable.
+func (r *Reconciler) getHeldNodeUpdates(ctx context.Context, nodeSet *appsv1.ChainNodeSet, group appsv1.NodeGroupSpec, chainNodes []appsv1.ChainNode, desired []*appsv1.ChainNode) (map[int]*appsv1.ChainNode, error) {
+	logger := log.FromContext(ctx)
+	strategy := group.UpdateStrategy
+	if strategy == nil {
+		return nil, nil
+	}
+
+	current := make(map[string]*appsv1.ChainNode)
+	available := make(map[string]bool)
+	unavailable := 0
+	for i, node := range chainNodes {
+		if !metav1.IsControlledBy(&node, nodeSet) || node.GetDeletionTimestamp() != nil {
+			continue
+		}
+		current[node.GetName()] = &chainNodes[i]
+
+		ok, err := r.isNodeAvailable(ctx, &node, strategy.ShouldWaitForSynced())
+		if err != nil {
+			return nil, err
+		}
+		available[node.GetName()] = ok
+		if !ok {
+			unavailable++
+		}
+	}
+
+	budget := strategy.GetMaxUnavailable() - unavailable
+	held := make(map[int]*appsv1.ChainNode)
+	for i := len(desired) - 1; i >= 0; i-- {
+		node, ok := current[desired[i].GetName()]
+		if !ok || !nodeSpecChanged(node, desired[i]) {
+			continue
+		}
+
+		if i >= strategy.GetPartition() {
+			// Updating an unavailable node does not increase disruption
+			if !available[node.GetName()] {
+				continue
+			}
+			if budget > 0 {
+				budget--
+				continue
+			}
+		}
+
+		logger.V(1).Info("holding chainnode update", "group", group.Name, "chainnode", node.GetName())
+		held[i] = getHeldNodeSpec(node, desired[i])
+	}
+	return held, nil
+}
+
+// isNodeAvailable returns whether the ChainNode is serving with a pod created from its current spec. ChainNode
+// status has no observed generation, so the generation annotation of the pod is used instead: the ChainNode
+// controller only sets it once the pod matches the current spec and config.
+func (r *Reconciler) isNodeAvailable(ctx context.Context, node *appsv1.ChainNode, waitForSynced bool) (bool, error) {
+	if waitForSynced && node.Status.Phase != appsv1.PhaseChainNodeRunning {
+		return false, nil
+	}
+	if !node.IsReady() {
+		return false, nil
+	}
+
+	pod := &corev1.Pod{}
+	err := r.uncachedReader().Get(ctx, client.ObjectKeyFromObject(node), pod)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return metav1.IsControlledBy(pod, node) && pod.GetDeletionTimestamp().IsZero() &&
+		pod.GetAnnotations()[controllers.AnnotationChainNodeGeneration] == strconv.FormatInt(node.GetGeneration(), 10) &&
+		isPodReady(pod), nil
+}
+
+// nodeSpecChanged returns whether ensureNode would update the current ChainNode to the desired one.
+func nodeSpecChanged(current, desired *appsv1.ChainNode) bool {
+	node := desired.DeepCopy()
+	if current.Spec.OverrideVersion != nil && node.Spec.OverrideVersion == nil {
+		node.Spec.OverrideVersion = current.Spec.OverrideVersion
+	}
+	withInSyncLabelFrom(node, current)
+	return !current.Equal(node)
+}
+
+// getHeldNodeSpec returns the current ChainNode with the desired upgrades and in-sync label only. Upgrades are
+// never held back, since the ChainNode must know about them before reaching the upgrade height.
+func getHeldNodeSpec(current, desired *appsv1.ChainNode) *appsv1.ChainNode {
+	node := current.DeepCopy()
+	node.Spec.App.Upgrades = desired.Spec.App.Upgrades
+	withInSyncLabelFrom(node, desired)
+	return node
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
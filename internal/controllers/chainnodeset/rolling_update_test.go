@@ -0,0 +1,117 @@
+package chainnodeset
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func rollingUpdateTestObjects(nodeSet *appsv1.ChainNodeSet, instances int) ([]appsv1.ChainNode, []client.Object) {
+	nodes := make([]appsv1.ChainNode, instances)
+	objects := []client.Object{nodeSet}
+	for i := range nodes {
+		node := upgradeRolloutTestNode(nodeSet, fmt.Sprintf("set-rpc-%d", i), "rpc", false)
+		node.Generation = 2
+		node.Spec.App = appsv1.AppSpec{Image: "app", Version: ptr.To("v1")}
+		nodes[i] = *node
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        node.Name,
+				Namespace:   node.Namespace,
+				Annotations: map[string]string{controllers.AnnotationChainNodeGeneration: "2"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: appsv1.GroupVersion.String(),
+					Kind:       "ChainNode",
+					Name:       node.Name,
+					Controller: ptr.To(true),
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		objects = append(objects, pod)
+	}
+	return nodes, objects
+}
+
+func rollingUpdateTestDesired(nodes []appsv1.ChainNode) []*appsv1.ChainNode {
+	desired := make([]*appsv1.ChainNode, len(nodes))
+	for i := range nodes {
+		desired[i] = nodes[i].DeepCopy()
+		desired[i].Spec.App.Version = ptr.To("v2")
+		desired[i].Spec.App.Upgrades = []appsv1.UpgradeSpec{{Height: 1000, Image: "app:v3"}}
+	}
+	return desired
+}
+
+func TestGetHeldNodeUpdates(t *testing.T) {
+	nodeSet := upgradeRolloutTestNodeSet(nil)
+	nodes, objects := rollingUpdateTestObjects(nodeSet, 3)
+	r := newGenesisTestReconciler(t, objects...)
+	desired := rollingUpdateTestDesired(nodes)
+
+	// No strategy updates all nodes at once
+	held, err := r.getHeldNodeUpdates(context.Background(), nodeSet, appsv1.NodeGroupSpec{Name: "rpc"}, nodes, desired)
+	require.NoError(t, err)
+	assert.Empty(t, held)
+
+	// Highest index is updated first
+	group := appsv1.NodeGroupSpec{Name: "rpc", UpdateStrategy: &appsv1.NodeGroupUpdateStrategy{}}
+	held, err = r.getHeldNodeUpdates(context.Background(), nodeSet, group, nodes, desired)
+	require.NoError(t, err)
+	require.Len(t, held, 2)
+	assert.Contains(t, held, 0)
+	assert.Contains(t, held, 1)
+
+	// Held nodes still get upgrades
+	assert.Equal(t, ptr.To("v1"), held[0].Spec.App.Version)
+	assert.Equal(t, desired[0].Spec.App.Upgrades, held[0].Spec.App.Upgrades)
+
+	// Partition prevents lower indexes from being updated
+	group.UpdateStrategy = &appsv1.NodeGroupUpdateStrategy{MaxUnavailable: ptr.To(3), Partition: ptr.To(2)}
+	held, err = r.getHeldNodeUpdates(context.Background(), nodeSet, group, nodes, desired)
+	require.NoError(t, err)
+	assert.Len(t, held, 2)
+	assert.NotContains(t, held, 2)
+}
+
+func TestGetHeldNodeUpdatesCountsUnavailableNodes(t *testing.T) {
+	nodeSet := upgradeRolloutTestNodeSet(nil)
+	nodes, objects := rollingUpdateTestObjects(nodeSet, 3)
+	// Node 2 was updated and is still syncing
+	nodes[2].Status.Phase = appsv1.PhaseChainNodeSyncing
+	r := newGenesisTestReconciler(t, objects...)
+	desired := rollingUpdateTestDesired(nodes)
+	desired[2] = nodes[2].DeepCopy()
+
+	group := appsv1.NodeGroupSpec{Name: "rpc", UpdateStrategy: &appsv1.NodeGroupUpdateStrategy{}}
+	held, err := r.getHeldNodeUpdates(context.Background(), nodeSet, group, nodes, desired)
+	require.NoError(t, err)
+	assert.Len(t, held, 2)
+
+	// Syncing nodes are available when not waiting for nodes to be synced
+	group.UpdateStrategy.WaitForSynced = ptr.To(false)
+	held, err = r.getHeldNodeUpdates(context.Background(), nodeSet, group, nodes, desired)
+	require.NoError(t, err)
+	assert.Len(t, held, 1)
+	assert.Contains(t, held, 0)
+
+	// Nodes whose pod was not recreated with the latest spec are unavailable
+	nodes[2].Generation = 3
+	held, err = r.getHeldNodeUpdates(context.Background(), nodeSet, group, nodes, desired)
+	require.NoError(t, err)
+	assert.Len(t, held, 2)
+}
@@ -22,6 +22,7 @@ import (
 	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
 	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
 	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+	"github.com/voluzi/cosmopilot/v3/internal/cosmoguard"
 )
 
 func (r *Reconciler) initializeLegacySignerServiceNames(ctx context.Context, nodeSet *appsv1.ChainNodeSet) (bool, error) {
@@ -202,9 +203,14 @@ func sortedKeys(set map[string]struct{}) []string {
 	return out
 }
 
-func (r *Reconciler) ensureServices(ctx context.Context, nodeSet *appsv1.ChainNodeSet, guards cosmoGuardReconcile) error {
+func (r *Reconciler) ensureServices(ctx context.Context, nodeSet *appsv1.ChainNodeSet, guards cosmoGuardReconcile, heightProxies map[string]bool) error {
 	logger := log.FromContext(ctx)
 
+	inSync, err := r.getInSyncGroups(ctx, nodeSet)
+	if err != nil {
+		return err
+	}
+
 	expectedGroup := map[string]bool{}
 	expectedGlobal := map[string]bool{}
 
@@ -235,6 +241,10 @@ func (r *Reconciler) ensureServices(ctx context.Context, nodeSet *appsv1.ChainNo
 		if err != nil {
 			return err
 		}
+		// Lagging nodes are left out of the Service. Guarded groups filter them on the guard upstream instead.
+		if inSync[group.Name] && !cosmoguard.SelectsGuard(svc.Spec.Selector) {
+			withInSyncSelector(svc)
+		}
 		if err = ensure(svc, scopeGroup); err != nil {
 			return err
 		}
@@ -253,6 +263,13 @@ func (r *Reconciler) ensureServices(ctx context.Context, nodeSet *appsv1.ChainNo
 		if err != nil {
 			return err
 		}
+		if allInSync(inSync, ingress.Groups) && !cosmoguard.SelectsGuard(svc.Spec.Selector) {
+			withInSyncSelector(svc)
+		}
+		// The height routing proxy sits in front of the groups (and their guards) once it is available
+		if heightProxies[ingress.Name] {
+			withHeightProxy(svc, nodeSet, ingress)
+		}
 		if err = ensure(svc, scopeGlobal); err != nil {
 			return err
 		}
@@ -273,6 +290,9 @@ func (r *Reconciler) ensureServices(ctx context.Context, nodeSet *appsv1.ChainNo
 		if err != nil {
 			return err
 		}
+		if allInSync(inSync, gw.Groups) && !cosmoguard.SelectsGuard(svc.Spec.Selector) {
+			withInSyncSelector(svc)
+		}
 		if err = ensure(svc, scopeGlobal); err != nil {
 			return err
 		}
@@ -0,0 +1,314 @@
+package chainnodeset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+// ensureUpgradeRollouts progresses the staged rollouts of manual upgrades. The upgrade is only added to the
+// spec of ChainNodes it was released to (see getNodeAppSpec), so the remaining nodes keep running the previous
+// image until the previous stages pass the health gates for the soak time.
+func (r *Reconciler) ensureUpgradeRollouts(ctx context.Context, nodeSet *appsv1.ChainNodeSet) error {
+	logger := log.FromContext(ctx)
+
+	if nodeSet.Spec.UpgradeRollout == nil {
+		if len(nodeSet.Status.UpgradeRollouts) == 0 {
+			return nil
+		}
+		logger.Info("upgrade rollout strategy removed: releasing all upgrades")
+		nodeSet.Status.UpgradeRollouts = nil
+		return r.Status().Update(ctx, nodeSet)
+	}
+
+	chainNodes, err := r.listNodeSetNodes(ctx, nodeSet)
+	if err != nil {
+		return err
+	}
+	nodes := make(map[string]*appsv1.ChainNode)
+	for i, node := range chainNodes.Items {
+		if metav1.IsControlledBy(&node, nodeSet) && node.GetDeletionTimestamp() == nil {
+			nodes[node.GetName()] = &chainNodes.Items[i]
+		}
+	}
+	stages := getUpgradeRolloutStages(nodeSet.Spec.UpgradeRollout, nodes)
+
+	rollouts := make([]appsv1.UpgradeRolloutStatus, 0)
+	for _, upgrade := range nodeSet.Spec.App.Upgrades {
+		if !nodeSet.ShouldStageUpgrade(upgrade) {
+			continue
+		}
+
+		var rollout *appsv1.UpgradeRolloutStatus
+		for i := range nodeSet.Status.UpgradeRollouts {
+			if nodeSet.Status.UpgradeRollouts[i].Height == upgrade.Height {
+				rollout = nodeSet.Status.UpgradeRollouts[i].DeepCopy()
+				break
+			}
+		}
+
+		switch {
+		// Upgrades applied before the strategy was set are not staged
+		case rollout == nil && isUpgradeApplied(nodeSet, upgrade.Height):
+			continue
+
+		// A new image restarts the rollout
+		case rollout == nil || rollout.Image != upgrade.Image:
+			logger.Info("starting staged upgrade rollout", "height", upgrade.Height, "image", upgrade.Image, "stages", len(stages))
+			rollout = &appsv1.UpgradeRolloutStatus{
+				Height: upgrade.Height,
+				Image:  upgrade.Image,
+				Phase:  appsv1.UpgradeRolloutPending,
+			}
+		}
+
+		r.progressUpgradeRollout(ctx, nodeSet, rollout, stages, nodes)
+		rollouts = append(rollouts, *rollout)
+	}
+
+	if !reflect.DeepEqual(rollouts, nodeSet.Status.UpgradeRollouts) && !(len(rollouts) == 0 && len(nodeSet.Status.UpgradeRollouts) == 0) {
+		logger.Info("updating .status.upgradeRollouts")
+		nodeSet.Status.UpgradeRollouts = rollouts
+		return r.Status().Update(ctx, nodeSet)
+	}
+	return nil
+}
+
+func (r *Reconciler) progressUpgradeRollout(ctx context.Context, nodeSet *appsv1.ChainNodeSet, rollout *appsv1.UpgradeRolloutStatus, stages [][]string, nodes map[string]*appsv1.ChainNode) {
+	logger := log.FromContext(ctx).WithValues("height", rollout.Height, "image", rollout.Image)
+	strategy := nodeSet.Spec.UpgradeRollout
+
+	if rollout.Phase == appsv1.UpgradeRolloutCompleted || rollout.Phase == appsv1.UpgradeRolloutHalted {
+		return
+	}
+
+	// Nodes added to the current or previous stages are released too
+	rollout.Stages = len(stages)
+	for i := 0; i <= rollout.Stage && i < len(stages); i++ {
+		for _, name := range stages[i] {
+			if !slices.Contains(rollout.Nodes, name) {
+				rollout.Nodes = append(rollout.Nodes, name)
+			}
+		}
+	}
+
+	if rollout.Phase == appsv1.UpgradeRolloutPending {
+		if nodeSet.Status.LatestHeight < rollout.Height {
+			rollout.Message = fmt.Sprintf("Waiting for upgrade height %d", rollout.Height)
+			return
+		}
+		logger.Info("upgrade height reached: verifying first rollout stage")
+		rollout.Phase = appsv1.UpgradeRolloutProgressing
+		rollout.StageStartedAt = ptrNow()
+		rollout.StageStartHeight = rollout.Height
+	}
+
+	healthy, failure, reason := checkUpgradeRolloutHealth(strategy, rollout, nodes)
+	if failure != "" {
+		r.haltUpgradeRollout(ctx, nodeSet, rollout, failure)
+		return
+	}
+
+	if !healthy {
+		rollout.HealthySince = nil
+		if rollout.StageStartedAt != nil && time.Since(rollout.StageStartedAt.Time) > strategy.GetTimeout() {
+			r.haltUpgradeRollout(ctx, nodeSet, rollout,
+				fmt.Sprintf("Stage %d did not pass health gates within %s: %s", rollout.Stage, strategy.GetTimeout(), reason),
+			)
+			return
+		}
+		rollout.Message = fmt.Sprintf("Waiting for stage %d to pass health gates: %s", rollout.Stage, reason)
+		return
+	}
+
+	if rollout.HealthySince == nil {
+		rollout.HealthySince = ptrNow()
+	}
+
+	if rollout.Stage >= len(stages)-1 {
+		logger.Info("upgrade rollout completed")
+		rollout.Phase = appsv1.UpgradeRolloutCompleted
+		rollout.Message = fmt.Sprintf("Upgrade rolled out to all %d nodes", len(rollout.Nodes))
+		r.recorder.Eventf(nodeSet,
+			corev1.EventTypeNormal,
+			appsv1.ReasonUpgradeRolloutCompleted,
+			"Upgrade to %s at height %d rolled out to all nodes",
+			rollout.Image, rollout.Height,
+		)
+		return
+	}
+
+	if soak := time.Since(rollout.HealthySince.Time); soak < strategy.GetSoakTime() {
+		rollout.Message = fmt.Sprintf("Stage %d healthy, soaking for %s", rollout.Stage, (strategy.GetSoakTime() - soak).Round(time.Second))
+		return
+	}
+
+	logger.Info("upgrade rollout stage completed", "stage", rollout.Stage)
+	r.recorder.Eventf(nodeSet,
+		corev1.EventTypeNormal,
+		appsv1.ReasonUpgradeRolloutStageCompleted,
+		"Stage %d of upgrade to %s at height %d completed. Releasing upgrade to %s",
+		rollout.Stage, rollout.Image, rollout.Height, strings.Join(stages[rollout.Stage+1], ", "),
+	)
+	rollout.Stage++
+	rollout.Nodes = append(rollout.Nodes, stages[rollout.Stage]...)
+	rollout.StageStartedAt = ptrNow()
+	rollout.StageStartHeight = nodeSet.Status.LatestHeight
+	rollout.HealthySince = nil
+	rollout.Message = fmt.Sprintf("Upgrade released to stage %d", rollout.Stage)
+}
+
+func (r *Reconciler) haltUpgradeRollout(ctx context.Context, nodeSet *appsv1.ChainNodeSet, rollout *appsv1.UpgradeRolloutStatus, message string) {
+	log.FromContext(ctx).Info("halting upgrade rollout", "height", rollout.Height, "reason", message)
+	rollout.Phase = appsv1.UpgradeRolloutHalted
+	rollout.HealthySince = nil
+	rollout.Message = message
+	r.recorder.Eventf(nodeSet,
+		corev1.EventTypeWarning,
+		appsv1.ReasonUpgradeRolloutHalted,
+		"Halted rollout of upgrade to %s at height %d: %s",
+		rollout.Image, rollout.Height, message,
+	)
+}
+
+// checkUpgradeRolloutHealth checks the health gates on all nodes the upgrade was released to. It returns whether
+// all nodes are healthy, a failure message when the rollout must be halted, and the reason nodes are not healthy.
+func checkUpgradeRolloutHealth(strategy *appsv1.UpgradeRolloutStrategy, rollout *appsv1.UpgradeRolloutStatus, nodes map[string]*appsv1.ChainNode) (bool, string, string) {
+	for _, name := range rollout.Nodes {
+		node, ok := nodes[name]
+		if !ok {
+			continue
+		}
+
+		var upgrade *appsv1.Upgrade
+		for i := range node.Status.Upgrades {
+			if node.Status.Upgrades[i].Height == rollout.Height {
+				upgrade = &node.Status.Upgrades[i]
+				break
+			}
+		}
+
+		switch {
+		case upgrade != nil && upgrade.Status == appsv1.UpgradeFailed && slices.Contains(upgrade.FailedImages, rollout.Image):
+			return false, fmt.Sprintf("Upgrade failed on %s", name), ""
+
+		case upgrade == nil || upgrade.Image != rollout.Image ||
+			(upgrade.Status != appsv1.UpgradeCompleted && upgrade.Status != appsv1.UpgradeSkipped):
+			return false, "", fmt.Sprintf("%s did not apply the upgrade yet", name)
+
+		case strategy.ShouldRequireSynced() && node.Status.Phase != appsv1.PhaseChainNodeRunning:
+			return false, "", fmt.Sprintf("%s is %s", name, node.Status.Phase)
+
+		case strategy.ShouldRequireHeightAdvancing() && node.Status.LatestHeight <= rollout.StageStartHeight:
+			return false, "", fmt.Sprintf("%s did not process blocks past height %d", name, rollout.StageStartHeight)
+		}
+	}
+	return true, "", ""
+}
+
+// getUpgradeRolloutStages returns the names of the ChainNodes on each stage of a rollout.
+func getUpgradeRolloutStages(strategy *appsv1.UpgradeRolloutStrategy, nodes map[string]*appsv1.ChainNode) [][]string {
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stages := make([][]string, 0)
+	if len(strategy.Groups) > 0 {
+		assigned := make(map[string]bool)
+		for _, group := range strategy.Groups {
+			var stage []string
+			for _, name := range names {
+				if nodes[name].Labels[controllers.LabelChainNodeSetGroup] == group {
+					stage = append(stage, name)
+					assigned[name] = true
+				}
+			}
+			if len(stage) > 0 {
+				stages = append(stages, stage)
+			}
+		}
+		var rest []string
+		for _, name := range names {
+			if !assigned[name] {
+				rest = append(rest, name)
+			}
+		}
+		if len(rest) > 0 {
+			stages = append(stages, rest)
+		}
+		return stages
+	}
+
+	// Prefer non-validator nodes as canaries
+	sort.SliceStable(names, func(i, j int) bool {
+		return !isValidatorNode(nodes[names[i]]) && isValidatorNode(nodes[names[j]])
+	})
+	canary := min(*strategy.Canary, len(names))
+	if canary > 0 {
+		stages = append(stages, names[:canary])
+	}
+	if len(names) > canary {
+		stages = append(stages, names[canary:])
+	}
+	return stages
+}
+
+// getNodeAppSpec returns the app spec for a ChainNode of this set, without the upgrades whose staged rollout
+// was not released to it yet.
+func getNodeAppSpec(nodeSet *appsv1.ChainNodeSet, name string) appsv1.AppSpec {
+	spec := nodeSet.GetAppSpecWithUpgrades()
+	if nodeSet.Spec.UpgradeRollout == nil {
+		return spec
+	}
+
+	held := make(map[int64]bool)
+	for _, rollout := range nodeSet.Status.UpgradeRollouts {
+		if rollout.Phase != appsv1.UpgradeRolloutCompleted && !slices.Contains(rollout.Nodes, name) {
+			held[rollout.Height] = true
+		}
+	}
+	if len(held) == 0 {
+		return spec
+	}
+
+	upgrades := make([]appsv1.UpgradeSpec, 0, len(spec.Upgrades))
+	for _, upgrade := range spec.Upgrades {
+		if !held[upgrade.Height] {
+			upgrades = append(upgrades, upgrade)
+		}
+	}
+	spec.Upgrades = upgrades
+	return spec
+}
+
+// isUpgradeApplied returns true if any node of the set already applied the upgrade at the given height.
+func isUpgradeApplied(nodeSet *appsv1.ChainNodeSet, height int64) bool {
+	for _, upgrade := range nodeSet.Status.Upgrades {
+		if upgrade.Height == height && upgrade.Status == appsv1.UpgradeCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidatorNode(node *appsv1.ChainNode) bool {
+	return node.Labels[controllers.LabelChainNodeSetValidator] == controllers.StringValueTrue
+}
+
+func ptrNow() *metav1.Time {
+	now := metav1.Now()
+	return &now
+}
@@ -0,0 +1,190 @@
+package chainnodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/controllers"
+)
+
+func upgradeRolloutTestNodeSet(strategy *appsv1.UpgradeRolloutStrategy) *appsv1.ChainNodeSet {
+	return &appsv1.ChainNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "set", Namespace: "default", UID: types.UID("set-uid")},
+		Spec: appsv1.ChainNodeSetSpec{
+			App: appsv1.AppSpec{
+				Image:    "app",
+				Version:  ptr.To("v1"),
+				Upgrades: []appsv1.UpgradeSpec{{Height: 1000, Image: "app:v2"}},
+			},
+			UpgradeRollout: strategy,
+		},
+		Status: appsv1.ChainNodeSetStatus{ChainID: "test-1", LatestHeight: 900},
+	}
+}
+
+func upgradeRolloutTestNode(nodeSet *appsv1.ChainNodeSet, name, group string, validator bool) *appsv1.ChainNode {
+	return &appsv1.ChainNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: nodeSet.GetNamespace(),
+			Labels: map[string]string{
+				controllers.LabelChainNodeSet:          nodeSet.GetName(),
+				controllers.LabelChainNodeSetGroup:     group,
+				controllers.LabelChainNodeSetValidator: map[bool]string{true: "true", false: "false"}[validator],
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: appsv1.GroupVersion.String(),
+				Kind:       "ChainNodeSet",
+				Name:       nodeSet.GetName(),
+				UID:        nodeSet.GetUID(),
+				Controller: ptr.To(true),
+			}},
+		},
+		Status: appsv1.ChainNodeStatus{Phase: appsv1.PhaseChainNodeRunning, LatestHeight: 900},
+	}
+}
+
+func setUpgradeRolloutTestNodeUpgrade(node *appsv1.ChainNode, status appsv1.UpgradePhase, height int64) {
+	node.Status.LatestHeight = height
+	node.Status.Upgrades = []appsv1.Upgrade{{Height: 1000, Image: "app:v2", Status: status, Source: appsv1.ManualUpgrade}}
+	if status == appsv1.UpgradeFailed {
+		node.Status.Upgrades[0].FailedImages = []string{"app:v2"}
+	}
+}
+
+func TestGetUpgradeRolloutStages(t *testing.T) {
+	nodeSet := upgradeRolloutTestNodeSet(nil)
+	nodes := map[string]*appsv1.ChainNode{}
+	for _, node := range []*appsv1.ChainNode{
+		upgradeRolloutTestNode(nodeSet, "set-validator", "validator", true),
+		upgradeRolloutTestNode(nodeSet, "set-rpc-1", "rpc", false),
+		upgradeRolloutTestNode(nodeSet, "set-rpc-0", "rpc", false),
+		upgradeRolloutTestNode(nodeSet, "set-archive-0", "archive", false),
+	} {
+		nodes[node.Name] = node
+	}
+
+	stages := getUpgradeRolloutStages(&appsv1.UpgradeRolloutStrategy{Groups: []string{"rpc", "sentry"}}, nodes)
+	assert.Equal(t, [][]string{{"set-rpc-0", "set-rpc-1"}, {"set-archive-0", "set-validator"}}, stages)
+
+	stages = getUpgradeRolloutStages(&appsv1.UpgradeRolloutStrategy{Canary: ptr.To(1)}, nodes)
+	assert.Equal(t, [][]string{{"set-archive-0"}, {"set-rpc-0", "set-rpc-1", "set-validator"}}, stages)
+
+	stages = getUpgradeRolloutStages(&appsv1.UpgradeRolloutStrategy{Canary: ptr.To(5)}, nodes)
+	assert.Len(t, stages, 1)
+}
+
+func TestEnsureUpgradeRolloutsProgressesStages(t *testing.T) {
+	nodeSet := upgradeRolloutTestNodeSet(&appsv1.UpgradeRolloutStrategy{Canary: ptr.To(1), SoakTime: ptr.To("0s")})
+	canary := upgradeRolloutTestNode(nodeSet, "set-rpc-0", "rpc", false)
+	other := upgradeRolloutTestNode(nodeSet, "set-rpc-1", "rpc", false)
+	r := newGenesisTestReconciler(t, nodeSet, canary, other)
+	recorder := r.recorder.(*record.FakeRecorder)
+	ctx := context.Background()
+
+	// Upgrade is released to the canary only
+	require.NoError(t, r.ensureUpgradeRollouts(ctx, nodeSet))
+	require.Len(t, nodeSet.Status.UpgradeRollouts, 1)
+	rollout := nodeSet.Status.UpgradeRollouts[0]
+	assert.Equal(t, appsv1.UpgradeRolloutPending, rollout.Phase)
+	assert.Equal(t, []string{"set-rpc-0"}, rollout.Nodes)
+	assert.Equal(t, 2, rollout.Stages)
+	assert.Len(t, getNodeAppSpec(nodeSet, "set-rpc-0").Upgrades, 1)
+	assert.Empty(t, getNodeAppSpec(nodeSet, "set-rpc-1").Upgrades)
+
+	// Canary did not apply the upgrade yet
+	nodeSet.Status.LatestHeight = 1000
+	require.NoError(t, r.ensureUpgradeRollouts(ctx, nodeSet))
+	assert.Equal(t, appsv1.UpgradeRolloutProgressing, nodeSet.Status.UpgradeRollouts[0].Phase)
+	assert.Nil(t, nodeSet.Status.UpgradeRollouts[0].HealthySince)
+
+	// Canary is healthy, so the next stage is released
+	setUpgradeRolloutTestNodeUpgrade(canary, appsv1.UpgradeCompleted, 1010)
+	require.NoError(t, r.Update(ctx, canary))
+	nodeSet.Status.LatestHeight = 1010
+	require.NoError(t, r.ensureUpgradeRollouts(ctx, nodeSet))
+	rollout = nodeSet.Status.UpgradeRollouts[0]
+	assert.Equal(t, 1, rollout.Stage)
+	assert.Equal(t, []string{"set-rpc-0", "set-rpc-1"}, rollout.Nodes)
+	assert.Len(t, getNodeAppSpec(nodeSet, "set-rpc-1").Upgrades, 1)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeRolloutStageCompleted)
+
+	// Held node switches image with the upgrade skipped
+	setUpgradeRolloutTestNodeUpgrade(other, appsv1.UpgradeSkipped, 1020)
+	require.NoError(t, r.Update(ctx, other))
+	require.NoError(t, r.ensureUpgradeRollouts(ctx, nodeSet))
+	assert.Equal(t, appsv1.UpgradeRolloutProgressing, nodeSet.Status.UpgradeRollouts[0].Phase)
+
+	// Every node the upgrade was released to must keep processing blocks
+	canary.Status.LatestHeight = 1020
+	require.NoError(t, r.Update(ctx, canary))
+	require.NoError(t, r.ensureUpgradeRollouts(ctx, nodeSet))
+	assert.Equal(t, appsv1.UpgradeRolloutCompleted, nodeSet.Status.UpgradeRollouts[0].Phase)
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeRolloutCompleted)
+
+	stored := &appsv1.ChainNodeSet{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(nodeSet), stored))
+	assert.Equal(t, appsv1.UpgradeRolloutCompleted, stored.Status.UpgradeRollouts[0].Phase)
+}
+
+func TestEnsureUpgradeRolloutsHaltsOnFailure(t *testing.T) {
+	nodeSet := upgradeRolloutTestNodeSet(&appsv1.UpgradeRolloutStrategy{Groups: []string{"rpc"}})
+	nodeSet.Status.LatestHeight = 1000
+	nodeSet.Status.UpgradeRollouts = []appsv1.UpgradeRolloutStatus{{
+		Height:         1000,
+		Image:          "app:v2",
+		Phase:          appsv1.UpgradeRolloutProgressing,
+		Stages:         2,
+		Nodes:          []string{"set-rpc-0"},
+		StageStartedAt: ptr.To(metav1.NewTime(time.Now().Add(-time.Minute))),
+	}}
+	node := upgradeRolloutTestNode(nodeSet, "set-rpc-0", "rpc", false)
+	setUpgradeRolloutTestNodeUpgrade(node, appsv1.UpgradeFailed, 1000)
+	held := upgradeRolloutTestNode(nodeSet, "set-archive-0", "archive", false)
+	r := newGenesisTestReconciler(t, nodeSet, node, held)
+	recorder := r.recorder.(*record.FakeRecorder)
+
+	require.NoError(t, r.ensureUpgradeRollouts(context.Background(), nodeSet))
+	rollout := nodeSet.Status.UpgradeRollouts[0]
+	assert.Equal(t, appsv1.UpgradeRolloutHalted, rollout.Phase)
+	assert.Contains(t, rollout.Message, "set-rpc-0")
+	assert.Contains(t, <-recorder.Events, appsv1.ReasonUpgradeRolloutHalted)
+	assert.Empty(t, getNodeAppSpec(nodeSet, "set-archive-0").Upgrades)
+
+	// A new image restarts the rollout
+	nodeSet.Spec.App.Upgrades[0].Image = "app:v2.0.1"
+	require.NoError(t, r.ensureUpgradeRollouts(context.Background(), nodeSet))
+	assert.Equal(t, appsv1.UpgradeRolloutProgressing, nodeSet.Status.UpgradeRollouts[0].Phase)
+	assert.Equal(t, "app:v2.0.1", nodeSet.Status.UpgradeRollouts[0].Image)
+}
+
+func TestEnsureUpgradeRolloutsHaltsOnTimeout(t *testing.T) {
+	nodeSet := upgradeRolloutTestNodeSet(&appsv1.UpgradeRolloutStrategy{Canary: ptr.To(1), Timeout: ptr.To("10m")})
+	nodeSet.Status.LatestHeight = 1000
+	nodeSet.Status.UpgradeRollouts = []appsv1.UpgradeRolloutStatus{{
+		Height:           1000,
+		Image:            "app:v2",
+		Phase:            appsv1.UpgradeRolloutProgressing,
+		Nodes:            []string{"set-rpc-0"},
+		StageStartedAt:   ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))),
+		StageStartHeight: 1000,
+	}}
+	node := upgradeRolloutTestNode(nodeSet, "set-rpc-0", "rpc", false)
+	setUpgradeRolloutTestNodeUpgrade(node, appsv1.UpgradeCompleted, 1000)
+	r := newGenesisTestReconciler(t, nodeSet, node, upgradeRolloutTestNode(nodeSet, "set-rpc-1", "rpc", false))
+
+	require.NoError(t, r.ensureUpgradeRollouts(context.Background(), nodeSet))
+	rollout := nodeSet.Status.UpgradeRollouts[0]
+	assert.Equal(t, appsv1.UpgradeRolloutHalted, rollout.Phase)
+	assert.Contains(t, rollout.Message, "did not process blocks past height 1000")
+}
@@ -65,6 +65,11 @@ func AddOrUpdateUpgrade(upgrades []appsv1.Upgrade, upgrade appsv1.Upgrade) []app
 			return upgrades
 		}
 	}
+	// Pre-upgrade snapshots, image pre-pulls and rehearsals are done per node, so they are only tracked on each
+	// ChainNode.
+	upgrade.Snapshot = ""
+	upgrade.PrePulledOn = ""
+	upgrade.Rehearsal = nil
 	upgrades = append(upgrades, upgrade)
 	return upgrades
 }
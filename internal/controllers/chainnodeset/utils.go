@@ -2,6 +2,7 @@ package chainnodeset
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -194,6 +196,40 @@ func (r *Reconciler) ensureStatefulSet(ctx context.Context, ss *appsv1.StatefulS
 	return nil
 }
 
+func (r *Reconciler) ensureDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
+	logger := log.FromContext(ctx).WithValues("deployment", deployment.GetName())
+
+	current := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(deployment), current)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("creating deployment")
+			return r.Create(ctx, deployment)
+		}
+		return err
+	}
+	if desiredOwner := metav1.GetControllerOf(deployment); desiredOwner != nil {
+		currentOwner := metav1.GetControllerOf(current)
+		if currentOwner == nil || currentOwner.UID != desiredOwner.UID {
+			return fmt.Errorf("deployment %q is managed by another owner or is unowned; refusing to overwrite it", deployment.GetName())
+		}
+	}
+
+	patchResult, err := patch.DefaultPatchMaker.Calculate(current, deployment, patch.IgnoreStatusFields())
+	if err != nil {
+		return err
+	}
+
+	if !patchResult.IsEmpty() {
+		logger.Info("updating deployment")
+		deployment.ObjectMeta.ResourceVersion = current.ObjectMeta.ResourceVersion
+		return r.Update(ctx, deployment)
+	}
+
+	*deployment = *current
+	return nil
+}
+
 // parsePublicAddress parses a ChainNode Status.PublicAddress of the form "<nodeID>@<host>:<port>"
 // into its host and port. ok is false when the value is empty or not in that form.
 func parsePublicAddress(publicAddress string) (host string, port int, ok bool) {
@@ -430,15 +430,16 @@ func (r *Reconciler) getValidatorSpecWithBlockedSignerTargets(nodeSet *appsv1.Ch
 		}
 	}
 
+	name := validatorNodeName(nodeSet, group, index)
 	validator := &appsv1.ChainNode{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      validatorNodeName(nodeSet, group, index),
+			Name:      name,
 			Namespace: nodeSet.GetNamespace(),
 			Labels:    labels,
 		},
 		Spec: appsv1.ChainNodeSpec{
 			Genesis:        genesisConfig,
-			App:            nodeSet.GetAppSpecWithUpgrades(),
+			App:            getNodeAppSpec(nodeSet, name),
 			DeletionPolicy: nodeSet.Spec.DeletionPolicy.DeepCopy(),
 			Config:         configForChild(cfg.Config),
 			Persistence:    cfg.Persistence,
@@ -451,6 +452,13 @@ func (r *Reconciler) getValidatorSpecWithBlockedSignerTargets(nodeSet *appsv1.Ch
 				AccountHDPath:    cfg.AccountHDPath,
 				AccountPrefix:    cfg.AccountPrefix,
 				ValPrefix:        cfg.ValPrefix,
+				MissedBlocks:     cfg.MissedBlocks,
+				AutoUnjail:       cfg.AutoUnjail,
+				Governance:       cfg.Governance,
+				AutoRestake:      cfg.AutoRestake,
+				LowBalance:       cfg.LowBalance,
+				SigningLease:     cfg.SigningLease,
+				DoubleSignCheck:  getDoubleSignCheckConfig(nodeSet, cfg.DoubleSignCheck),
 			},
 			Resources:          cfg.Resources,
 			Affinity:           cfg.Affinity,
@@ -459,6 +467,7 @@ func (r *Reconciler) getValidatorSpecWithBlockedSignerTargets(nodeSet *appsv1.Ch
 			StateSyncResources: cfg.StateSyncResources,
 			VPA:                cfg.VPA,
 			OverrideVersion:    cfg.OverrideVersion,
+			Monitoring:         nodeSet.Spec.Monitoring.DeepCopy(),
 		},
 	}
 
@@ -472,6 +481,23 @@ func (r *Reconciler) getValidatorSpecWithBlockedSignerTargets(nodeSet *appsv1.Ch
 	return validator, controllerutil.SetControllerReference(nodeSet, validator, r.Scheme)
 }
 
+// getDoubleSignCheckConfig returns the double-sign check config of a validator, with the internal services of
+// the non-validator groups of the set added to the trusted RPC endpoints.
+func getDoubleSignCheckConfig(nodeSet *appsv1.ChainNodeSet, cfg *appsv1.DoubleSignCheckConfig) *appsv1.DoubleSignCheckConfig {
+	if cfg == nil {
+		return nil
+	}
+	derived := cfg.DeepCopy()
+	for _, group := range nodeSet.Spec.Nodes {
+		if group.Validator != nil {
+			continue
+		}
+		derived.RPCs = append(derived.RPCs, fmt.Sprintf("http://%s-internal.%s.svc.cluster.local:%d",
+			group.GetServiceName(nodeSet), nodeSet.GetNamespace(), chainutils.RpcPort))
+	}
+	return derived
+}
+
 // deriveGroupValidatorConfig returns the per-instance validator config to use for a
 // validator group. For a group that initializes genesis (Init != nil) with more than
 // one instance:
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	k8sappsv1 "k8s.io/api/apps/v1"
@@ -48,6 +49,7 @@ func newValidatorTestReconciler(t *testing.T, objs ...client.Object) *Reconciler
 	require.NoError(t, policyv1.AddToScheme(scheme))
 	require.NoError(t, networkingv1.AddToScheme(scheme))
 	require.NoError(t, gwapiv1.Install(scheme))
+	require.NoError(t, monitoringv1.AddToScheme(scheme))
 
 	cl := fake.NewClientBuilder().
 		WithScheme(scheme).
@@ -170,6 +172,31 @@ func TestGetValidatorSpecUsesValidatorScopedFields(t *testing.T) {
 	assert.Equal(t, []string{"persistence", "overrideVersion"}, group.MisplacedValidatorScopedFields())
 }
 
+// TestGetDoubleSignCheckConfig verifies that the internal services of the non-validator groups are added to
+// the trusted RPC endpoints of the double-sign check, without changing the user-provided config.
+func TestGetDoubleSignCheckConfig(t *testing.T) {
+	nodeSet := &appsv1.ChainNodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-nodeset", Namespace: "default"},
+		Spec: appsv1.ChainNodeSetSpec{
+			Nodes: []appsv1.NodeGroupSpec{
+				{Name: "fullnodes"},
+				{Name: "validators", Validator: &appsv1.NodeSetValidatorConfig{}},
+				{Name: "archive"},
+			},
+		},
+	}
+	assert.Nil(t, getDoubleSignCheckConfig(nodeSet, nil))
+
+	cfg := &appsv1.DoubleSignCheckConfig{RPCs: []string{"https://rpc.example.com:443"}}
+	derived := getDoubleSignCheckConfig(nodeSet, cfg)
+	assert.Equal(t, []string{
+		"https://rpc.example.com:443",
+		"http://test-nodeset-fullnodes-internal.default.svc.cluster.local:26657",
+		"http://test-nodeset-archive-internal.default.svc.cluster.local:26657",
+	}, derived.RPCs)
+	assert.Equal(t, []string{"https://rpc.example.com:443"}, cfg.RPCs)
+}
+
 // TestDeriveGroupValidatorConfigInitWithMultipleInstances verifies the per-instance
 // validator config derivation for a genesis-initializing group with multiple instances:
 // instance 0 keeps Init and records the other validators in Init.GenesisValidators (so they
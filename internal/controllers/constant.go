@@ -26,10 +26,12 @@ const (
 	AnnotationVaultKeyUploaded                     = "cosmopilot.voluzi.com/vault-key-uploaded"
 	AnnotationPvcSnapshotInProgress                = "cosmopilot.voluzi.com/snapshotting-pvc"
 	AnnotationLastPvcSnapshot                      = "cosmopilot.voluzi.com/last-pvc-snapshot"
+	AnnotationSnapshotHeightCheckpoint             = "cosmopilot.voluzi.com/snapshot-height-checkpoint"
 	AnnotationSnapshotRetention                    = "cosmopilot.voluzi.com/snapshot-retention"
 	AnnotationPvcSnapshotReady                     = "cosmopilot.voluzi.com/snapshot-ready"
 	AnnotationExportingTarball                     = "cosmopilot.voluzi.com/exporting-tarball"
 	AnnotationTarballExportAttempts                = "cosmopilot.voluzi.com/tarball-export-attempts"
+	AnnotationTarballExportStartTime               = "cosmopilot.voluzi.com/tarball-export-start-time"
 	AnnotationTarballDeletionComplete              = "cosmopilot.voluzi.com/tarball-deletion-complete"
 	AnnotationTarballDeletionName                  = "cosmopilot.voluzi.com/tarball-deletion-name"
 	AnnotationSnapshotExportCleanupAcknowledgement = "cosmopilot.voluzi.com/acknowledge-snapshot-export-cleanup"
@@ -41,6 +43,10 @@ const (
 	AnnotationVPALastCPUScale                      = "cosmopilot.voluzi.com/last-cpu-scale"
 	AnnotationVPALastMemoryScale                   = "cosmopilot.voluzi.com/last-memory-scale"
 	AnnotationVPAOOMRecoveryHistory                = "cosmopilot.voluzi.com/oom-recovery-history"
+	AnnotationAutoUnjailHistory                    = "cosmopilot.voluzi.com/auto-unjail-history"
+	AnnotationAutoUnjailSyncedHeight               = "cosmopilot.voluzi.com/auto-unjail-synced-height"
+	AnnotationUpgradePendingVerification           = "cosmopilot.voluzi.com/upgrade-pending-verification"
+	AnnotationUpgradeRollbackSnapshot              = "cosmopilot.voluzi.com/upgrade-rollback-snapshot"
 	AnnotationStatefulSetPodName                   = "statefulset.kubernetes.io/pod-name"
 
 	LabelNodeID                = "node-id"
@@ -56,6 +62,11 @@ const (
 	LabelSeed                  = "seed"
 	LabelPeer                  = "peer"
 	LabelUpgrading             = "upgrading"
+	LabelUpgradeHeight         = "upgrade-height"
+	LabelUpgradeRehearsal      = "upgrade-rehearsal"
+	// LabelInSync marks whether a node of a ChainNodeSet is close enough to the highest node of the set to
+	// serve traffic through group and global Services.
+	LabelInSync = "in-sync"
 	// LabelCosmosignerTarget marks a node as a signing endpoint for a cosmosigner deployment.
 	// The cosmosigner discovery service selects pods carrying this label so a single service can
 	// target one or more node groups uniformly.
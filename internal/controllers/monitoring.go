@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// MonitorParams describes the targets scraped by a ServiceMonitor or PodMonitor.
+type MonitorParams struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+
+	// Selector matches the Services scraped by a ServiceMonitor, or the Pods scraped by a PodMonitor.
+	Selector map[string]string
+
+	// Ports are the names of the Service ports (or container ports for a PodMonitor) serving metrics.
+	Ports    []string
+	Interval time.Duration
+}
+
+// ServiceMonitor renders a ServiceMonitor scraping the ports of the selected Services.
+func (p MonitorParams) ServiceMonitor() *monitoringv1.ServiceMonitor {
+	endpoints := make([]monitoringv1.Endpoint, len(p.Ports))
+	for i, port := range p.Ports {
+		endpoints[i] = monitoringv1.Endpoint{Port: port, Interval: PrometheusDuration(p.Interval)}
+	}
+	return &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector:  metav1.LabelSelector{MatchLabels: p.Selector},
+			Endpoints: endpoints,
+		},
+	}
+}
+
+// PodMonitor renders a PodMonitor scraping the ports of the selected Pods.
+func (p MonitorParams) PodMonitor() *monitoringv1.PodMonitor {
+	endpoints := make([]monitoringv1.PodMetricsEndpoint, len(p.Ports))
+	for i, port := range p.Ports {
+		endpoints[i] = monitoringv1.PodMetricsEndpoint{Port: ptr.To(port), Interval: PrometheusDuration(p.Interval)}
+	}
+	return &monitoringv1.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Labels:    p.Labels,
+		},
+		Spec: monitoringv1.PodMonitorSpec{
+			Selector:            metav1.LabelSelector{MatchLabels: p.Selector},
+			PodMetricsEndpoints: endpoints,
+		},
+	}
+}
+
+// PrometheusDuration formats a duration the way Prometheus parses it (e.g. `1h30m`).
+func PrometheusDuration(d time.Duration) monitoringv1.Duration {
+	return monitoringv1.Duration(model.Duration(d).String())
+}
+
+// EnsureServiceMonitor creates or updates the given ServiceMonitor. The returned bool is false when the
+// Prometheus Operator CRDs are not installed in the cluster, in which case the call is a no-op.
+func EnsureServiceMonitor(ctx context.Context, c client.Client, monitor *monitoringv1.ServiceMonitor) (bool, error) {
+	return ensureMonitoringObject(ctx, c, monitor, &monitoringv1.ServiceMonitor{}, "servicemonitor")
+}
+
+// EnsurePodMonitor creates or updates the given PodMonitor. See EnsureServiceMonitor for the meaning of the
+// bool return value.
+func EnsurePodMonitor(ctx context.Context, c client.Client, monitor *monitoringv1.PodMonitor) (bool, error) {
+	return ensureMonitoringObject(ctx, c, monitor, &monitoringv1.PodMonitor{}, "podmonitor")
+}
+
+// EnsurePrometheusRule creates or updates the given PrometheusRule. See EnsureServiceMonitor for the meaning
+// of the bool return value.
+func EnsurePrometheusRule(ctx context.Context, c client.Client, rule *monitoringv1.PrometheusRule) (bool, error) {
+	return ensureMonitoringObject(ctx, c, rule, &monitoringv1.PrometheusRule{}, "prometheusrule")
+}
+
+// ensureMonitoringObject creates or updates obj, using current to read the existing object. Objects with
+// the same name that are controlled by someone else are never overwritten.
+func ensureMonitoringObject(ctx context.Context, c client.Client, obj, current client.Object, kind string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if err != nil {
+		if IsCRDNotInstalled(err) {
+			return false, nil
+		}
+		if errors.IsNotFound(err) {
+			logger.Info("creating "+kind, kind, obj.GetName())
+			if err = patch.DefaultAnnotator.SetLastAppliedAnnotation(obj); err != nil {
+				return false, err
+			}
+			if err = c.Create(ctx, obj); err != nil {
+				if IsCRDNotInstalled(err) {
+					return false, nil
+				}
+				return false, err
+			}
+			return true, nil
+		}
+		return false, err
+	}
+
+	if want, got := metav1.GetControllerOf(obj), metav1.GetControllerOf(current); want != nil && (got == nil || got.UID != want.UID) {
+		return false, fmt.Errorf("%s %q is managed by another owner; refusing to overwrite it", kind, current.GetName())
+	}
+
+	patchResult, err := patch.DefaultPatchMaker.Calculate(current, obj)
+	if err != nil {
+		return false, err
+	}
+
+	if !patchResult.IsEmpty() {
+		logger.Info("updating "+kind, kind, obj.GetName())
+		if err = patch.DefaultAnnotator.SetLastAppliedAnnotation(obj); err != nil {
+			return false, err
+		}
+		obj.SetResourceVersion(current.GetResourceVersion())
+		if err = c.Update(ctx, obj); err != nil {
+			if IsCRDNotInstalled(err) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// DeleteOwnedMonitoringObject deletes the monitor or rule with the name and namespace of obj when it is
+// controlled by owner. Missing objects and missing Prometheus Operator CRDs are not errors.
+func DeleteOwnedMonitoringObject(ctx context.Context, c client.Client, owner metav1.Object, obj client.Object) error {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if errors.IsNotFound(err) || IsCRDNotInstalled(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(obj, owner) {
+		return nil
+	}
+	log.FromContext(ctx).Info("deleting monitoring resource", "name", obj.GetName())
+	if err := c.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) && !IsCRDNotInstalled(err) {
+		return err
+	}
+	return nil
+}
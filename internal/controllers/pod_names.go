@@ -9,6 +9,7 @@ var deterministicChainNodePodSuffixes = [...]string{
 	"-create-validator",
 	"-tmkms-generate-identity",
 	"-tmkms-vault-upload",
+	"-image-prepull",
 }
 
 // IsDeterministicChainNodePodName reports whether podName is the main ChainNode pod or one of the
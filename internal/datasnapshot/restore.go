@@ -0,0 +1,42 @@
+package datasnapshot
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
+)
+
+// restoreDataDir is where the init pod mounts the data volume.
+const restoreDataDir = "/home/app/data"
+
+// RestoreCommand returns the data initialization command that downloads an exported tarball and
+// extracts it into the data volume. When latest is true, archive is used as a prefix and the most
+// recent archive matching it is restored.
+func RestoreCommand(cfg *appsv1.TarballRestoreConfig, dataExporterImage, archive string, latest bool) *chainutils.InitCommand {
+	cmd := &chainutils.InitCommand{
+		Image: dataExporterImage,
+		Env: []corev1.EnvVar{
+			{Name: "LATEST", Value: strconv.FormatBool(latest)},
+		},
+	}
+
+	if cfg.GCS != nil {
+		gcs := &GCS{Config: cfg.GCS}
+		cmd.Args = []string{"gcs", "download", cfg.GCS.Bucket, archive, restoreDataDir}
+		cmd.Env = append(cmd.Env, gcs.credentialsEnv()...)
+		cmd.Volumes = gcs.credentialsVolume()
+		cmd.VolumeMounts = gcs.credentialsVolumeMount()
+		cmd.ServiceAccountName = gcs.serviceAccountName()
+		return cmd
+	}
+
+	s3 := &S3{Config: cfg.S3}
+	cmd.Args = []string{"s3", "download", cfg.S3.Bucket, archive, restoreDataDir}
+	cmd.Env = append(cmd.Env, s3.storageEnv()...)
+	cmd.EnvFrom = s3.credentialsEnvFrom()
+	cmd.ServiceAccountName = s3.serviceAccountName()
+	return cmd
+}
@@ -0,0 +1,54 @@
+package datasnapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1 "github.com/voluzi/cosmopilot/v3/api/v1"
+)
+
+func TestRestoreCommandGCSWithCredentialsSecret(t *testing.T) {
+	cmd := RestoreCommand(&appsv1.TarballRestoreConfig{
+		GCS: &appsv1.GcsExportConfig{
+			Bucket: "snapshots",
+			CredentialsSecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "gcs-credentials"},
+				Key:                  "credentials.json",
+			},
+		},
+	}, "dataexporter:latest", "cosmoshub-4-", true)
+
+	assert.Equal(t, "dataexporter:latest", cmd.Image)
+	assert.Equal(t, []string{"gcs", "download", "snapshots", "cosmoshub-4-", "/home/app/data"}, cmd.Args)
+	assert.Contains(t, cmd.Env, corev1.EnvVar{Name: "LATEST", Value: "true"})
+	assert.Contains(t, cmd.Env, corev1.EnvVar{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/creds/credentials.json"})
+	require.Len(t, cmd.Volumes, 1)
+	assert.Equal(t, "gcs-credentials", cmd.Volumes[0].Secret.SecretName)
+	require.Len(t, cmd.VolumeMounts, 1)
+	assert.Equal(t, "/creds", cmd.VolumeMounts[0].MountPath)
+	assert.Empty(t, cmd.ServiceAccountName)
+}
+
+func TestRestoreCommandS3WithServiceAccount(t *testing.T) {
+	cmd := RestoreCommand(&appsv1.TarballRestoreConfig{
+		S3: &appsv1.S3ExportConfig{
+			Bucket:             "snapshots",
+			Region:             "eu-west-1",
+			Endpoint:           ptr.To("https://minio.example.com"),
+			ForcePathStyle:     ptr.To(true),
+			ServiceAccountName: ptr.To("snapshot-reader"),
+		},
+	}, "dataexporter:latest", "osmosis-1-20240101120000", false)
+
+	assert.Equal(t, []string{"s3", "download", "snapshots", "osmosis-1-20240101120000", "/home/app/data"}, cmd.Args)
+	assert.Contains(t, cmd.Env, corev1.EnvVar{Name: "LATEST", Value: "false"})
+	assert.Contains(t, cmd.Env, corev1.EnvVar{Name: "AWS_REGION", Value: "eu-west-1"})
+	assert.Contains(t, cmd.Env, corev1.EnvVar{Name: "S3_ENDPOINT", Value: "https://minio.example.com"})
+	assert.Contains(t, cmd.Env, corev1.EnvVar{Name: "S3_FORCE_PATH_STYLE", Value: "true"})
+	assert.Empty(t, cmd.EnvFrom)
+	assert.Equal(t, "snapshot-reader", cmd.ServiceAccountName)
+}
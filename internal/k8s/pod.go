@@ -147,7 +147,7 @@ func (p *PodHelper) WaitForPodPhase(ctx context.Context, timeout time.Duration,
 		default:
 			*p.pod = *event.Object.(*corev1.Pod)
 			if p.pod.Status.Phase == corev1.PodFailed {
-				return false, fmt.Errorf("pod failed: %s", p.getFailureReason(ctx))
+				return false, fmt.Errorf("pod failed: %s", p.GetFailureReason(ctx))
 			}
 			return p.pod.Status.Phase == phase, nil
 		}
@@ -369,7 +369,7 @@ func (p *PodHelper) WaitForContainerStarted(ctx context.Context, timeout time.Du
 					}
 
 					if c.State.Terminated != nil && c.State.Terminated.ExitCode != 0 {
-						return false, fmt.Errorf("container failed: %v", p.getFailureReason(ctx))
+						return false, fmt.Errorf("container failed: %v", p.GetFailureReason(ctx))
 					}
 				}
 			}
@@ -403,7 +403,8 @@ func (p *PodHelper) GetLogs(ctx context.Context, container string) (string, erro
 	return strings.TrimSpace(buf.String()), nil
 }
 
-func (p *PodHelper) getFailureReason(ctx context.Context) string {
+// GetFailureReason returns the logs of the first container of the pod that exited with an error.
+func (p *PodHelper) GetFailureReason(ctx context.Context) string {
 	for _, c := range append(p.pod.Status.ContainerStatuses, p.pod.Status.InitContainerStatuses...) {
 		if !c.Ready && c.State.Terminated != nil {
 			if c.State.Terminated.ExitCode != 0 {
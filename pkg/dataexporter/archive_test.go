@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v4"
@@ -229,3 +230,161 @@ func TestIsArchiveObjectName(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractTarballRoundTrip(t *testing.T) {
+	testContent := map[string]string{
+		"application.db/000001.log": "application state",
+		"priv_validator_state.json": `{"height":"100"}`,
+	}
+
+	for _, compression := range []Compression{CompressionNone, CompressionGzip, CompressionZstd, CompressionLz4} {
+		t.Run(string(compression), func(t *testing.T) {
+			source := t.TempDir()
+			for name, content := range testContent {
+				path := filepath.Join(source, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatalf("create test directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatalf("write test file: %v", err)
+				}
+			}
+
+			var archive bytes.Buffer
+			if err := writeTarball(source, &archive, compression); err != nil {
+				t.Fatalf("writeTarball() error = %v", err)
+			}
+
+			destination := t.TempDir()
+			// Files created by the app init container must be overwritten by the archive contents.
+			if err := os.WriteFile(filepath.Join(destination, "priv_validator_state.json"), []byte("{}"), 0o600); err != nil {
+				t.Fatalf("write existing file: %v", err)
+			}
+			if err := extractTarball(&archive, destination, compression); err != nil {
+				t.Fatalf("extractTarball() error = %v", err)
+			}
+			for name, content := range testContent {
+				got, err := os.ReadFile(filepath.Join(destination, name))
+				if err != nil {
+					t.Fatalf("read extracted %q: %v", name, err)
+				}
+				if string(got) != content {
+					t.Errorf("extracted %q = %q, want %q", name, got, content)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractTarballRejectsEntriesOutsideDestination(t *testing.T) {
+	tests := []struct {
+		name string
+		hdr  *tar.Header
+	}{
+		{name: "parent traversal", hdr: &tar.Header{Name: "../escape", Typeflag: tar.TypeReg, Mode: 0o644}},
+		{name: "absolute symlink", hdr: &tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc"}},
+		{name: "relative symlink", hdr: &tar.Header{Name: "nested/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var archive bytes.Buffer
+			tw := tar.NewWriter(&archive)
+			if err := tw.WriteHeader(tt.hdr); err != nil {
+				t.Fatalf("write header: %v", err)
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatalf("close tar writer: %v", err)
+			}
+			if err := extractTarball(&archive, t.TempDir(), CompressionNone); err == nil {
+				t.Fatal("extractTarball() expected an error")
+			}
+		})
+	}
+}
+
+func TestResolveArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		objects     []string
+		want        []string
+		compression Compression
+		wantErr     bool
+	}{
+		{
+			name:        "single archive",
+			objects:     []string{"snapshot.tar.zst", "snapshot-old.tar.gz"},
+			want:        []string{"snapshot.tar.zst"},
+			compression: CompressionZstd,
+		},
+		{
+			name:        "split archive is ordered by part number",
+			objects:     []string{"snapshot-part-10.tar.gz", "snapshot-part-00.tar.gz", "snapshot-part-01.tar.gz", "snapshot-part-02.tar.gz", "snapshot-part-03.tar.gz", "snapshot-part-04.tar.gz", "snapshot-part-05.tar.gz", "snapshot-part-06.tar.gz", "snapshot-part-07.tar.gz", "snapshot-part-08.tar.gz", "snapshot-part-09.tar.gz"},
+			want:        []string{"snapshot-part-00.tar.gz", "snapshot-part-01.tar.gz", "snapshot-part-02.tar.gz", "snapshot-part-03.tar.gz", "snapshot-part-04.tar.gz", "snapshot-part-05.tar.gz", "snapshot-part-06.tar.gz", "snapshot-part-07.tar.gz", "snapshot-part-08.tar.gz", "snapshot-part-09.tar.gz", "snapshot-part-10.tar.gz"},
+			compression: CompressionGzip,
+		},
+		{
+			name:    "unfinished upload chunks are ignored",
+			objects: []string{"snapshot-part-00000000", "snapshot.tar.gz-temp-0-1"},
+			wantErr: true,
+		},
+		{
+			name:    "missing part",
+			objects: []string{"snapshot-part-0.tar", "snapshot-part-2.tar"},
+			wantErr: true,
+		},
+		{
+			name:    "mixed compression",
+			objects: []string{"snapshot-part-0.tar", "snapshot-part-1.tar.lz4"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := make([]archiveObject, len(tt.objects))
+			for i, name := range tt.objects {
+				objects[i] = archiveObject{Name: name}
+			}
+			layout, err := resolveArchive("snapshot", objects)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveArchive() = %v, want error", layout.Objects)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveArchive() error = %v", err)
+			}
+			if fmt.Sprint(layout.Objects) != fmt.Sprint(tt.want) {
+				t.Fatalf("resolveArchive() objects = %v, want %v", layout.Objects, tt.want)
+			}
+			if layout.Compression != tt.compression {
+				t.Fatalf("resolveArchive() compression = %q, want %q", layout.Compression, tt.compression)
+			}
+		})
+	}
+}
+
+func TestLatestArchive(t *testing.T) {
+	now := time.Now()
+	objects := []archiveObject{
+		{Name: "cosmoshub-4-20240101000000.tar.gz", Updated: now.Add(-48 * time.Hour)},
+		{Name: "cosmoshub-4-20240102000000-part-0.tar.zst", Updated: now.Add(-2 * time.Hour)},
+		{Name: "cosmoshub-4-20240102000000-part-1.tar.zst", Updated: now.Add(-time.Hour)},
+		{Name: "cosmoshub-4-20240103000000-part-00000000", Updated: now},
+		{Name: "osmosis-1-20240104000000.tar.gz", Updated: now},
+	}
+
+	got, err := latestArchive("cosmoshub-4-", objects)
+	if err != nil {
+		t.Fatalf("latestArchive() error = %v", err)
+	}
+	if got != "cosmoshub-4-20240102000000" {
+		t.Fatalf("latestArchive() = %q, want cosmoshub-4-20240102000000", got)
+	}
+
+	if _, err := latestArchive("juno-1-", objects); err == nil {
+		t.Fatal("latestArchive() expected an error when no archive matches")
+	}
+}
@@ -2,6 +2,7 @@ package dataexporter
 
 import (
 	"archive/tar"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -170,6 +171,125 @@ func newCompressionWriter(out io.Writer, compression Compression) (io.WriteClose
 	}
 }
 
+func newCompressionReader(in io.Reader, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(in), nil
+	case CompressionGzip:
+		reader, err := pgzip.NewReader(in)
+		if err != nil {
+			return nil, fmt.Errorf("create gzip reader: %w", err)
+		}
+		return reader, nil
+	case CompressionZstd:
+		reader, err := zstd.NewReader(in)
+		if err != nil {
+			return nil, fmt.Errorf("create zstd reader: %w", err)
+		}
+		return reader.IOReadCloser(), nil
+	case CompressionLz4:
+		return io.NopCloser(lz4.NewReader(in)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+}
+
+// extractTarball unpacks a tar archive produced by writeTarball into dir. Entries that would be
+// written outside dir, either directly or through a symlink, are rejected.
+func extractTarball(in io.Reader, dir string, compression Compression) error {
+	decompressed, err := newCompressionReader(in, compression)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return fmt.Errorf("create %q: %w", root, err)
+	}
+
+	tr := tar.NewReader(decompressed)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar header: %w", err)
+		}
+
+		target, err := extractionPath(root, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("create %q: %w", target, err)
+			}
+
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return fmt.Errorf("create %q: %w", filepath.Dir(target), err)
+			}
+			// Remove whatever is there first so that an existing symlink is replaced rather than followed.
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("replace %q: %w", target, err)
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return fmt.Errorf("create %q: %w", target, err)
+			}
+			_, copyErr := io.Copy(f, tr)
+			closeErr := f.Close()
+			if copyErr != nil {
+				return fmt.Errorf("extract %q: %w", target, copyErr)
+			}
+			if closeErr != nil {
+				return fmt.Errorf("close %q: %w", target, closeErr)
+			}
+
+		case tar.TypeSymlink:
+			linkTarget := hdr.Linkname
+			if !filepath.IsAbs(linkTarget) {
+				linkTarget = filepath.Join(filepath.Dir(target), linkTarget)
+			}
+			if !isWithin(root, filepath.Clean(linkTarget)) {
+				return fmt.Errorf("symlink %q points outside the destination", hdr.Name)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return fmt.Errorf("create %q: %w", filepath.Dir(target), err)
+			}
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("replace %q: %w", target, err)
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return fmt.Errorf("create symlink %q: %w", target, err)
+			}
+
+		default:
+			// writeTarball only produces regular files and symlinks; anything else is ignored.
+		}
+	}
+}
+
+// extractionPath resolves an archive entry name to a path inside root.
+func extractionPath(root, name string) (string, error) {
+	target := filepath.Join(root, filepath.FromSlash(name))
+	if !isWithin(root, target) {
+		return "", fmt.Errorf("archive entry %q points outside the destination", name)
+	}
+	return target, nil
+}
+
+func isWithin(root, path string) bool {
+	return path == root || strings.HasPrefix(path, root+string(os.PathSeparator))
+}
+
 type nopWriteCloser struct {
 	io.Writer
 }
@@ -0,0 +1,186 @@
+package dataexporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	log "github.com/sirupsen/logrus"
+)
+
+// archiveObject is a stored object considered when resolving an archive.
+type archiveObject struct {
+	Name    string
+	Size    int64
+	Updated time.Time
+}
+
+// archiveLayout describes the objects that make up one complete archive, in the order they must be
+// concatenated to rebuild the tar stream.
+type archiveLayout struct {
+	Compression Compression
+	Objects     []string
+	Size        int64
+}
+
+var archiveExtensions = []Compression{
+	// Longest extensions first so that `.tar` does not shadow the compressed variants.
+	CompressionZstd,
+	CompressionLz4,
+	CompressionGzip,
+	CompressionNone,
+}
+
+// parseArchiveObjectName splits the name of a complete archive object (a single archive or one of its
+// final parts) into its base name, compression and part index. Chunks and composition temporaries
+// left behind by an unfinished upload are not complete archive objects.
+func parseArchiveObjectName(objectName string) (baseName string, compression Compression, part int, ok bool) {
+	for _, c := range archiveExtensions {
+		trimmed, found := strings.CutSuffix(objectName, c.Extension())
+		if !found || trimmed == "" {
+			continue
+		}
+		if index := strings.LastIndex(trimmed, "-part-"); index > 0 && isDecimal(trimmed[index+len("-part-"):]) {
+			part, err := strconv.Atoi(trimmed[index+len("-part-"):])
+			if err != nil {
+				return "", "", 0, false
+			}
+			return trimmed[:index], c, part, true
+		}
+		return trimmed, c, -1, true
+	}
+	return "", "", 0, false
+}
+
+// resolveArchive selects the objects belonging to the archive named baseName.
+func resolveArchive(baseName string, objects []archiveObject) (*archiveLayout, error) {
+	type part struct {
+		name  string
+		index int
+	}
+	var (
+		single      *archiveObject
+		parts       []part
+		compression Compression
+		size        int64
+	)
+	for i, object := range objects {
+		name, c, index, ok := parseArchiveObjectName(object.Name)
+		if !ok || name != baseName {
+			continue
+		}
+		if compression != "" && compression != c {
+			return nil, fmt.Errorf("archive %q exists with more than one compression format", baseName)
+		}
+		compression = c
+		size += object.Size
+		if index < 0 {
+			single = &objects[i]
+			continue
+		}
+		parts = append(parts, part{name: object.Name, index: index})
+	}
+
+	switch {
+	case single == nil && len(parts) == 0:
+		return nil, fmt.Errorf("archive %q not found", baseName)
+	case single != nil && len(parts) > 0:
+		return nil, fmt.Errorf("archive %q exists both as a single object and as parts", baseName)
+	case single != nil:
+		return &archiveLayout{Compression: compression, Objects: []string{single.Name}, Size: size}, nil
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].index < parts[j].index })
+	layout := &archiveLayout{Compression: compression, Size: size}
+	for i, p := range parts {
+		if p.index != i {
+			return nil, fmt.Errorf("archive %q is missing part %d", baseName, i)
+		}
+		layout.Objects = append(layout.Objects, p.name)
+	}
+	return layout, nil
+}
+
+// latestArchive returns the base name of the most recently written archive whose name starts with
+// prefix. For split archives the newest part decides.
+func latestArchive(prefix string, objects []archiveObject) (string, error) {
+	latest := ""
+	var latestTime time.Time
+	for _, object := range objects {
+		name, _, _, ok := parseArchiveObjectName(object.Name)
+		if !ok || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if latest == "" || object.Updated.After(latestTime) || (object.Updated.Equal(latestTime) && name > latest) {
+			latest = name
+			latestTime = object.Updated
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no archives found with prefix %q", prefix)
+	}
+	return latest, nil
+}
+
+// downloadArchive streams the archive objects in order through the decompressor and extracts them into dir.
+func downloadArchive(
+	ctx context.Context,
+	layout *archiveLayout,
+	dir string,
+	open func(ctx context.Context, objectName string) (io.ReadCloser, error),
+	options *DownloadOptions,
+) error {
+	var bytesDownloaded atomic.Uint64
+
+	progressCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(options.ReportPeriod)
+		defer ticker.Stop()
+		var last uint64
+		for {
+			select {
+			case <-progressCtx.Done():
+				return
+			case <-ticker.C:
+				current := bytesDownloaded.Load()
+				if current != last {
+					log.WithFields(log.Fields{
+						"downloaded": datasize.ByteSize(current).HumanReadable(),
+						"total":      datasize.ByteSize(layout.Size).HumanReadable(),
+					}).Info("downloading and extracting")
+				}
+				last = current
+			}
+		}
+	}()
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		for _, objectName := range layout.Objects {
+			log.WithField("object", objectName).Debug("downloading object")
+			reader, err := open(ctx, objectName)
+			if err != nil {
+				_ = pw.CloseWithError(fmt.Errorf("open %q: %w", objectName, err))
+				return
+			}
+			_, err = io.Copy(pw, newReaderWithBytesCounter(reader, &bytesDownloaded))
+			_ = reader.Close()
+			if err != nil {
+				_ = pw.CloseWithError(fmt.Errorf("download %q: %w", objectName, err))
+				return
+			}
+		}
+		_ = pw.Close()
+	}()
+
+	return extractTarball(pr, dir, layout.Compression)
+}
@@ -10,7 +10,7 @@ import (
 // Provider identifies a cloud storage provider.
 type Provider string
 
-// Exporter provides methods to upload and delete data snapshots from cloud storage.
+// Exporter provides methods to upload, download and delete data snapshots from cloud storage.
 type Exporter interface {
 	// Provider returns the cloud storage provider type.
 	Provider() Provider
@@ -23,6 +23,14 @@ type Exporter interface {
 	// Delete removes an object from the specified bucket.
 	// Options can be provided to customize the delete behavior.
 	Delete(bucket, name string, opts ...DeleteOption) error
+
+	// Download fetches the archive with the specified name from the bucket and extracts it into dir.
+	// Split archives are reassembled and the compression is detected from the object extension.
+	Download(bucket, name, dir string, opts ...DownloadOption) error
+
+	// Latest returns the name of the most recently uploaded archive in the bucket whose name starts
+	// with prefix. The returned name can be passed to Download.
+	Latest(bucket, prefix string) (string, error)
 }
 
 // FromProvider creates an Exporter for the specified provider.
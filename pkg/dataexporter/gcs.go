@@ -456,3 +456,62 @@ func (gcs *GcsExporter) Delete(bucket, name string, opts ...DeleteOption) error
 	}).Infof("deleting object(s) with name(prefix): %s", name)
 	return gcs.batchDelete(ctx, bucket, objectNames, options.ConcurrentJobs)
 }
+
+func (gcs *GcsExporter) listArchiveObjects(ctx context.Context, bucket, prefix string) ([]archiveObject, error) {
+	var objects []archiveObject
+	it := gcs.client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		objAttrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %v", err)
+		}
+		objects = append(objects, archiveObject{
+			Name:    objAttrs.Name,
+			Size:    objAttrs.Size,
+			Updated: objAttrs.Updated,
+		})
+	}
+}
+
+func (gcs *GcsExporter) Download(bucket, name, dir string, opts ...DownloadOption) error {
+	options := defaultDownloadOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := validateDownloadOptions(options); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	objects, err := gcs.listArchiveObjects(ctx, bucket, name)
+	if err != nil {
+		return err
+	}
+	layout, err := resolveArchive(name, objects)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"size":        datasize.ByteSize(layout.Size).HumanReadable(),
+		"source":      fmt.Sprintf("gs://%s/%s%s", bucket, name, layout.Compression.Extension()),
+		"objects":     len(layout.Objects),
+		"target":      dir,
+		"compression": layout.Compression,
+	}).Info("start downloading and extracting")
+
+	return downloadArchive(ctx, layout, dir, func(ctx context.Context, objectName string) (io.ReadCloser, error) {
+		return gcs.client.Bucket(bucket).Object(objectName).NewReader(ctx)
+	}, options)
+}
+
+func (gcs *GcsExporter) Latest(bucket, prefix string) (string, error) {
+	objects, err := gcs.listArchiveObjects(context.Background(), bucket, prefix)
+	if err != nil {
+		return "", err
+	}
+	return latestArchive(prefix, objects)
+}
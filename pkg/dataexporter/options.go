@@ -173,3 +173,31 @@ func WithConcurrentDeleteJobs(concurrentJobs int) DeleteOption {
 		o.ConcurrentJobs = concurrentJobs
 	}
 }
+
+// DownloadOptions configures the behavior of data downloads from cloud storage.
+type DownloadOptions struct {
+	ReportPeriod time.Duration
+}
+
+func defaultDownloadOptions() *DownloadOptions {
+	return &DownloadOptions{
+		ReportPeriod: DefaultReportPeriod,
+	}
+}
+
+// DownloadOption is a functional option for configuring downloads.
+type DownloadOption func(*DownloadOptions)
+
+// WithDownloadReportPeriod sets how often download progress is reported.
+func WithDownloadReportPeriod(period time.Duration) DownloadOption {
+	return func(o *DownloadOptions) {
+		o.ReportPeriod = period
+	}
+}
+
+func validateDownloadOptions(options *DownloadOptions) error {
+	if options.ReportPeriod <= 0 {
+		return fmt.Errorf("report period must be greater than zero")
+	}
+	return nil
+}
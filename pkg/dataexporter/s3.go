@@ -44,6 +44,7 @@ type s3API interface {
 	UploadPart(context.Context, *s3.UploadPartInput, ...func(*s3.Options)) (*s3.UploadPartOutput, error)
 	CompleteMultipartUpload(context.Context, *s3.CompleteMultipartUploadInput, ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
 	AbortMultipartUpload(context.Context, *s3.AbortMultipartUploadInput, ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
 	ListObjectsV2(context.Context, *s3.ListObjectsV2Input, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
 	DeleteObject(context.Context, *s3.DeleteObjectInput, ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
 	DeleteObjects(context.Context, *s3.DeleteObjectsInput, ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
@@ -567,3 +568,76 @@ func formatS3DeleteErrors(deleteErrors []types.Error) string {
 	}
 	return fmt.Sprint(messages)
 }
+
+func (exporter *S3Exporter) listArchiveObjects(ctx context.Context, bucket, prefix string) ([]archiveObject, error) {
+	var continuationToken *string
+	var objects []archiveObject
+	for {
+		output, err := exporter.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(bucket),
+			Prefix:            aws.String(prefix),
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list S3 objects with prefix %q: %w", prefix, err)
+		}
+		for _, object := range output.Contents {
+			objects = append(objects, archiveObject{
+				Name:    aws.ToString(object.Key),
+				Size:    aws.ToInt64(object.Size),
+				Updated: aws.ToTime(object.LastModified),
+			})
+		}
+		if !aws.ToBool(output.IsTruncated) {
+			return objects, nil
+		}
+		continuationToken = output.NextContinuationToken
+	}
+}
+
+func (exporter *S3Exporter) Download(bucket, name, dir string, opts ...DownloadOption) error {
+	options := defaultDownloadOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := validateDownloadOptions(options); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	objects, err := exporter.listArchiveObjects(ctx, bucket, name)
+	if err != nil {
+		return err
+	}
+	layout, err := resolveArchive(name, objects)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"size":        datasize.ByteSize(layout.Size).HumanReadable(),
+		"source":      fmt.Sprintf("s3://%s/%s%s", bucket, name, layout.Compression.Extension()),
+		"objects":     len(layout.Objects),
+		"target":      dir,
+		"compression": layout.Compression,
+	}).Info("start downloading and extracting")
+
+	return downloadArchive(ctx, layout, dir, func(ctx context.Context, objectName string) (io.ReadCloser, error) {
+		output, err := exporter.client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(objectName),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output.Body, nil
+	}, options)
+}
+
+func (exporter *S3Exporter) Latest(bucket, prefix string) (string, error) {
+	objects, err := exporter.listArchiveObjects(context.Background(), bucket, prefix)
+	if err != nil {
+		return "", err
+	}
+	return latestArchive(prefix, objects)
+}
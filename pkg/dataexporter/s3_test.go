@@ -139,6 +139,49 @@ func TestS3UploadSplitsOversizedArchives(t *testing.T) {
 	}
 }
 
+func TestS3DownloadReassemblesSplitArchive(t *testing.T) {
+	source := t.TempDir()
+	payload := bytes.Repeat([]byte("snapshot-data-"), 600000)
+	if err := os.WriteFile(filepath.Join(source, "state.db"), payload, 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	client := newFakeS3Client()
+	exporter := newS3Exporter(client)
+	if err := exporter.Upload(source, "snapshots", "osmosis-1-20240101000000",
+		WithCompression(CompressionLz4),
+		WithSizeLimit("1B"),
+		WithPartSize("6MB"),
+		WithChunkSize("6MB"),
+	); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	for _, name := range client.completedNames() {
+		client.listedObjects = append(client.listedObjects, types.Object{Key: aws.String(name)})
+	}
+	client.listedObjects = append(client.listedObjects, types.Object{Key: aws.String("osmosis-1-20240101000000-part-00000009")})
+
+	latest, err := exporter.Latest("snapshots", "osmosis-1-")
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if latest != "osmosis-1-20240101000000" {
+		t.Fatalf("Latest() = %q, want osmosis-1-20240101000000", latest)
+	}
+
+	destination := t.TempDir()
+	if err := exporter.Download("snapshots", latest, destination); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(destination, "state.db"))
+	if err != nil {
+		t.Fatalf("read extracted file: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatal("downloaded archive did not reconstruct the source data")
+	}
+}
+
 func TestS3ArchiveRequiresSplitBeforeMultipartLimit(t *testing.T) {
 	options := defaultUploadOptions()
 	options.ChunkSize = datasize.MustParseString(DefaultS3ChunkSize)
@@ -425,6 +468,14 @@ func (f *fakeS3Client) ListObjectsV2(_ context.Context, _ *s3.ListObjectsV2Input
 	return &s3.ListObjectsV2Output{Contents: f.listedObjects}, nil
 }
 
+func (f *fakeS3Client) GetObject(_ context.Context, input *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	object, ok := f.completedObject(aws.ToString(input.Key))
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(object))}, nil
+}
+
 func (f *fakeS3Client) DeleteObjects(_ context.Context, input *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
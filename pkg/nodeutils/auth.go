@@ -0,0 +1,47 @@
+package nodeutils
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AuthTokenSecretKey is the key under which the node-utils token is stored in the operator-managed Secret.
+const AuthTokenSecretKey = "token"
+
+// GenerateAuthToken returns a fresh random token for authenticating requests to node-utils.
+func GenerateAuthToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating node-utils token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// requireAuth wraps the handler of a route that changes the state of node-utils or of the node, so that it
+// can only be called with the configured token. Routes are left open when no token is configured.
+func (s *NodeUtils) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.AuthToken == "" {
+			next(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.AuthToken)) != 1 {
+			log.WithFields(log.Fields{
+				"path":   r.URL.Path,
+				"remote": r.RemoteAddr,
+			}).Warn("rejected unauthenticated request")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
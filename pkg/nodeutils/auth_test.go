@@ -0,0 +1,84 @@
+package nodeutils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGenerateAuthToken(t *testing.T) {
+	a, err := GenerateAuthToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := GenerateAuthToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a) != 64 {
+		t.Errorf("expected 64 hex characters, got %d", len(a))
+	}
+	if a == b {
+		t.Error("expected different tokens")
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		header     string
+		wantStatus int
+	}{
+		{
+			name:       "no token configured",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing header",
+			token:      "secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong token",
+			token:      "secret",
+			header:     "Bearer wrong",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong scheme",
+			token:      "secret",
+			header:     "Basic secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "valid token",
+			token:      "secret",
+			header:     "Bearer secret",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &NodeUtils{cfg: &Options{AuthToken: tt.token}}
+			handler := server.requireAuth(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/shutdown", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("expected WWW-Authenticate Bearer, got %q", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -28,7 +28,19 @@ type StatsClient interface {
 
 // Client provides methods to interact with the node-utils HTTP server.
 type Client struct {
-	url string
+	url   string
+	token string
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithToken makes the client authenticate its requests with the given token, which is required by routes that
+// change the state of node-utils or of the node.
+func WithToken(token string) ClientOption {
+	return func(c *Client) {
+		c.token = token
+	}
 }
 
 // Ensure Client implements StatsClient
@@ -36,8 +48,24 @@ var _ StatsClient = (*Client)(nil)
 
 // NewClient creates a new node-utils client for the given host.
 // The host should be a hostname or IP address without scheme or port.
-func NewClient(host string) *Client {
-	return &Client{url: fmt.Sprintf("http://%s:%d", host, DefaultPort)}
+func NewClient(host string, opts ...ClientOption) *Client {
+	c := &Client{url: fmt.Sprintf("http://%s:%d", host, DefaultPort)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// newRequest creates a request to the given endpoint, authenticated with the client token if there is one.
+func (c *Client) newRequest(ctx context.Context, method, endpoint string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
 }
 
 // httpGet performs an HTTP GET request and returns the response body as a string.
@@ -48,7 +76,7 @@ func (c *Client) httpGet(ctx context.Context, endpoint string) (string, error) {
 
 // httpGetWithStatus performs an HTTP GET request and accepts multiple valid status codes.
 func (c *Client) httpGetWithStatus(ctx context.Context, endpoint string, validStatuses ...int) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+endpoint, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint)
 	if err != nil {
 		return "", err
 	}
@@ -80,7 +108,7 @@ func (c *Client) httpGetWithStatus(ctx context.Context, endpoint string, validSt
 
 // httpGetJSON performs an HTTP GET request and unmarshals the JSON response.
 func (c *Client) httpGetJSON(ctx context.Context, endpoint string, target interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+endpoint, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint)
 	if err != nil {
 		return err
 	}
@@ -121,6 +149,25 @@ func (c *Client) GetLatestHeight(ctx context.Context) (int64, error) {
 	return strconv.ParseInt(body, 10, 64)
 }
 
+// GetSigningState returns the signing state stored in the data directory of the node, or nil if the node has
+// not signed anything yet.
+func (c *Client) GetSigningState(ctx context.Context) (*SigningState, error) {
+	var state *SigningState
+	if err := c.httpGetJSON(ctx, "/signing_state", &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// GetDoubleSignCheck returns the result of the last check of recent blocks for signatures of the validator.
+func (c *Client) GetDoubleSignCheck(ctx context.Context) (*DoubleSignCheck, error) {
+	check := &DoubleSignCheck{}
+	if err := c.httpGetJSON(ctx, "/double_sign_check", check); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
 // RequiresUpgrade checks if the node requires an upgrade.
 // Returns true if an upgrade is required, false otherwise.
 func (c *Client) RequiresUpgrade(ctx context.Context) (bool, error) {
@@ -131,9 +178,51 @@ func (c *Client) RequiresUpgrade(ctx context.Context) (bool, error) {
 	return strconv.ParseBool(body)
 }
 
+// RequiredUpgrade is the response of /must_upgrade when JSON is requested. Name and Info are only known for
+// on-chain upgrades detected from upgrade-info.json.
+type RequiredUpgrade struct {
+	MustUpgrade bool   `json:"mustUpgrade"`
+	Name        string `json:"name,omitempty"`
+	Height      int64  `json:"height,omitempty"`
+	Info        string `json:"info,omitempty"`
+}
+
+// GetRequiredUpgrade checks if the node requires an upgrade and returns the upgrade plan when known.
+func (c *Client) GetRequiredUpgrade(ctx context.Context) (*RequiredUpgrade, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/must_upgrade", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUpgradeRequired {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Older node-utils versions always reply with plain text
+	if mustUpgrade, err := strconv.ParseBool(string(body)); err == nil {
+		return &RequiredUpgrade{MustUpgrade: mustUpgrade}, nil
+	}
+	upgrade := &RequiredUpgrade{}
+	if err := json.Unmarshal(body, upgrade); err != nil {
+		return nil, err
+	}
+	return upgrade, nil
+}
+
 // ShutdownNodeUtilsServer sends a shutdown signal to the node-utils server.
 func (c *Client) ShutdownNodeUtilsServer(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/shutdown", nil)
+	req, err := c.newRequest(ctx, http.MethodPost, "/shutdown")
 	if err != nil {
 		return err
 	}
@@ -489,6 +489,32 @@ func TestClient_ShutdownNodeUtilsServer(t *testing.T) {
 	}
 }
 
+func TestClient_WithToken(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &Client{url: server.URL}
+	WithToken("secret")(client)
+	if err := client.ShutdownNodeUtilsServer(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Bearer secret" {
+		t.Errorf("expected Authorization Bearer secret, got %q", got)
+	}
+
+	client = &Client{url: server.URL}
+	if err := client.ShutdownNodeUtilsServer(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected no Authorization header, got %q", got)
+	}
+}
+
 func TestClient_ContextCancellation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(100 * time.Millisecond)
@@ -507,3 +533,62 @@ func TestClient_ContextCancellation(t *testing.T) {
 		t.Error("expected error due to cancelled context, got nil")
 	}
 }
+
+func TestClient_GetRequiredUpgrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		statusCode int
+		want       RequiredUpgrade
+		wantErr    bool
+	}{
+		{
+			name:       "upgrade from upgrade-info.json",
+			response:   `{"mustUpgrade":true,"name":"v2","height":1000,"info":"{}"}`,
+			statusCode: http.StatusUpgradeRequired,
+			want:       RequiredUpgrade{MustUpgrade: true, Name: "v2", Height: 1000, Info: "{}"},
+		},
+		{
+			name:       "no upgrade required",
+			response:   `{"mustUpgrade":false}`,
+			statusCode: http.StatusOK,
+			want:       RequiredUpgrade{},
+		},
+		{
+			name:       "plain text from older node-utils",
+			response:   "true",
+			statusCode: http.StatusUpgradeRequired,
+			want:       RequiredUpgrade{MustUpgrade: true},
+		},
+		{
+			name:       "server error",
+			response:   "error",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept") != "application/json" {
+					t.Errorf("expected Accept application/json, got %s", r.Header.Get("Accept"))
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := &Client{url: server.URL}
+			got, err := client.GetRequiredUpgrade(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetRequiredUpgrade() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("GetRequiredUpgrade() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
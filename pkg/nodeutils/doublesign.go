@@ -0,0 +1,173 @@
+package nodeutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// doubleSignCheckInterval is the time to wait before checking recent blocks again after a failed check.
+	doubleSignCheckInterval = 10 * time.Second
+
+	// doubleSignRPCTimeout is the timeout, in seconds, of each request to a trusted RPC endpoint.
+	doubleSignRPCTimeout = 10
+)
+
+// DoubleSignCheck is the result of checking recent blocks for signatures of the validator.
+type DoubleSignCheck struct {
+	// Passed is true once recent blocks were checked and none of them was signed by the validator.
+	Passed bool `json:"passed"`
+
+	// Height of the block in which a signature of the validator was found.
+	Height int64 `json:"height,omitempty"`
+
+	// RPC endpoint that reported the signature.
+	RPC string `json:"rpc,omitempty"`
+
+	// Error that prevented recent blocks from being checked.
+	Error string `json:"error,omitempty"`
+}
+
+// commitClient is the subset of the CometBFT RPC client used to retrieve recent block commits.
+type commitClient interface {
+	Status(context.Context) (*coretypes.ResultStatus, error)
+	Commit(context.Context, *int64) (*coretypes.ResultCommit, error)
+}
+
+type trustedRPC struct {
+	url    string
+	client commitClient
+}
+
+func newTrustedRPCs(urls []string) ([]trustedRPC, error) {
+	rpcs := make([]trustedRPC, len(urls))
+	for i, url := range urls {
+		client, err := rpchttp.NewWithTimeout(url, "/websocket", doubleSignRPCTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("error creating client for %s: %w", url, err)
+		}
+		rpcs[i] = trustedRPC{url: url, client: client}
+	}
+	return rpcs, nil
+}
+
+// runDoubleSignCheck checks recent blocks for signatures of the validator until a check passes. The application
+// is not allowed to start before that, so that it never signs while the same key is signing somewhere else.
+func (s *NodeUtils) runDoubleSignCheck() {
+	log.WithField("address", s.cfg.DoubleSignCheckAddress).Info("checking recent blocks for signatures of the validator")
+	for {
+		result := s.checkDoubleSign(context.Background())
+		s.doubleSignCheck.Store(result)
+
+		switch {
+		case result.Passed:
+			log.Info("no signatures of the validator found in recent blocks")
+			return
+		case result.Error != "":
+			log.Errorf("failed to check recent blocks for signatures of the validator: %s", result.Error)
+		default:
+			log.WithFields(log.Fields{
+				"height": result.Height,
+				"rpc":    result.RPC,
+			}).Error("validator signed a recent block while this node was not running: waiting for it to stop signing")
+		}
+		time.Sleep(doubleSignCheckInterval)
+	}
+}
+
+// checkDoubleSign checks recent blocks reported by all trusted RPC endpoints for signatures of the validator.
+// Blocks up to the last height signed by this node are skipped, as those signatures might be its own.
+func (s *NodeUtils) checkDoubleSign(ctx context.Context) *DoubleSignCheck {
+	lastSigned := s.getLastSignedHeight()
+
+	var (
+		checked bool
+		errs    []string
+	)
+	for _, rpc := range s.doubleSignRPCs {
+		height, err := findSignature(ctx, rpc.client, s.cfg.DoubleSignCheckAddress, lastSigned, s.cfg.DoubleSignCheckBlocks)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", rpc.url, err))
+			continue
+		}
+		if height > 0 {
+			return &DoubleSignCheck{Height: height, RPC: rpc.url}
+		}
+		checked = true
+	}
+
+	if !checked {
+		if len(errs) == 0 {
+			return &DoubleSignCheck{Error: "no trusted RPC endpoints configured"}
+		}
+		return &DoubleSignCheck{Error: "no trusted RPC endpoint could be reached: " + strings.Join(errs, "; ")}
+	}
+	for _, err := range errs {
+		log.Warnf("failed to check recent blocks: %s", err)
+	}
+	return &DoubleSignCheck{Passed: true}
+}
+
+// findSignature returns the height of the most recent of the last blocks commits that includes a signature of the
+// validator with the given address, skipping heights up to after. It returns 0 if there is none.
+func findSignature(ctx context.Context, client commitClient, address string, after, blocks int64) (int64, error) {
+	status, err := client.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+
+	for height := latest; height > max(latest-blocks, after, 0); height-- {
+		commit, err := client.Commit(ctx, &height)
+		if err != nil {
+			return 0, fmt.Errorf("error getting commit at height %d: %w", height, err)
+		}
+		for _, sig := range commit.Commit.Signatures {
+			if sig.BlockIDFlag != tmtypes.BlockIDFlagAbsent && strings.EqualFold(sig.ValidatorAddress.String(), address) {
+				return height, nil
+			}
+		}
+	}
+	return 0, nil
+}
+
+// getLastSignedHeight returns the last height signed by this node, according to the signing state in the data
+// directory and the one recorded by cosmopilot.
+func (s *NodeUtils) getLastSignedHeight() int64 {
+	var height int64
+	for _, path := range []string{filepath.Join(s.cfg.DataPath, PrivValidatorStateFilename), s.cfg.SigningStateFile} {
+		if path == "" {
+			continue
+		}
+		state, err := ReadSigningState(path)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				log.Warnf("failed to read signing state: %v", err)
+			}
+			continue
+		}
+		height = max(height, state.Height)
+	}
+	return height
+}
+
+// readyToSign returns true if the application is allowed to start signing.
+func (s *NodeUtils) readyToSign() bool {
+	if s.signingLeaseElector != nil && !s.signingLeaseHeld.Load() {
+		return false
+	}
+	if check := s.doubleSignCheck.Load(); check != nil && !check.Passed {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package nodeutils
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+)
+
+const testValidatorAddress = "0E85BC4610C7710A01CA6CC98E2CC5CFE9935690"
+
+// fakeCommitClient reports a chain at latest height where the validator signed the blocks in signed.
+type fakeCommitClient struct {
+	latest int64
+	signed map[int64]tmtypes.BlockIDFlag
+	err    error
+}
+
+func (c *fakeCommitClient) Status(context.Context) (*coretypes.ResultStatus, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: c.latest}}, nil
+}
+
+func (c *fakeCommitClient) Commit(_ context.Context, height *int64) (*coretypes.ResultCommit, error) {
+	address, _ := hex.DecodeString(testValidatorAddress)
+	other, _ := hex.DecodeString("AAAABC4610C7710A01CA6CC98E2CC5CFE9935690")
+
+	sigs := []tmtypes.CommitSig{{BlockIDFlag: tmtypes.BlockIDFlagCommit, ValidatorAddress: other}}
+	if flag, ok := c.signed[*height]; ok {
+		sigs = append(sigs, tmtypes.CommitSig{BlockIDFlag: flag, ValidatorAddress: address})
+	}
+	return &coretypes.ResultCommit{
+		SignedHeader: tmtypes.SignedHeader{Commit: &tmtypes.Commit{Height: *height, Signatures: sigs}},
+	}, nil
+}
+
+func TestFindSignature(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeCommitClient{latest: 100, signed: map[int64]tmtypes.BlockIDFlag{
+		95: tmtypes.BlockIDFlagNil,
+		80: tmtypes.BlockIDFlagCommit,
+	}}
+
+	tests := []struct {
+		name   string
+		after  int64
+		blocks int64
+		want   int64
+	}{
+		{"signed in last blocks", 0, 10, 95},
+		{"signed before last blocks", 0, 5, 0},
+		{"signed before last signed height of this node", 95, 10, 0},
+		{"older signature in last blocks", 0, 30, 95},
+		{"more blocks than the chain has", 96, 1000, 0},
+	}
+	for _, tt := range tests {
+		got, err := findSignature(ctx, client, testValidatorAddress, tt.after, tt.blocks)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: findSignature() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// Absent signatures are not signatures of the validator
+	client.signed = map[int64]tmtypes.BlockIDFlag{100: tmtypes.BlockIDFlagAbsent}
+	if got, _ := findSignature(ctx, client, testValidatorAddress, 0, 10); got != 0 {
+		t.Errorf("findSignature() with absent signature = %d, want 0", got)
+	}
+}
+
+func TestCheckDoubleSign(t *testing.T) {
+	ctx := context.Background()
+	dataPath := t.TempDir()
+	server := &NodeUtils{cfg: &Options{
+		DataPath:               dataPath,
+		DoubleSignCheckAddress: testValidatorAddress,
+		DoubleSignCheckBlocks:  10,
+	}}
+
+	unreachable := trustedRPC{url: "http://unreachable", client: &fakeCommitClient{err: errors.New("connection refused")}}
+	clean := trustedRPC{url: "http://clean", client: &fakeCommitClient{latest: 100}}
+	signed := trustedRPC{url: "http://signed", client: &fakeCommitClient{latest: 100, signed: map[int64]tmtypes.BlockIDFlag{
+		98: tmtypes.BlockIDFlagCommit,
+	}}}
+
+	server.doubleSignRPCs = []trustedRPC{unreachable}
+	if check := server.checkDoubleSign(ctx); check.Passed || check.Error == "" {
+		t.Fatalf("check without reachable endpoints = %+v, want error", check)
+	}
+
+	server.doubleSignRPCs = []trustedRPC{unreachable, clean}
+	if check := server.checkDoubleSign(ctx); !check.Passed {
+		t.Fatalf("check with a clean endpoint = %+v, want passed", check)
+	}
+
+	server.doubleSignRPCs = []trustedRPC{clean, signed}
+	check := server.checkDoubleSign(ctx)
+	if check.Passed || check.Height != 98 || check.RPC != "http://signed" {
+		t.Fatalf("check with a signature = %+v, want signature at 98 reported by http://signed", check)
+	}
+
+	// Signatures up to the last height signed by this node are its own
+	if err := os.WriteFile(filepath.Join(dataPath, PrivValidatorStateFilename), []byte(`{"height":"98","round":0,"step":3}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if check := server.checkDoubleSign(ctx); !check.Passed {
+		t.Fatalf("check with own signature = %+v, want passed", check)
+	}
+}
+
+func TestReadyToSign(t *testing.T) {
+	server := &NodeUtils{}
+	if !server.readyToSign() {
+		t.Fatal("node without signing gates is not ready to sign")
+	}
+
+	server.doubleSignCheck.Store(&DoubleSignCheck{})
+	if server.readyToSign() {
+		t.Fatal("node is ready to sign before recent blocks were checked")
+	}
+
+	server.doubleSignCheck.Store(&DoubleSignCheck{Passed: true})
+	if !server.readyToSign() {
+		t.Fatal("node is not ready to sign after recent blocks were checked")
+	}
+}
@@ -3,6 +3,7 @@ package nodeutils
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,8 +11,10 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/voluzi/cosmopilot/v3/pkg/statscollector"
@@ -26,17 +29,21 @@ func (s *NodeUtils) registerRoutes() {
 	s.router.HandleFunc("/must_upgrade", s.mustUpgrade).Methods(http.MethodGet)
 	s.router.HandleFunc("/tmkms_active", s.tmkmsConnectionActive).Methods(http.MethodGet)
 	s.router.HandleFunc("/signer_discovered", s.signerDiscoveredStatus).Methods(http.MethodGet)
+	s.router.HandleFunc("/double_sign_check", s.doubleSignCheckStatus).Methods(http.MethodGet)
+	s.router.HandleFunc("/ready_to_sign", s.readyToSignStatus).Methods(http.MethodGet)
+	s.router.HandleFunc("/signing_state", s.signingState).Methods(http.MethodGet)
 	s.router.HandleFunc("/snapshots", s.listSnapshots).Methods(http.MethodGet)
-	s.router.HandleFunc("/shutdown", s.shutdownServer).Methods(http.MethodGet, http.MethodPost)
+	s.router.HandleFunc("/shutdown", s.requireAuth(s.shutdownServer)).Methods(http.MethodGet, http.MethodPost)
 	s.router.HandleFunc("/stats", s.stats).Methods(http.MethodGet)
 	s.router.HandleFunc("/stats/cpu", s.statsCPU).Methods(http.MethodGet)
 	s.router.HandleFunc("/stats/memory", s.statsMemory).Methods(http.MethodGet)
 	s.router.HandleFunc("/state_syncing", s.stateSyncing).Methods(http.MethodGet)
+	s.router.Handle("/metrics", promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{})).Methods(http.MethodGet)
 
-	// Mock mode control endpoints
-	s.router.HandleFunc("/mock/cpu", s.mockSetCPU).Methods(http.MethodPost)
-	s.router.HandleFunc("/mock/memory", s.mockSetMemory).Methods(http.MethodPost)
-	s.router.HandleFunc("/mock/stats", s.mockGetStats).Methods(http.MethodGet)
+	// Mock mode control endpoints. Like /shutdown, they require the token.
+	s.router.HandleFunc("/mock/cpu", s.requireAuth(s.mockSetCPU)).Methods(http.MethodPost)
+	s.router.HandleFunc("/mock/memory", s.requireAuth(s.mockSetMemory)).Methods(http.MethodPost)
+	s.router.HandleFunc("/mock/stats", s.requireAuth(s.mockGetStats)).Methods(http.MethodGet)
 }
 
 func writeError(w http.ResponseWriter, format string, args ...interface{}) {
@@ -142,13 +149,28 @@ func (s *NodeUtils) latestHeight(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *NodeUtils) mustUpgrade(w http.ResponseWriter, r *http.Request) {
-	log.WithField("must-upgrade", s.requiresUpgrade.Load()).Info("checked if should upgrade")
-	if s.requiresUpgrade.Load() {
-		w.WriteHeader(http.StatusUpgradeRequired)
-	} else {
-		w.WriteHeader(http.StatusOK)
+	mustUpgrade := s.requiresUpgrade.Load()
+	log.WithField("must-upgrade", mustUpgrade).Info("checked if should upgrade")
+
+	status := http.StatusOK
+	if mustUpgrade {
+		status = http.StatusUpgradeRequired
+	}
+
+	// Plain text responses are kept for probes and older clients
+	if !strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(strconv.FormatBool(mustUpgrade)))
+		return
 	}
-	_, _ = w.Write([]byte(strconv.FormatBool(s.requiresUpgrade.Load())))
+
+	response := RequiredUpgrade{MustUpgrade: mustUpgrade}
+	if info := s.requiredUpgrade.Load(); mustUpgrade && info != nil {
+		response.Name = info.Name
+		response.Height = info.Height
+		response.Info = info.Info
+	}
+	writeJSON(w, status, response)
 }
 
 func (s *NodeUtils) tmkmsConnectionActive(w http.ResponseWriter, r *http.Request) {
@@ -172,6 +194,40 @@ func (s *NodeUtils) signerDiscoveredStatus(w http.ResponseWriter, _ *http.Reques
 	_, _ = w.Write([]byte(strconv.FormatBool(discovered)))
 }
 
+// doubleSignCheckStatus returns the result of the last check of recent blocks for signatures of the validator.
+// The check is reported as passed when it is not enabled.
+func (s *NodeUtils) doubleSignCheckStatus(w http.ResponseWriter, _ *http.Request) {
+	check := s.doubleSignCheck.Load()
+	if check == nil {
+		check = &DoubleSignCheck{Passed: true}
+	}
+	writeJSON(w, http.StatusOK, check)
+}
+
+// readyToSignStatus reports whether the application is allowed to start signing: the signing lease is held and
+// no recent signatures of the validator were found, when those are enabled. It is used as startup probe.
+func (s *NodeUtils) readyToSignStatus(w http.ResponseWriter, _ *http.Request) {
+	ready := s.readyToSign()
+	log.WithField("ready-to-sign", ready).Debug("checked if node is ready to sign")
+	if ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusNotAcceptable)
+	}
+	_, _ = w.Write([]byte(strconv.FormatBool(ready)))
+}
+
+// signingState returns the signing state stored in the data directory, or null if there is none yet.
+func (s *NodeUtils) signingState(w http.ResponseWriter, _ *http.Request) {
+	state, err := ReadSigningState(path.Join(s.cfg.DataPath, PrivValidatorStateFilename))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		writeError(w, "error reading signing state: %v", err)
+		return
+	}
+	log.WithField("signing-state", state).Debug("retrieved signing state")
+	writeJSON(w, http.StatusOK, state)
+}
+
 func (s *NodeUtils) shutdownServer(w http.ResponseWriter, r *http.Request) {
 	log.Info("shutting down server")
 	if err := s.Stop(true); err != nil {
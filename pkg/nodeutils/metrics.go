@@ -0,0 +1,152 @@
+package nodeutils
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/voluzi/cosmopilot/v3/pkg/utils"
+)
+
+const (
+	metricsNamespace = "nodeutils"
+
+	dataSizeCollectorInterval = time.Minute
+)
+
+// metrics holds the Prometheus registry served on /metrics and the instruments that are updated
+// as events happen. Values already tracked elsewhere in NodeUtils are read at scrape time by
+// nodeCollector instead of being duplicated here.
+type metrics struct {
+	registry         *prometheus.Registry
+	blocksProcessed  prometheus.Counter
+	blockInterval    prometheus.Histogram
+	signerReconnects prometheus.Counter
+	lastBlockTime    time.Time
+}
+
+func newMetrics(s *NodeUtils) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		blocksProcessed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "blocks_processed_total",
+			Help:      "Number of new block heights observed in store traces.",
+		}),
+		blockInterval: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "block_processing_seconds",
+			Help:      "Time elapsed between consecutive block heights observed in store traces.",
+			Buckets:   []float64{0.5, 1, 2, 3, 5, 7.5, 10, 15, 30, 60, 120},
+		}),
+		signerReconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "remote_signer_reconnects_total",
+			Help:      "Number of times the remote-signer proxy was restarted after its connection finished.",
+		}),
+	}
+	m.registry.MustRegister(m.blocksProcessed, m.blockInterval, m.signerReconnects, newNodeCollector(s))
+	return m
+}
+
+// observeBlock records that a new block height was seen in the traces.
+func (m *metrics) observeBlock(now time.Time) {
+	m.blocksProcessed.Inc()
+	if !m.lastBlockTime.IsZero() {
+		m.blockInterval.Observe(now.Sub(m.lastBlockTime).Seconds())
+	}
+	m.lastBlockTime = now
+}
+
+// nodeCollector exposes the state NodeUtils already keeps for its JSON routes as gauges, so
+// there is a single source of truth for both.
+type nodeCollector struct {
+	s *NodeUtils
+
+	latestHeight       *prometheus.Desc
+	requiresUpgrade    *prometheus.Desc
+	pendingUpgrade     *prometheus.Desc
+	signerConnected    *prometheus.Desc
+	signerDiscovered   *prometheus.Desc
+	dataSize           *prometheus.Desc
+	processCPUSeconds  *prometheus.Desc
+	processResidentMem *prometheus.Desc
+}
+
+func newNodeCollector(s *NodeUtils) *nodeCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", name), help, nil, nil)
+	}
+	return &nodeCollector{
+		s:                  s,
+		latestHeight:       desc("latest_block_height", "Latest block height observed in store traces."),
+		requiresUpgrade:    desc("upgrade_required", "Whether the node halted and requires an upgrade (1) or not (0)."),
+		pendingUpgrade:     desc("upgrade_pending_height", "Height of the next scheduled upgrade, or 0 when none is scheduled."),
+		signerConnected:    desc("remote_signer_connected", "Whether the remote-signer proxy currently holds a connection (1) or not (0)."),
+		signerDiscovered:   desc("remote_signer_discovered", "Whether a trusted remote signer has connected since startup (1) or not (0)."),
+		dataSize:           desc("data_size_bytes", "Size of the node data directory in bytes."),
+		processCPUSeconds:  desc("process_cpu_seconds_total", "Total user and system CPU time spent by the node application in seconds."),
+		processResidentMem: desc("process_resident_memory_bytes", "Resident memory size of the node application in bytes."),
+	}
+}
+
+func (c *nodeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.latestHeight
+	ch <- c.requiresUpgrade
+	ch <- c.pendingUpgrade
+	ch <- c.signerConnected
+	ch <- c.signerDiscovered
+	ch <- c.dataSize
+	ch <- c.processCPUSeconds
+	ch <- c.processResidentMem
+}
+
+func (c *nodeCollector) Collect(ch chan<- prometheus.Metric) {
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+
+	gauge(c.latestHeight, float64(c.s.latestBlockHeight.Load()))
+	gauge(c.requiresUpgrade, boolToFloat(c.s.requiresUpgrade.Load()))
+	if c.s.upgradeChecker != nil {
+		gauge(c.pendingUpgrade, float64(c.s.upgradeChecker.PendingUpgradeHeight()))
+	}
+	gauge(c.signerConnected, boolToFloat(c.s.tmkmsActive.Load()))
+	gauge(c.signerDiscovered, boolToFloat(c.s.signerDiscovered.Load()))
+	gauge(c.dataSize, float64(c.s.dataSizeBytes.Load()))
+
+	if c.s.cfg != nil && c.s.cfg.MockMode {
+		gauge(c.processResidentMem, float64(c.s.mockStats.GetMemory()))
+		return
+	}
+
+	p, err := c.s.getNodeProcess()
+	if err != nil {
+		log.Debugf("skipping process metrics: %v", err)
+		return
+	}
+	stats, err := GetProcessStats(p)
+	if err != nil {
+		log.Debugf("skipping process metrics: %v", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.processCPUSeconds, prometheus.CounterValue, stats.CPUTimeSec)
+	gauge(c.processResidentMem, float64(stats.MemoryRSS))
+}
+
+func (s *NodeUtils) updateDataSize() {
+	size, err := utils.DirSize(s.cfg.DataPath)
+	if err != nil {
+		log.Errorf("error collecting data size: %v", err)
+		return
+	}
+	s.dataSizeBytes.Store(size)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
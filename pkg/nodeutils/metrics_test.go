@@ -0,0 +1,74 @@
+package nodeutils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	server := &NodeUtils{
+		cfg:    &Options{MockMode: true},
+		router: mux.NewRouter(),
+		upgradeChecker: &UpgradeChecker{config: UpgradesConfig{Upgrades: []Upgrade{
+			{Height: 300, Status: UpgradeScheduled},
+			{Height: 200, Status: UpgradeScheduled},
+			{Height: 100, Status: "completed"},
+		}}},
+		mockStats: NewMockStats(),
+	}
+	server.metrics = newMetrics(server)
+	server.registerRoutes()
+
+	server.latestBlockHeight.Store(150)
+	server.tmkmsActive.Store(true)
+	server.dataSizeBytes.Store(4096)
+	now := time.Now()
+	server.metrics.observeBlock(now)
+	server.metrics.observeBlock(now.Add(2 * time.Second))
+	server.metrics.signerReconnects.Inc()
+
+	response := httptest.NewRecorder()
+	server.router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", response.Code, http.StatusOK)
+	}
+
+	body := response.Body.String()
+	for _, want := range []string{
+		"nodeutils_latest_block_height 150",
+		"nodeutils_upgrade_required 0",
+		"nodeutils_upgrade_pending_height 200",
+		"nodeutils_remote_signer_connected 1",
+		"nodeutils_remote_signer_discovered 0",
+		"nodeutils_remote_signer_reconnects_total 1",
+		"nodeutils_data_size_bytes 4096",
+		"nodeutils_blocks_processed_total 2",
+		"nodeutils_block_processing_seconds_count 1",
+		"nodeutils_block_processing_seconds_sum 2",
+		"nodeutils_process_resident_memory_bytes 3.145728e+08",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
+
+func TestPendingUpgradeHeight(t *testing.T) {
+	checker := &UpgradeChecker{}
+	if got := checker.PendingUpgradeHeight(); got != 0 {
+		t.Fatalf("PendingUpgradeHeight() without upgrades = %d, want 0", got)
+	}
+
+	checker.config.Upgrades = []Upgrade{
+		{Height: 50, Status: "completed"},
+		{Height: 500, Status: UpgradeScheduled},
+	}
+	if got := checker.PendingUpgradeHeight(); got != 500 {
+		t.Fatalf("PendingUpgradeHeight() = %d, want 500", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"path/filepath"
 	"sync/atomic"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/shirou/gopsutil/process"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/leaderelection"
 
 	"github.com/voluzi/cosmopilot/v3/internal/chainutils"
 	"github.com/voluzi/cosmopilot/v3/pkg/proxy"
@@ -43,6 +45,7 @@ type NodeUtils struct {
 	latestBlockHeight      atomic.Int64
 	upgradeChecker         *UpgradeChecker
 	requiresUpgrade        atomic.Bool
+	requiredUpgrade        atomic.Pointer[UpgradeInfo]
 	tmkmsActive            atomic.Bool
 	signerDiscovered       atomic.Bool
 	signerPeerResolver     signerPeerResolver
@@ -54,6 +57,15 @@ type NodeUtils struct {
 	fineStats              *statscollector.Collector
 	coarseStats            *statscollector.Collector
 	mockStats              *MockStats
+	dataSizeBytes          atomic.Int64
+	metrics                *metrics
+	signingLeaseElector    *leaderelection.LeaderElector
+	signingLeaseHeld       atomic.Bool
+	signingLeaseReleasing  atomic.Bool
+	signingLeaseCancel     context.CancelFunc
+	signingLeaseDone       chan struct{}
+	doubleSignRPCs         []trustedRPC
+	doubleSignCheck        atomic.Pointer[DoubleSignCheck]
 }
 
 func New(nodeBinaryName string, opts ...Option) (*NodeUtils, error) {
@@ -70,6 +82,7 @@ func New(nodeBinaryName string, opts ...Option) (*NodeUtils, error) {
 		fineStats:          statscollector.NewCollector(int(time.Hour / fineStatsCollectorInterval)),
 		coarseStats:        statscollector.NewCollector(int((24 * time.Hour) / coarseStatsCollectorInterval)),
 	}
+	nodeUtils.metrics = newMetrics(nodeUtils)
 
 	// Initialize tracer - needed in both normal and mock mode to track block heights
 	t, err := tracer.NewStoreTracer(options.TraceStore, options.CreateFifo)
@@ -85,6 +98,27 @@ func New(nodeBinaryName string, opts ...Option) (*NodeUtils, error) {
 	}
 	nodeUtils.upgradeChecker = uc
 
+	// The signing lease gates the application start, so it is needed in both normal and mock mode
+	if options.SigningLease != "" {
+		clientSet, err := newSigningLeaseClient()
+		if err != nil {
+			return nil, err
+		}
+		nodeUtils.signingLeaseElector, err = nodeUtils.newSigningLeaseElector(clientSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Recent blocks must be checked before the application starts, so that is also needed in mock mode
+	if options.DoubleSignCheckAddress != "" {
+		nodeUtils.doubleSignRPCs, err = newTrustedRPCs(options.DoubleSignCheckRPCs)
+		if err != nil {
+			return nil, err
+		}
+		nodeUtils.doubleSignCheck.Store(&DoubleSignCheck{})
+	}
+
 	// In mock mode, we only mock CPU/memory stats - the blockchain still runs
 	if options.MockMode {
 		nodeUtils.mockStats = NewMockStats()
@@ -183,6 +217,7 @@ func (s *NodeUtils) runTmkmsProxy() {
 
 		// Wait one second before restarting
 		time.Sleep(time.Second)
+		s.metrics.signerReconnects.Inc()
 	}
 }
 
@@ -195,11 +230,26 @@ func (s *NodeUtils) Start() error {
 			log.Errorf("error watching config file: %v", err)
 		}
 	}()
+	go func() {
+		// x/upgrade writes upgrade-info.json when halting for an on-chain upgrade, including upgrades that
+		// are not in the upgrades config or when traces are lagging behind.
+		if err := WatchUpgradeInfoFile(filepath.Join(s.cfg.DataPath, UpgradeInfoFilename), s.setUpgradeRequired); err != nil {
+			log.Errorf("error watching upgrade info file: %v", err)
+		}
+	}()
 
 	if s.tmkmsProxy != nil {
 		go s.runTmkmsProxy()
 	}
 
+	if s.signingLeaseElector != nil {
+		s.runSigningLease()
+	}
+
+	if s.cfg.DoubleSignCheckAddress != "" {
+		go s.runDoubleSignCheck()
+	}
+
 	// Fine-grained collector (1h window)
 	go func() {
 		ticker := time.NewTicker(fineStatsCollectorInterval)
@@ -211,6 +261,16 @@ func (s *NodeUtils) Start() error {
 		}
 	}()
 
+	// Data size is refreshed in the background so that /metrics scrapes never walk the data directory
+	go func() {
+		s.updateDataSize()
+		ticker := time.NewTicker(dataSizeCollectorInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.updateDataSize()
+		}
+	}()
+
 	// Coarse-grained collector (24h window)
 	go func() {
 		ticker := time.NewTicker(coarseStatsCollectorInterval)
@@ -234,8 +294,12 @@ func (s *NodeUtils) Start() error {
 			}
 
 			if trace.Metadata != nil {
-				heightUpdated := s.latestBlockHeight.CompareAndSwap(s.latestBlockHeight.Load(), trace.Metadata.BlockHeight)
+				previousHeight := s.latestBlockHeight.Load()
+				heightUpdated := s.latestBlockHeight.CompareAndSwap(previousHeight, trace.Metadata.BlockHeight)
 				height := s.latestBlockHeight.Load()
+				if height > previousHeight {
+					s.metrics.observeBlock(time.Now())
+				}
 
 				if s.upgradeChecker.ShouldUpgrade(height) {
 					upgrade, err := s.upgradeChecker.GetUpgrade(height)
@@ -250,10 +314,12 @@ func (s *NodeUtils) Start() error {
 					// stop the node after the whole block is processed, let's do it on the first trace of the next height
 					if upgrade.Source == OnChainUpgrade {
 						log.WithField("height", height).Info("on-chain upgrade: application should panic now")
+						s.requiredUpgrade.CompareAndSwap(nil, &UpgradeInfo{Height: upgrade.Height})
 						s.requiresUpgrade.Store(true)
 
 					} else if heightUpdated {
 						log.WithField("height", height).Warn("stopping node for upgrade")
+						s.requiredUpgrade.CompareAndSwap(nil, &UpgradeInfo{Height: upgrade.Height})
 						s.requiresUpgrade.Store(true)
 						if err := s.StopNode(); err != nil {
 							log.Errorf("failed to stop node: %v", err)
@@ -262,6 +328,17 @@ func (s *NodeUtils) Start() error {
 						}
 					}
 				}
+
+				// Like manual upgrades, the node is stopped on the first trace of the next height, so that the
+				// block at the snapshot height is fully committed.
+				if heightUpdated && s.upgradeChecker.ShouldHaltForSnapshot(height) {
+					log.WithField("height", height).Warn("stopping node for snapshot")
+					if err := s.StopNode(); err != nil {
+						log.Errorf("failed to stop node: %v", err)
+					} else {
+						return
+					}
+				}
 			}
 		}
 	}()
@@ -274,6 +351,13 @@ func (s *NodeUtils) Start() error {
 	return nil
 }
 
+// setUpgradeRequired marks the node as requiring the given upgrade.
+func (s *NodeUtils) setUpgradeRequired(info *UpgradeInfo) {
+	log.WithFields(log.Fields{"name": info.Name, "height": info.Height}).Info("upgrade info written: node requires upgrade")
+	s.requiredUpgrade.Store(info)
+	s.requiresUpgrade.Store(true)
+}
+
 func (s *NodeUtils) Stop(force bool) error {
 	log.WithField("force", force).Info("stopping server")
 
@@ -309,6 +393,9 @@ func (s *NodeUtils) Stop(force bool) error {
 		log.Errorf("failed to stop node: %v", err)
 	}
 
+	// Let a replacement pod start signing without waiting for the lease to expire
+	s.releaseSigningLease()
+
 	// Shutdown main server
 	log.Debug("shutting down http server")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
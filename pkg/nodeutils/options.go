@@ -46,6 +46,26 @@ type Options struct {
 	SignerPeerDNS  string
 	HaltHeight     int64
 	MockMode       bool
+
+	// SigningLease is the name of the Lease that must be held before the application is allowed to sign.
+	// The lease is not used when empty.
+	SigningLease          string
+	SigningLeaseNamespace string
+	SigningLeaseIdentity  string
+
+	// DoubleSignCheckAddress is the consensus address of the validator, in hex. When set, the application is
+	// only allowed to start once recent blocks reported by DoubleSignCheckRPCs show no signatures of it.
+	DoubleSignCheckAddress string
+	DoubleSignCheckRPCs    []string
+	DoubleSignCheckBlocks  int64
+
+	// AuthToken is the token required by routes that change the state of node-utils or of the node, such
+	// as /shutdown. Those routes are left open when empty.
+	AuthToken string
+
+	// SigningStateFile is the file with the last signing state recorded for the validator, which is used
+	// when it is ahead of the one in the data directory.
+	SigningStateFile string
 }
 
 type Option func(*Options)
@@ -115,3 +135,36 @@ func WithMockMode(enable bool) Option {
 		opts.MockMode = enable
 	}
 }
+
+// WithSigningLease requires the Lease with the given name to be held, with the given identity, before the
+// application is allowed to sign.
+func WithSigningLease(namespace, name, identity string) Option {
+	return func(opts *Options) {
+		opts.SigningLeaseNamespace = namespace
+		opts.SigningLease = name
+		opts.SigningLeaseIdentity = identity
+	}
+}
+
+// WithDoubleSignCheck requires recent blocks reported by the given RPC endpoints to have no signatures of the
+// validator with the given consensus address before the application is allowed to start.
+func WithDoubleSignCheck(address string, rpcs []string, blocks int64) Option {
+	return func(opts *Options) {
+		opts.DoubleSignCheckAddress = address
+		opts.DoubleSignCheckRPCs = rpcs
+		opts.DoubleSignCheckBlocks = blocks
+	}
+}
+
+func WithSigningStateFile(path string) Option {
+	return func(opts *Options) {
+		opts.SigningStateFile = path
+	}
+}
+
+// WithAuthToken requires the given token on routes that change the state of node-utils or of the node.
+func WithAuthToken(token string) Option {
+	return func(opts *Options) {
+		opts.AuthToken = token
+	}
+}
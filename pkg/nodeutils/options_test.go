@@ -144,3 +144,35 @@ func TestMultipleOptions(t *testing.T) {
 		t.Errorf("expected TmkmsProxy true, got %v", opts.TmkmsProxy)
 	}
 }
+
+func TestWithSigningLease(t *testing.T) {
+	opts := defaultOptions()
+	if opts.SigningLease != "" {
+		t.Errorf("expected no SigningLease by default, got %s", opts.SigningLease)
+	}
+
+	WithSigningLease("default", "validator-signing", "pod-uid")(opts)
+
+	if opts.SigningLeaseNamespace != "default" {
+		t.Errorf("expected SigningLeaseNamespace default, got %s", opts.SigningLeaseNamespace)
+	}
+	if opts.SigningLease != "validator-signing" {
+		t.Errorf("expected SigningLease validator-signing, got %s", opts.SigningLease)
+	}
+	if opts.SigningLeaseIdentity != "pod-uid" {
+		t.Errorf("expected SigningLeaseIdentity pod-uid, got %s", opts.SigningLeaseIdentity)
+	}
+}
+
+func TestWithAuthToken(t *testing.T) {
+	opts := defaultOptions()
+	if opts.AuthToken != "" {
+		t.Errorf("expected no AuthToken by default, got %s", opts.AuthToken)
+	}
+
+	WithAuthToken("secret")(opts)
+
+	if opts.AuthToken != "secret" {
+		t.Errorf("expected AuthToken secret, got %s", opts.AuthToken)
+	}
+}
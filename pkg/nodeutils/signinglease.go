@@ -0,0 +1,132 @@
+package nodeutils
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// signingLeaseDuration is the time other pods wait after the last renewal before taking over the lease.
+	signingLeaseDuration = 30 * time.Second
+
+	// signingLeaseRenewDeadline is the time renewals may keep failing before the node is stopped. It must be
+	// shorter than signingLeaseDuration so that the node stops signing before another pod can take over.
+	signingLeaseRenewDeadline = 20 * time.Second
+
+	// signingLeaseRetryPeriod is the interval between attempts to acquire or renew the lease.
+	signingLeaseRetryPeriod = 5 * time.Second
+
+	// nodeExitTimeout is the time to wait for the application to exit before releasing the signing lease.
+	nodeExitTimeout = 10 * time.Second
+)
+
+// newSigningLeaseClient returns a client for managing the signing lease with the pod service account.
+func newSigningLeaseClient() (kubernetes.Interface, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+// newSigningLeaseElector returns an elector that acquires and keeps renewing the signing lease. The
+// application is stopped if the lease is lost, so that it never signs without holding it.
+func (s *NodeUtils) newSigningLeaseElector(clientSet kubernetes.Interface) (*leaderelection.LeaderElector, error) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Namespace: s.cfg.SigningLeaseNamespace,
+			Name:      s.cfg.SigningLease,
+		},
+		Client: clientSet.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: s.cfg.SigningLeaseIdentity,
+		},
+	}
+
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		Name:            s.cfg.SigningLease,
+		LeaseDuration:   signingLeaseDuration,
+		RenewDeadline:   signingLeaseRenewDeadline,
+		RetryPeriod:     signingLeaseRetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				log.WithField("lease", s.cfg.SigningLease).Info("acquired signing lease")
+				s.signingLeaseHeld.Store(true)
+			},
+			OnStoppedLeading: s.onSigningLeaseLost,
+		},
+	})
+}
+
+// runSigningLease acquires the signing lease and keeps renewing it until it is lost or released.
+func (s *NodeUtils) runSigningLease() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.signingLeaseCancel = cancel
+	s.signingLeaseDone = make(chan struct{})
+
+	log.WithField("lease", s.cfg.SigningLease).Info("waiting for signing lease")
+	go func() {
+		defer close(s.signingLeaseDone)
+		s.signingLeaseElector.Run(ctx)
+	}()
+}
+
+func (s *NodeUtils) onSigningLeaseLost() {
+	wasHeld := s.signingLeaseHeld.Swap(false)
+	if !wasHeld || s.signingLeaseReleasing.Load() {
+		return
+	}
+
+	// Another pod may take over the lease soon, so the application must stop signing right away.
+	log.WithField("lease", s.cfg.SigningLease).Error("lost signing lease: stopping node to prevent double signing")
+	if err := s.StopNode(); err != nil {
+		log.Errorf("failed to stop node: %v", err)
+	}
+}
+
+// releaseSigningLease releases the signing lease once the application exited, so that a replacement pod
+// can start signing right away. If it is still running, the lease is kept until it expires.
+func (s *NodeUtils) releaseSigningLease() {
+	if s.signingLeaseCancel == nil {
+		return
+	}
+
+	if !s.waitForNodeExit(nodeExitTimeout) {
+		log.Warn("node is still running: signing lease will only be released when it expires")
+		return
+	}
+
+	log.WithField("lease", s.cfg.SigningLease).Info("releasing signing lease")
+	s.signingLeaseReleasing.Store(true)
+	s.signingLeaseCancel()
+	<-s.signingLeaseDone
+}
+
+// waitForNodeExit returns true if the application is not running or exits within timeout.
+func (s *NodeUtils) waitForNodeExit(timeout time.Duration) bool {
+	p, err := s.getNodeProcess()
+	if err != nil {
+		return true
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		running, err := p.IsRunning()
+		if err != nil || !running {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
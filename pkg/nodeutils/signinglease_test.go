@@ -0,0 +1,117 @@
+package nodeutils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func newSigningLeaseTestServer(t *testing.T, lease *coordinationv1.Lease) (*NodeUtils, *fake.Clientset) {
+	t.Helper()
+	clientSet := fake.NewSimpleClientset(lease)
+	server := &NodeUtils{
+		nodeBinaryName: "nonexistent-node-binary",
+		cfg: &Options{
+			SigningLease:          lease.Name,
+			SigningLeaseNamespace: lease.Namespace,
+			SigningLeaseIdentity:  "new-pod-uid",
+		},
+	}
+	elector, err := server.newSigningLeaseElector(clientSet)
+	if err != nil {
+		t.Fatalf("failed to create elector: %v", err)
+	}
+	server.signingLeaseElector = elector
+	return server, clientSet
+}
+
+func getSigningLease(t *testing.T, clientSet *fake.Clientset) *coordinationv1.Lease {
+	t.Helper()
+	lease, err := clientSet.CoordinationV1().Leases("default").Get(context.Background(), "validator-signing", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	return lease
+}
+
+func TestReadyToSignStatusWaitsForSigningLease(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/ready_to_sign", nil)
+
+	// Without a signing lease the application is always allowed to start
+	server := &NodeUtils{}
+	response := httptest.NewRecorder()
+	server.readyToSignStatus(response, request)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status without lease = %d, want %d", response.Code, http.StatusOK)
+	}
+
+	server, _ = newSigningLeaseTestServer(t, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "validator-signing"},
+	})
+	response = httptest.NewRecorder()
+	server.readyToSignStatus(response, request)
+	if response.Code != http.StatusNotAcceptable {
+		t.Fatalf("status before acquiring lease = %d, want %d", response.Code, http.StatusNotAcceptable)
+	}
+
+	server.signingLeaseHeld.Store(true)
+	response = httptest.NewRecorder()
+	server.readyToSignStatus(response, request)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status after acquiring lease = %d, want %d", response.Code, http.StatusOK)
+	}
+}
+
+func TestSigningLeaseAcquireAndRelease(t *testing.T) {
+	server, clientSet := newSigningLeaseTestServer(t, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "validator-signing"},
+	})
+
+	server.runSigningLease()
+	deadline := time.Now().Add(5 * time.Second)
+	for !server.signingLeaseHeld.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("signing lease was not acquired")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if holder := ptr.Deref(getSigningLease(t, clientSet).Spec.HolderIdentity, ""); holder != "new-pod-uid" {
+		t.Fatalf("lease holder = %q, want %q", holder, "new-pod-uid")
+	}
+
+	// The application is not running, so the lease is released right away
+	server.releaseSigningLease()
+	if server.signingLeaseHeld.Load() {
+		t.Fatal("signing lease is still marked as held after release")
+	}
+	if holder := ptr.Deref(getSigningLease(t, clientSet).Spec.HolderIdentity, ""); holder != "" {
+		t.Fatalf("lease holder after release = %q, want none", holder)
+	}
+}
+
+func TestSigningLeaseHeldByAnotherPod(t *testing.T) {
+	server, _ := newSigningLeaseTestServer(t, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "validator-signing"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("old-pod-uid"),
+			LeaseDurationSeconds: ptr.To(int32(30)),
+			AcquireTime:          &metav1.MicroTime{Time: time.Now()},
+			RenewTime:            &metav1.MicroTime{Time: time.Now()},
+		},
+	})
+
+	server.runSigningLease()
+	defer server.signingLeaseCancel()
+
+	time.Sleep(time.Second)
+	if server.signingLeaseHeld.Load() {
+		t.Fatal("signing lease was acquired while held by another pod")
+	}
+}
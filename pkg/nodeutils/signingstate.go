@@ -0,0 +1,96 @@
+package nodeutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PrivValidatorStateFilename is the name of the file, within the data directory, where CometBFT keeps the
+// last height, round and step signed by the validator.
+const PrivValidatorStateFilename = "priv_validator_state.json"
+
+// SigningState is the last height, round and step signed by a validator, as stored in priv_validator_state.json.
+type SigningState struct {
+	Height int64 `json:"height,string"`
+	Round  int32 `json:"round"`
+	Step   int8  `json:"step"`
+}
+
+// IsBehind returns true if s is lower than other, comparing height, round and step in this order.
+func (s SigningState) IsBehind(other SigningState) bool {
+	if s.Height != other.Height {
+		return s.Height < other.Height
+	}
+	if s.Round != other.Round {
+		return s.Round < other.Round
+	}
+	return s.Step < other.Step
+}
+
+// ReadSigningState reads the signing state from the given priv_validator_state.json file.
+func ReadSigningState(path string) (*SigningState, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &SigningState{}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// EnsureSigningState makes sure the signing state in the given priv_validator_state.json file is not behind
+// minimum, overwriting it with minimum otherwise. The signature of the last signed message is not kept, so
+// CometBFT refuses to sign again at exactly that height, round and step. Returns true if the file was changed.
+func EnsureSigningState(path string, minimum SigningState) (bool, error) {
+	current, err := ReadSigningState(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	if current != nil && !current.IsBehind(minimum) {
+		return false, nil
+	}
+
+	b, err := json.MarshalIndent(minimum, "", "  ")
+	if err != nil {
+		return false, err
+	}
+
+	// Write to a temporary file first, so that the state file is never left partially written
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return false, err
+	}
+
+	log.WithFields(log.Fields{
+		"path":     path,
+		"current":  current,
+		"restored": minimum,
+	}).Warn("signing state on disk was behind the last known signing state and was restored")
+	return true, nil
+}
+
+// CheckSigningState ensures the signing state in the data directory is not behind the one stored in
+// minimumPath. A missing minimumPath means no signing state was recorded yet, so nothing is checked.
+func CheckSigningState(dataPath, minimumPath string) error {
+	minimum, err := ReadSigningState(minimumPath)
+	if errors.Is(err, os.ErrNotExist) {
+		log.WithField("path", minimumPath).Info("no signing state recorded yet")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = EnsureSigningState(filepath.Join(dataPath, PrivValidatorStateFilename), *minimum)
+	return err
+}
@@ -0,0 +1,86 @@
+package nodeutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSigningStateIsBehind(t *testing.T) {
+	state := SigningState{Height: 100, Round: 1, Step: 2}
+	tests := []struct {
+		other SigningState
+		want  bool
+	}{
+		{SigningState{Height: 101}, true},
+		{SigningState{Height: 99, Round: 5, Step: 3}, false},
+		{SigningState{Height: 100, Round: 2}, true},
+		{SigningState{Height: 100, Round: 0, Step: 3}, false},
+		{SigningState{Height: 100, Round: 1, Step: 3}, true},
+		{state, false},
+	}
+	for _, tt := range tests {
+		if got := state.IsBehind(tt.other); got != tt.want {
+			t.Errorf("%+v.IsBehind(%+v) = %v, want %v", state, tt.other, got, tt.want)
+		}
+	}
+}
+
+func TestEnsureSigningState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), PrivValidatorStateFilename)
+	if err := os.WriteFile(path, []byte(`{"height":"100","round":0,"step":3,"signature":"abc"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// State on disk is ahead of the minimum, so it is kept as is
+	changed, err := EnsureSigningState(path, SigningState{Height: 90})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Fatal("signing state was changed while ahead of the minimum")
+	}
+
+	changed, err = EnsureSigningState(path, SigningState{Height: 120, Round: 1, Step: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("signing state was not changed while behind the minimum")
+	}
+	state, err := ReadSigningState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (SigningState{Height: 120, Round: 1, Step: 2}); *state != want {
+		t.Fatalf("signing state = %+v, want %+v", *state, want)
+	}
+}
+
+func TestCheckSigningState(t *testing.T) {
+	dataPath := t.TempDir()
+	minimumPath := filepath.Join(t.TempDir(), PrivValidatorStateFilename)
+
+	// Nothing is done while no signing state was recorded
+	if err := CheckSigningState(dataPath, minimumPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dataPath, PrivValidatorStateFilename)); !os.IsNotExist(err) {
+		t.Fatalf("signing state was written without a recorded minimum: %v", err)
+	}
+
+	// A missing state on disk (e.g. a fresh data volume) is restored from the recorded one
+	if err := os.WriteFile(minimumPath, []byte(`{"height":"50","round":0,"step":3}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSigningState(dataPath, minimumPath); err != nil {
+		t.Fatal(err)
+	}
+	state, err := ReadSigningState(filepath.Join(dataPath, PrivValidatorStateFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Height != 50 {
+		t.Fatalf("restored height = %d, want 50", state.Height)
+	}
+}
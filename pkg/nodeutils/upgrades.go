@@ -16,6 +16,9 @@ type UpgradeSource string
 const (
 	UpgradeScheduled = "scheduled"
 
+	// UpgradeInfoFilename is the file x/upgrade writes to the data directory when the node halts for an upgrade.
+	UpgradeInfoFilename = "upgrade-info.json"
+
 	ManualUpgrade  UpgradeSource = "manual"
 	OnChainUpgrade UpgradeSource = "on-chain"
 )
@@ -27,6 +30,10 @@ type UpgradeChecker struct {
 
 type UpgradesConfig struct {
 	Upgrades []Upgrade `json:"upgrades"`
+
+	// SnapshotHeight is the height at which the node is halted for a scheduled snapshot, once the block at that
+	// height is committed. Zero when no snapshot is scheduled.
+	SnapshotHeight int64 `json:"snapshotHeight,omitempty"`
 }
 
 type Upgrade struct {
@@ -36,6 +43,66 @@ type Upgrade struct {
 	Source UpgradeSource `json:"source"`
 }
 
+// UpgradeInfo holds the upgrade plan written by x/upgrade to upgrade-info.json.
+type UpgradeInfo struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+	Info   string `json:"info,omitempty"`
+}
+
+// ReadUpgradeInfo reads an upgrade-info.json file.
+func ReadUpgradeInfo(file string) (*UpgradeInfo, error) {
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	info := &UpgradeInfo{}
+	if err := json.Unmarshal(body, info); err != nil {
+		return nil, err
+	}
+	if info.Name == "" || info.Height <= 0 {
+		return nil, fmt.Errorf("invalid upgrade info: %s", string(body))
+	}
+	return info, nil
+}
+
+// WatchUpgradeInfoFile calls onUpgrade whenever x/upgrade writes the given upgrade-info.json file. An existing
+// file is ignored until it is written again, since it is kept after the upgrade is applied.
+func WatchUpgradeInfoFile(file string, onUpgrade func(*UpgradeInfo)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		return err
+	}
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return fmt.Errorf("could not retrieve event")
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(file) || !event.Has(fsnotify.Create|fsnotify.Write) {
+				continue
+			}
+			info, err := ReadUpgradeInfo(file)
+			if err != nil {
+				// The file may still be being written, so wait for the next event
+				log.WithError(err).Debug("could not read upgrade info")
+				continue
+			}
+			onUpgrade(info)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return fmt.Errorf("could not retrieve error")
+			}
+			return err
+		}
+	}
+}
+
 func NewUpgradeChecker(configFile string) (*UpgradeChecker, error) {
 	if _, err := os.Stat(configFile); err != nil {
 		return nil, fmt.Errorf("configuration file does not exist: %v", err)
@@ -86,7 +153,13 @@ func (u *UpgradeChecker) loadConfig() error {
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(body, &u.config)
+	// Unmarshal into an empty config so that fields removed from the file are reset
+	config := UpgradesConfig{}
+	if err := json.Unmarshal(body, &config); err != nil {
+		return err
+	}
+	u.config = config
+	return nil
 }
 
 func (u *UpgradeChecker) ShouldUpgrade(height int64) bool {
@@ -102,3 +175,23 @@ func (u *UpgradeChecker) GetUpgrade(height int64) (*Upgrade, error) {
 	}
 	return nil, fmt.Errorf("upgrade not found")
 }
+
+// ShouldHaltForSnapshot returns true if the node must be halted at height for a scheduled snapshot, which is
+// the case once the block at the snapshot height was committed.
+func (u *UpgradeChecker) ShouldHaltForSnapshot(height int64) bool {
+	return u.config.SnapshotHeight > 0 && height > u.config.SnapshotHeight
+}
+
+// PendingUpgradeHeight returns the lowest height of a scheduled upgrade, or zero when none is scheduled.
+func (u *UpgradeChecker) PendingUpgradeHeight() int64 {
+	var pending int64
+	for _, upgrade := range u.config.Upgrades {
+		if upgrade.Status != UpgradeScheduled {
+			continue
+		}
+		if pending == 0 || upgrade.Height < pending {
+			pending = upgrade.Height
+		}
+	}
+	return pending
+}
@@ -0,0 +1,108 @@
+package nodeutils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchUpgradeInfoFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, UpgradeInfoFilename)
+
+	// A file left from a previous upgrade must not be reported
+	if err := os.WriteFile(file, []byte(`{"name":"v1","height":500}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgrades := make(chan *UpgradeInfo, 10)
+	go func() {
+		_ = WatchUpgradeInfoFile(file, func(info *UpgradeInfo) { upgrades <- info })
+	}()
+
+	// Give the watcher some time to start
+	time.Sleep(100 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(dir, "priv_validator_state.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(`{"name":"v2","height":1000,"info":"{}"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case info := <-upgrades:
+		if info.Name != "v2" || info.Height != 1000 || info.Info != "{}" {
+			t.Fatalf("unexpected upgrade info: %+v", info)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("upgrade info was not reported")
+	}
+}
+
+func TestReadUpgradeInfoRejectsIncompleteFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), UpgradeInfoFilename)
+	if err := os.WriteFile(file, []byte(`{"name":"v2"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadUpgradeInfo(file); err == nil {
+		t.Fatal("expected error for upgrade info without height")
+	}
+}
+
+func TestMustUpgradeReportsUpgradeInfo(t *testing.T) {
+	server := &NodeUtils{}
+
+	response := httptest.NewRecorder()
+	server.mustUpgrade(response, httptest.NewRequest(http.MethodGet, "/must_upgrade", nil))
+	if response.Code != http.StatusOK || response.Body.String() != "false" {
+		t.Fatalf("plain response = %d %q, want %d %q", response.Code, response.Body.String(), http.StatusOK, "false")
+	}
+
+	server.setUpgradeRequired(&UpgradeInfo{Name: "v2", Height: 1000})
+
+	response = httptest.NewRecorder()
+	server.mustUpgrade(response, httptest.NewRequest(http.MethodGet, "/must_upgrade", nil))
+	if response.Code != http.StatusUpgradeRequired || response.Body.String() != "true" {
+		t.Fatalf("plain response = %d %q, want %d %q", response.Code, response.Body.String(), http.StatusUpgradeRequired, "true")
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/must_upgrade", nil)
+	request.Header.Set("Accept", "application/json")
+	response = httptest.NewRecorder()
+	server.mustUpgrade(response, request)
+	want := `{"mustUpgrade":true,"name":"v2","height":1000}`
+	if response.Code != http.StatusUpgradeRequired || response.Body.String() != want {
+		t.Fatalf("json response = %d %s, want %d %s", response.Code, response.Body.String(), http.StatusUpgradeRequired, want)
+	}
+}
+
+func TestUpgradeCheckerSnapshotHeight(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "upgrades.json")
+	if err := os.WriteFile(file, []byte(`{"upgrades":[],"snapshotHeight":1000}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	checker, err := NewUpgradeChecker(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checker.ShouldHaltForSnapshot(1000) {
+		t.Fatal("node must not halt before the snapshot height is committed")
+	}
+	if !checker.ShouldHaltForSnapshot(1001) {
+		t.Fatal("node must halt once the snapshot height is committed")
+	}
+
+	// Removing the snapshot height from the file stops halting the node
+	if err := os.WriteFile(file, []byte(`{"upgrades":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if checker.ShouldHaltForSnapshot(1001) {
+		t.Fatal("node must not halt without a snapshot height")
+	}
+}
@@ -0,0 +1,361 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// HeightHeader is the header (or gRPC metadata) used by Cosmos SDK clients to query state at a given height.
+	HeightHeader = "x-cosmos-block-height"
+
+	// heightStatusInterval is the interval at which the latest height is retrieved from recent upstreams.
+	heightStatusInterval = 5 * time.Second
+
+	// heightStatusTimeout is the timeout of each request for the latest height.
+	heightStatusTimeout = 5 * time.Second
+
+	// maxJSONRPCBodySize is the maximum size of a JSON-RPC request body inspected for a height. Larger
+	// bodies are proxied without being inspected.
+	maxJSONRPCBodySize = 1 << 20
+)
+
+// HeightConfig configures a height-aware proxy.
+type HeightConfig struct {
+	// RecentHosts are the hosts that serve recent heights only (e.g. pruned nodes).
+	RecentHosts []string
+
+	// ArchiveHosts are the hosts that serve all heights.
+	ArchiveHosts []string
+
+	// RecentBlocks is the number of most recent blocks available on recent hosts. Requests for older heights
+	// are sent to archive hosts.
+	RecentBlocks int64
+
+	// HTTPPorts are the ports proxied over HTTP/1.1 (e.g. RPC, LCD and EVM RPC). Requests are forwarded to the
+	// same port on the upstream hosts.
+	HTTPPorts []int
+
+	// GRPCPorts are the ports proxied over cleartext HTTP/2. Requests are forwarded to the same port on the
+	// upstream hosts.
+	GRPCPorts []int
+
+	// StatusPort is the CometBFT RPC port of recent hosts, from which the latest height is retrieved.
+	StatusPort int
+}
+
+// Height is a reverse proxy that sends requests for old heights to archive hosts and all other requests to
+// recent hosts. The height is taken from the x-cosmos-block-height header, the height query parameter or the
+// height parameter of JSON-RPC requests.
+type Height struct {
+	cfg     HeightConfig
+	latest  atomic.Int64
+	next    atomic.Uint64
+	dial    func(context.Context, string, string) (net.Conn, error)
+	mu      sync.Mutex
+	servers []*http.Server
+	stopped bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+type upstreamKey struct{}
+
+func NewHeightProxy(cfg HeightConfig) (*Height, error) {
+	if len(cfg.RecentHosts) == 0 {
+		return nil, errors.New("at least one recent host is required")
+	}
+	if len(cfg.ArchiveHosts) == 0 {
+		return nil, errors.New("at least one archive host is required")
+	}
+	if cfg.RecentBlocks <= 0 {
+		return nil, fmt.Errorf("recent blocks must be positive, got %d", cfg.RecentBlocks)
+	}
+	if len(cfg.HTTPPorts)+len(cfg.GRPCPorts) == 0 {
+		return nil, errors.New("at least one port is required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	return &Height{
+		cfg:    cfg,
+		dial:   dialer.DialContext,
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+// Start listens on all configured ports and tracks the latest height until Stop is called or a listener fails.
+func (p *Height) Start() error {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return ErrStopped
+	}
+	for _, port := range p.cfg.HTTPPorts {
+		p.servers = append(p.servers, p.newServer(port, false))
+	}
+	for _, port := range p.cfg.GRPCPorts {
+		p.servers = append(p.servers, p.newServer(port, true))
+	}
+	servers := p.servers
+	p.mu.Unlock()
+
+	go p.trackLatestHeight()
+
+	errs := make(chan error, len(servers))
+	for _, srv := range servers {
+		log.Infof("starting height proxy at %s", srv.Addr)
+		go func(srv *http.Server) {
+			errs <- srv.ListenAndServe()
+		}(srv)
+	}
+
+	err := <-errs
+	p.mu.Lock()
+	stopped := p.stopped
+	p.mu.Unlock()
+	if stopped {
+		return ErrStopped
+	}
+	_ = p.Stop()
+	return err
+}
+
+func (p *Height) Stop() error {
+	p.mu.Lock()
+	p.stopped = true
+	p.cancel()
+	servers := p.servers
+	p.mu.Unlock()
+
+	var errs []error
+	for _, srv := range servers {
+		if err := srv.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Height) newServer(port int, grpc bool) *http.Server {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           p.handler(port, grpc),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if grpc {
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return srv
+}
+
+// handler returns the handler of a port, which forwards each request to the same port on the chosen upstream.
+func (p *Height) handler(port int, grpc bool) http.Handler {
+	transport := &http.Transport{
+		DialContext:         p.dial,
+		MaxIdleConnsPerHost: 100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if grpc {
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			host, _ := r.In.Context().Value(upstreamKey{}).(string)
+			r.SetURL(&url.URL{Scheme: "http", Host: net.JoinHostPort(host, strconv.Itoa(port))})
+			r.SetXForwarded()
+		},
+		Transport:     transport,
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.WithField("upstream", r.Context().Value(upstreamKey{})).Warnf("height proxy request failed: %v", err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		height := requestHeight(r, !grpc)
+		host := p.upstream(height)
+		log.WithFields(log.Fields{
+			"path":     r.URL.Path,
+			"height":   height,
+			"upstream": host,
+		}).Trace("proxying request")
+		proxy.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), upstreamKey{}, host)))
+	})
+}
+
+// upstream returns the host a request for the given height is sent to. Height 0 means the latest height.
+func (p *Height) upstream(height int64) string {
+	hosts := p.cfg.RecentHosts
+	if p.isHistorical(height) {
+		hosts = p.cfg.ArchiveHosts
+	}
+	return hosts[p.next.Add(1)%uint64(len(hosts))]
+}
+
+// isHistorical returns true if the given height might not be available on recent hosts anymore. While the
+// latest height is unknown, any explicit height is considered historical, as only archive hosts are sure to
+// have it.
+func (p *Height) isHistorical(height int64) bool {
+	if height <= 0 {
+		return false
+	}
+	latest := p.latest.Load()
+	return latest == 0 || height <= latest-p.cfg.RecentBlocks
+}
+
+// trackLatestHeight periodically retrieves the latest height from recent hosts until the proxy is stopped.
+func (p *Height) trackLatestHeight() {
+	client := &http.Client{
+		Timeout:   heightStatusTimeout,
+		Transport: &http.Transport{DialContext: p.dial},
+	}
+	ticker := time.NewTicker(heightStatusInterval)
+	defer ticker.Stop()
+
+	for {
+		p.updateLatestHeight(client)
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateLatestHeight sets the latest height to the highest one reported by recent hosts. The previous value is
+// kept if none of them can be reached.
+func (p *Height) updateLatestHeight(client *http.Client) {
+	var latest int64
+	for _, host := range p.cfg.RecentHosts {
+		height, err := fetchLatestHeight(p.ctx, client, fmt.Sprintf("http://%s", net.JoinHostPort(host, strconv.Itoa(p.cfg.StatusPort))))
+		if err != nil {
+			log.WithField("host", host).Warnf("failed to get latest height: %v", err)
+			continue
+		}
+		latest = max(latest, height)
+	}
+	if latest > 0 {
+		p.latest.Store(latest)
+	}
+}
+
+// fetchLatestHeight returns the latest height reported by the CometBFT RPC at the given URL.
+func fetchLatestHeight(ctx context.Context, client *http.Client, rpc string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rpc+"/status", nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var status struct {
+		Result struct {
+			SyncInfo struct {
+				LatestBlockHeight string `json:"latest_block_height"`
+			} `json:"sync_info"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return 0, fmt.Errorf("error decoding status: %w", err)
+	}
+	return strconv.ParseInt(status.Result.SyncInfo.LatestBlockHeight, 10, 64)
+}
+
+// requestHeight returns the height a request is made at, or 0 for the latest height. When inspectBody is set,
+// the height parameter of JSON-RPC requests is considered as well, and the body is restored after being read.
+func requestHeight(r *http.Request, inspectBody bool) int64 {
+	if height := parseHeight(r.Header.Get(HeightHeader)); height > 0 {
+		return height
+	}
+	if height := parseHeight(r.URL.Query().Get("height")); height > 0 {
+		return height
+	}
+	if !inspectBody || r.Method != http.MethodPost || r.Body == nil || r.Body == http.NoBody {
+		return 0
+	}
+
+	b, err := io.ReadAll(io.LimitReader(r.Body, maxJSONRPCBodySize+1))
+	r.Body = readCloser{io.MultiReader(bytes.NewReader(b), r.Body), r.Body}
+	if err != nil || len(b) > maxJSONRPCBodySize {
+		return 0
+	}
+	return jsonRPCHeight(b)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// jsonRPCHeight returns the height parameter of a JSON-RPC request. For batches, the lowest height is returned,
+// so that the whole batch is sent to hosts that have all the requested heights.
+func jsonRPCHeight(body []byte) int64 {
+	type request struct {
+		Params json.RawMessage `json:"params"`
+	}
+
+	var requests []request
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return 0
+		}
+	} else {
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			return 0
+		}
+		requests = []request{req}
+	}
+
+	var lowest int64
+	for _, req := range requests {
+		var params map[string]json.RawMessage
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			continue
+		}
+		height := parseHeight(string(params["height"]))
+		if height > 0 && (lowest == 0 || height < lowest) {
+			lowest = height
+		}
+	}
+	return lowest
+}
+
+// parseHeight parses a height given as a number or a (quoted) string. Invalid heights are returned as 0.
+func parseHeight(s string) int64 {
+	height, err := strconv.ParseInt(strings.Trim(s, `"`), 10, 64)
+	if err != nil || height < 0 {
+		return 0
+	}
+	return height
+}
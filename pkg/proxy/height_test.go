@@ -0,0 +1,313 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHeightProxy(t *testing.T) {
+	valid := HeightConfig{
+		RecentHosts:  []string{"recent"},
+		ArchiveHosts: []string{"archive"},
+		RecentBlocks: 100,
+		HTTPPorts:    []int{26657},
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*HeightConfig)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*HeightConfig) {}},
+		{name: "grpc only", modify: func(c *HeightConfig) { c.HTTPPorts, c.GRPCPorts = nil, []int{9090} }},
+		{name: "no recent hosts", modify: func(c *HeightConfig) { c.RecentHosts = nil }, wantErr: true},
+		{name: "no archive hosts", modify: func(c *HeightConfig) { c.ArchiveHosts = nil }, wantErr: true},
+		{name: "no recent blocks", modify: func(c *HeightConfig) { c.RecentBlocks = 0 }, wantErr: true},
+		{name: "no ports", modify: func(c *HeightConfig) { c.HTTPPorts = nil }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			_, err := NewHeightProxy(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHeightProxy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestHeight(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		header      string
+		body        string
+		inspectBody bool
+		want        int64
+	}{
+		{name: "no height", method: http.MethodGet, target: "/status", want: 0},
+		{name: "header", method: http.MethodGet, target: "/cosmos/bank/v1beta1/balances/addr", header: "120", want: 120},
+		{name: "query parameter", method: http.MethodGet, target: "/block?height=150", want: 150},
+		{name: "quoted query parameter", method: http.MethodGet, target: `/block?height="150"`, want: 150},
+		{name: "invalid query parameter", method: http.MethodGet, target: "/block?height=abc", want: 0},
+		{name: "header takes precedence", method: http.MethodGet, target: "/block?height=150", header: "120", want: 120},
+		{
+			name:        "json-rpc string height",
+			method:      http.MethodPost,
+			target:      "/",
+			body:        `{"jsonrpc":"2.0","id":1,"method":"block","params":{"height":"200"}}`,
+			inspectBody: true,
+			want:        200,
+		},
+		{
+			name:        "json-rpc numeric height",
+			method:      http.MethodPost,
+			target:      "/",
+			body:        `{"jsonrpc":"2.0","id":1,"method":"block","params":{"height":200}}`,
+			inspectBody: true,
+			want:        200,
+		},
+		{
+			name:        "json-rpc batch uses lowest height",
+			method:      http.MethodPost,
+			target:      "/",
+			body:        `[{"method":"block","params":{"height":"300"}},{"method":"status"},{"method":"block","params":{"height":"250"}}]`,
+			inspectBody: true,
+			want:        250,
+		},
+		{
+			name:        "json-rpc positional params",
+			method:      http.MethodPost,
+			target:      "/",
+			body:        `{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["0x0","latest"]}`,
+			inspectBody: true,
+			want:        0,
+		},
+		{
+			name:   "body not inspected",
+			method: http.MethodPost,
+			target: "/",
+			body:   `{"jsonrpc":"2.0","id":1,"method":"block","params":{"height":"200"}}`,
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.header != "" {
+				r.Header.Set(HeightHeader, tt.header)
+			}
+			if got := requestHeight(r, tt.inspectBody); got != tt.want {
+				t.Errorf("requestHeight() = %d, want %d", got, tt.want)
+			}
+
+			// The body must still be available to the upstream
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.body {
+				t.Errorf("body = %q, want %q", b, tt.body)
+			}
+		})
+	}
+}
+
+func TestHeightIsHistorical(t *testing.T) {
+	p, err := NewHeightProxy(HeightConfig{
+		RecentHosts:  []string{"recent"},
+		ArchiveHosts: []string{"archive"},
+		RecentBlocks: 100,
+		HTTPPorts:    []int{26657},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Any explicit height is historical while the latest height is unknown
+	if !p.isHistorical(500) {
+		t.Error("expected height to be historical while the latest height is unknown")
+	}
+	if p.isHistorical(0) {
+		t.Error("expected latest height not to be historical")
+	}
+
+	p.latest.Store(1000)
+	tests := map[int64]bool{0: false, 1: true, 900: true, 901: false, 1000: false, 1500: false}
+	for height, want := range tests {
+		if got := p.isHistorical(height); got != want {
+			t.Errorf("isHistorical(%d) = %v, want %v", height, got, want)
+		}
+	}
+}
+
+// newTestHeightProxy returns a proxy whose upstream hosts are resolved to the given test servers.
+func newTestHeightProxy(t *testing.T, port int, upstreams map[string]*httptest.Server) *Height {
+	t.Helper()
+	p, err := NewHeightProxy(HeightConfig{
+		RecentHosts:  []string{"recent"},
+		ArchiveHosts: []string{"archive"},
+		RecentBlocks: 100,
+		HTTPPorts:    []int{port},
+		StatusPort:   port,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dialer := &net.Dialer{}
+	p.dial = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		srv, ok := upstreams[host]
+		if !ok {
+			t.Errorf("unexpected upstream %s", addr)
+			return nil, &net.AddrError{Err: "unknown host", Addr: addr}
+		}
+		return dialer.DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+	return p
+}
+
+func newNamedUpstream(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/status" {
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":-1,"result":{"sync_info":{"latest_block_height":"1000"}}}`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Upstream", name)
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestHeightHandler(t *testing.T) {
+	recent := newNamedUpstream("recent")
+	defer recent.Close()
+	archive := newNamedUpstream("archive")
+	defer archive.Close()
+
+	p := newTestHeightProxy(t, 26657, map[string]*httptest.Server{"recent": recent, "archive": archive})
+	p.updateLatestHeight(&http.Client{Timeout: time.Second, Transport: &http.Transport{DialContext: p.dial}})
+	if got := p.latest.Load(); got != 1000 {
+		t.Fatalf("latest height = %d, want 1000", got)
+	}
+
+	front := httptest.NewServer(p.handler(26657, false))
+	defer front.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		body   string
+		want   string
+	}{
+		{name: "latest", method: http.MethodGet, path: "/abci_info", want: "recent"},
+		{name: "recent height", method: http.MethodGet, path: "/block?height=950", want: "recent"},
+		{name: "old height", method: http.MethodGet, path: "/block?height=10", want: "archive"},
+		{name: "old height header", method: http.MethodGet, path: "/cosmos/bank/v1beta1/supply", header: "10", want: "archive"},
+		{
+			name:   "old height json-rpc",
+			method: http.MethodPost,
+			path:   "/",
+			body:   `{"jsonrpc":"2.0","id":1,"method":"block","params":{"height":"10"}}`,
+			want:   "archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, front.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.header != "" {
+				req.Header.Set(HeightHeader, tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			if got := resp.Header.Get("X-Upstream"); got != tt.want {
+				t.Errorf("upstream = %q, want %q", got, tt.want)
+			}
+			if tt.body != "" && string(body) != tt.body {
+				t.Errorf("upstream received body %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestHeightHandlerGRPC(t *testing.T) {
+	newH2CUpstream := func(name string) *httptest.Server {
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ProtoMajor != 2 {
+				t.Errorf("expected HTTP/2 upstream request, got %s", r.Proto)
+			}
+			w.Header().Set("X-Upstream", name)
+			w.Header().Set("Trailer", "Grpc-Status")
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Grpc-Status", "0")
+		}))
+		srv.Config.Protocols = new(http.Protocols)
+		srv.Config.Protocols.SetUnencryptedHTTP2(true)
+		srv.Start()
+		return srv
+	}
+	recent := newH2CUpstream("recent")
+	defer recent.Close()
+	archive := newH2CUpstream("archive")
+	defer archive.Close()
+
+	p := newTestHeightProxy(t, 9090, map[string]*httptest.Server{"recent": recent, "archive": archive})
+	p.latest.Store(1000)
+
+	front := httptest.NewUnstartedServer(p.handler(9090, true))
+	front.Config.Protocols = new(http.Protocols)
+	front.Config.Protocols.SetUnencryptedHTTP2(true)
+	front.Start()
+	defer front.Close()
+
+	client := &http.Client{Transport: &http.Transport{Protocols: new(http.Protocols)}}
+	client.Transport.(*http.Transport).Protocols.SetUnencryptedHTTP2(true)
+
+	for height, want := range map[string]string{"": "recent", "10": "archive"} {
+		req, err := http.NewRequest(http.MethodPost, front.URL+"/cosmos.bank.v1beta1.Query/Balance", strings.NewReader(""))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/grpc")
+		if height != "" {
+			req.Header.Set(HeightHeader, height)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if got := resp.Header.Get("X-Upstream"); got != want {
+			t.Errorf("height %q: upstream = %q, want %q", height, got, want)
+		}
+		if got := resp.Trailer.Get("Grpc-Status"); got != "0" {
+			t.Errorf("height %q: Grpc-Status trailer = %q, want 0", height, got)
+		}
+	}
+}